@@ -0,0 +1,166 @@
+package doco
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBackupUnsupported is returned for databases the backup endpoint can't snapshot
+var ErrBackupUnsupported = errors.New("backups need sqlite3, use pg_dump for postgres")
+
+// adminOnly rejects authenticated callers who aren't admins
+func (c *API) adminOnly(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok || !claims.Admin {
+			c.writeError(w, r, ErrForbidden, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+func (c *API) migrationStatusHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		status, err := Status(c.conn)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return status, http.StatusOK, nil
+	}
+	return fn
+}
+
+// backupHandler streams a consistent snapshot of a SQLite database. VACUUM INTO
+// copies within a read transaction, so writes carrying on meanwhile can't tear it.
+func (c *API) backupHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if c.conn.DriverName() != DriverSQLite {
+			c.writeError(w, r, ErrBackupUnsupported, http.StatusNotImplemented)
+			return
+		}
+		dir, err := ioutil.TempDir("", "doco-backup")
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "doco.db")
+		_, err = c.conn.ExecContext(r.Context(), "VACUUM INTO ?", path)
+		if err != nil {
+			c.writeError(w, r, fmt.Errorf("backup: %w", err), http.StatusInternalServerError)
+			return
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+
+		fileName := "doco-" + time.Now().UTC().Format("20060102T150405Z") + ".db"
+		w.Header().Set("Content-Type", "application/vnd.sqlite3")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+		w.Header().Set("Cache-Control", "no-store")
+		_, err = io.Copy(w, f)
+		if err != nil {
+			c.log.Errorw("backup", "err", err)
+			return
+		}
+		c.audit(r, AuditBackup, fileName)
+		c.log.Infow("database backed up", "file_name", fileName, "size", info.Size())
+	}
+	return fn
+}
+
+// ErrVacuumUnsupported is returned for databases the vacuum endpoint can't compact
+var ErrVacuumUnsupported = errors.New("vacuum needs sqlite3, postgres reclaims space with autovacuum")
+
+// ErrVacuumRunning is returned while another vacuum is still going
+var ErrVacuumRunning = errors.New("vacuum already running")
+
+// sqliteFileSize is the main database file and its write-ahead log together,
+// zero for in-memory databases
+func (c *API) sqliteFileSize(ctx context.Context) (int64, error) {
+	var path string
+	err := c.conn.QueryRowContext(ctx, "SELECT file FROM pragma_database_list WHERE name = 'main'").Scan(&path)
+	if err != nil {
+		return 0, err
+	}
+	if path == "" {
+		return 0, nil
+	}
+	size := int64(0)
+	for _, name := range []string{path, path + "-wal"} {
+		info, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
+
+// vacuumHandler rebuilds a SQLite database to give the pages freed by deletes back
+// to the filesystem. In WAL mode readers carry on meanwhile, writers wait on the
+// busy timeout and may fail if the vacuum outlasts it. The log is checkpointed
+// afterwards so the main file shrinks straight away.
+func (c *API) vacuumHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			BytesBefore    int64 `json:"bytes_before"`
+			BytesAfter     int64 `json:"bytes_after"`
+			BytesReclaimed int64 `json:"bytes_reclaimed"`
+		}
+
+		if c.conn.DriverName() != DriverSQLite {
+			return nil, http.StatusNotImplemented, ErrVacuumUnsupported
+		}
+		if !atomic.CompareAndSwapInt32(&c.vacuuming, 0, 1) {
+			return nil, http.StatusConflict, ErrVacuumRunning
+		}
+		defer atomic.StoreInt32(&c.vacuuming, 0)
+
+		before, err := c.sqliteFileSize(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("vacuum: %w", err)
+		}
+		start := time.Now()
+		_, err = c.conn.ExecContext(r.Context(), "VACUUM")
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("vacuum: %w", err)
+		}
+		_, err = c.conn.ExecContext(r.Context(), "PRAGMA wal_checkpoint(TRUNCATE)")
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("vacuum checkpoint: %w", err)
+		}
+		after, err := c.sqliteFileSize(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("vacuum: %w", err)
+		}
+
+		c.log.Infow("database vacuumed", "bytes_before", before, "bytes_after", after, "duration", time.Since(start))
+		return &Response{BytesBefore: before, BytesAfter: after, BytesReclaimed: before - after}, http.StatusOK, nil
+	}
+	return fn
+}
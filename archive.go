@@ -0,0 +1,170 @@
+package doco
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"doco/db"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// maxArchiveBlobs bounds one archive, bigger selections should be split
+const maxArchiveBlobs = 500
+
+// archiveEntryName keeps a blob's name from escaping the folder the zip is extracted into
+var archiveEntryName = strings.NewReplacer("/", "_", "\\", "_")
+
+// copyBlob writes a blob's plaintext to dst. Like serveBlob, small and legacy
+// blobs are checked against their checksum and segmented ones are streamed.
+func (c *API) copyBlob(ctx context.Context, dst io.Writer, blob *db.Blob) (int64, error) {
+	if blob.SegmentSize == 0 || blob.FileSizeBytes <= segmentSize {
+		b, err := openBlob(ctx, c.store, blobKey(blob), c.masterKey, blob)
+		if err != nil {
+			return 0, err
+		}
+		if blob.Compressed {
+			b, err = gunzipBytes(b)
+			if err != nil {
+				return 0, err
+			}
+		}
+		checksum := blobChecksum(b)
+		if blob.Checksum != "" && blob.Checksum != checksum {
+			return 0, fmt.Errorf("%w: %s expected %s got %s", ErrChecksumMismatch, blob.FileName, blob.Checksum, checksum)
+		}
+		n, err := dst.Write(b)
+		return int64(n), err
+	}
+
+	rc, err := c.store.Get(ctx, blobKey(blob))
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	src, size, err := readerAt(rc)
+	if err != nil {
+		return 0, err
+	}
+	segments, err := openSegments(c.masterKey, blob, src, size)
+	if err != nil {
+		return 0, err
+	}
+	var plaintext io.Reader = &contextReadSeeker{ctx: ctx, ReadSeeker: segments}
+	if blob.Compressed {
+		zr, err := gzip.NewReader(plaintext)
+		if err != nil {
+			return 0, err
+		}
+		defer zr.Close()
+		plaintext = zr
+	}
+	return io.Copy(dst, plaintext)
+}
+
+// blobArchiveHandler zips the named blobs, or the blobs carrying every given tag,
+// into one download. Entries are written as they are decrypted so memory stays
+// bounded. Once the first entry is sent the status can't change, a later failure
+// cuts the archive short and is only logged.
+func (c *API) blobArchiveHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		req := &blobSelection{}
+		err := decodeJSON(r, req)
+		if err != nil {
+			c.writeError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		err = req.validate(maxArchiveBlobs)
+		if err != nil {
+			c.writeError(w, r, err, http.StatusUnprocessableEntity)
+			return
+		}
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			c.writeError(w, r, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+
+		// columns are qualified as tags has some of the same names
+		columns := []string{}
+		for _, column := range blobColumnsWithoutFile {
+			columns = append(columns, db.TableNames.Blobs+"."+column)
+		}
+		filters := append(req.filters(),
+			qm.Select(columns...),
+			notExpired(time.Now()),
+			notTrashed(),
+			qm.OrderBy(db.TableNames.Blobs+"."+db.BlobColumns.FileName),
+			qm.Limit(maxArchiveBlobs+1),
+		)
+		if !claims.Admin {
+			filters = append(filters, db.BlobWhere.OwnerID.EQ(null.Int64From(claims.UserID)))
+		}
+		blobs, err := db.Blobs(filters...).AllG(r.Context())
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		if len(blobs) > maxArchiveBlobs {
+			c.writeError(w, r, ValidationErr(map[string]string{"tags": fmt.Sprintf("match more than %d blobs", maxArchiveBlobs)}), http.StatusUnprocessableEntity)
+			return
+		}
+		// a named blob that is missing, expired or someone else's fails the whole archive
+		if missing := req.missing(blobs); len(missing) > 0 {
+			c.writeError(w, r, fmt.Errorf("%w: %s", ErrBlobNotFound, strings.Join(missing, ", ")), http.StatusNotFound)
+			return
+		}
+		if len(blobs) == 0 {
+			c.writeError(w, r, ErrBlobNotFound, http.StatusNotFound)
+			return
+		}
+		// the whole archive takes one download slot, its blobs are read one at a time
+		release, ok := c.acquireDownload(w, r)
+		if !ok {
+			return
+		}
+		defer release()
+
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", contentDisposition("attachment", "doco-"+time.Now().UTC().Format("20060102-150405")+".zip"))
+		w.Header().Set("Cache-Control", "no-store")
+		cw := &countingWriter{ResponseWriter: w}
+		zw := zip.NewWriter(cw)
+		for _, blob := range blobs {
+			header := &zip.FileHeader{
+				Name:     archiveEntryName.Replace(blob.FileName),
+				Method:   zip.Deflate,
+				Modified: blobModTime(blob),
+			}
+			// deflating already compressed formats costs CPU for nothing
+			if !shouldCompress(blob.MimeType) {
+				header.Method = zip.Store
+			}
+			entry, err := zw.CreateHeader(header)
+			if err == nil {
+				_, err = c.copyBlob(r.Context(), entry, blob)
+			}
+			if err != nil {
+				c.log.Errorw("blob archive cut short", "file_name", blob.FileName, "request_id", middleware.GetReqID(r.Context()), "err", err)
+				c.metrics.blobBytesServedTotal.Add(float64(cw.n))
+				return
+			}
+			c.metrics.blobDownloadsTotal.Inc()
+			c.audit(r, AuditArchive, blob.FileName)
+		}
+		err = zw.Close()
+		if err != nil {
+			c.log.Errorw("blob archive", "request_id", middleware.GetReqID(r.Context()), "err", err)
+		}
+		c.metrics.blobBytesServedTotal.Add(float64(cw.n))
+		c.log.Infow("blob archive served", "blobs", len(blobs), "bytes", cw.n)
+	}
+	return fn
+}
@@ -0,0 +1,130 @@
+package doco
+
+import (
+	"doco/db"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// Audited blob actions
+const (
+	AuditDownload = "download"
+	AuditUpload   = "upload"
+	AuditDelete   = "delete"
+	AuditRestore  = "restore"
+	AuditRename   = "rename"
+	AuditShare    = "share"
+	AuditBackup   = "backup"
+	AuditExport   = "export"
+	AuditImport   = "import"
+	// AuditArchive is a download as part of a zip archive
+	AuditArchive = "archive"
+
+	// AuditSharedDownload is a download through a share link, it has no user
+	AuditSharedDownload = "shared_download"
+)
+
+// audit records who did what to a blob. A failed write is logged rather than
+// failing the request, the action itself already happened.
+func (c *API) audit(r *http.Request, action, fileName string) {
+	entry := &db.AuditLog{
+		Action:       action,
+		BlobFileName: fileName,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if claims, ok := ClaimsFromContext(r.Context()); ok {
+		entry.UserID = null.Int64From(claims.UserID)
+		entry.Username = claims.Username
+	}
+	err := insertG(r.Context(), entry, nil)
+	if err != nil {
+		c.log.Errorw("audit log", "action", action, "file_name", fileName, "err", err)
+	}
+}
+
+// queryTime reads an optional RFC 3339 query param
+func queryTime(r *http.Request, key string) (time.Time, bool, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s: %q", key, v)
+	}
+	return t.UTC(), true, nil
+}
+
+// auditLogHandler pages through the audit log, newest first, optionally
+// filtered by action and a from/to created_at range
+func (c *API) auditLogHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Total   int64          `json:"total"`
+			Limit   int            `json:"limit"`
+			Offset  int            `json:"offset"`
+			Entries []*db.AuditLog `json:"entries"`
+		}
+
+		limit, err := queryInt(r, "limit", defaultBlobListLimit)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if limit == 0 {
+			limit = defaultBlobListLimit
+		}
+		if limit > maxBlobListLimit {
+			limit = maxBlobListLimit
+		}
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		filters := []qm.QueryMod{}
+		if action := r.URL.Query().Get("action"); action != "" {
+			filters = append(filters, db.AuditLogWhere.Action.EQ(action))
+		}
+		from, ok, err := queryTime(r, "from")
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if ok {
+			filters = append(filters, db.AuditLogWhere.CreatedAt.GTE(from))
+		}
+		to, ok, err := queryTime(r, "to")
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if ok {
+			filters = append(filters, db.AuditLogWhere.CreatedAt.LT(to))
+		}
+
+		total, err := db.AuditLogs(filters...).CountG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		entries, err := db.AuditLogs(append(filters,
+			qm.OrderBy(db.AuditLogColumns.CreatedAt+" desc, "+db.AuditLogColumns.ID+" desc"),
+			qm.Limit(limit),
+			qm.Offset(offset),
+		)...).AllG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		result := &Response{
+			Total:   total,
+			Limit:   limit,
+			Offset:  offset,
+			Entries: []*db.AuditLog{},
+		}
+		result.Entries = append(result.Entries, entries...)
+		return result, http.StatusOK, nil
+	}
+	return fn
+}
@@ -0,0 +1,242 @@
+package doco
+
+import (
+	"context"
+	"database/sql"
+	"doco/db"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/volatiletech/null"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnauthorized is returned when a request carries no valid credentials
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned when the caller may not touch the requested blob
+var ErrForbidden = errors.New("forbidden")
+
+// ErrInvalidCredentials is returned when the username or password is wrong
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrJWTSecretRequired is returned when the server is built without a secret to sign tokens and share links with
+var ErrJWTSecretRequired = errors.New("jwt secret is required")
+
+// tokenLifetime is how long an issued JWT stays valid
+const tokenLifetime = 24 * time.Hour
+
+type contextKey string
+
+const claimsKey contextKey = "claims"
+
+// Claims carried in the JWT issued to a user
+type Claims struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+	Admin    bool   `json:"admin"`
+	jwt.StandardClaims
+}
+
+// ClaimsFromContext returns the claims the auth middleware attached to the request
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*Claims)
+	return claims, ok
+}
+
+// canAccessBlob reports whether the caller owns the blob or is an admin. Blobs
+// uploaded before ownership was recorded have no owner, only admins reach them.
+func canAccessBlob(r *http.Request, blob *db.Blob) bool {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		return false
+	}
+	if claims.Admin {
+		return true
+	}
+	return blob.OwnerID.Valid && blob.OwnerID.Int64 == claims.UserID
+}
+
+func (c *API) parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return c.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, ErrUnauthorized
+	}
+	return claims, nil
+}
+
+// authMiddleware rejects requests without a valid API key, Bearer token or session cookie
+func (c *API) authMiddleware(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(apiKeyHeader); key != "" {
+			claims, err := apiKeyClaims(r.Context(), key)
+			if errors.Is(err, ErrUnauthorized) {
+				writeErrorResponse(w, r, Err(err, "invalid api key"), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				c.writeError(w, r, err, http.StatusInternalServerError)
+				return
+			}
+			ctx := context.WithValue(r.Context(), claimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		header := r.Header.Get("Authorization")
+		if cookie, err := r.Cookie(c.sessions.Cookie.Name); err == nil && header == "" {
+			claims, err := c.sessionClaims(r.Context(), cookie.Value)
+			if errors.Is(err, ErrUnauthorized) {
+				writeErrorResponse(w, r, Err(err, "invalid session"), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				c.writeError(w, r, err, http.StatusInternalServerError)
+				return
+			}
+			ctx := context.WithValue(r.Context(), claimsKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+		if !strings.HasPrefix(header, "Bearer ") {
+			writeErrorResponse(w, r, Err(ErrUnauthorized, "missing bearer token"), http.StatusUnauthorized)
+			return
+		}
+		claims, err := c.parseToken(strings.TrimPrefix(header, "Bearer "))
+		if err != nil {
+			writeErrorResponse(w, r, Err(err, "invalid token"), http.StatusUnauthorized)
+			return
+		}
+		// the token only names the user, whether they are still active and an
+		// admin is read from the users table as for API keys and sessions
+		user, err := userClaims(r.Context(), claims.UserID)
+		if errors.Is(err, ErrUnauthorized) {
+			writeErrorResponse(w, r, Err(err, "invalid token"), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		claims.Username = user.Username
+		claims.Admin = user.Admin
+		ctx := context.WithValue(r.Context(), claimsKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// HashPassword returns the bcrypt hash stored against a user
+func HashPassword(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (c *API) issueToken(user *db.User) (string, time.Time, error) {
+	expiresAt := time.Now().Add(tokenLifetime)
+	claims := &Claims{
+		UserID:   user.ID.Int64,
+		Username: user.Username,
+		Admin:    user.Admin,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt.Unix(),
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(c.jwtSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (c *API) loginHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Request struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		type Response struct {
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+
+		req := &Request{}
+		err := decodeJSON(r, req)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		user, code, err := authenticate(r.Context(), req.Username, req.Password)
+		if err != nil {
+			return nil, code, err
+		}
+
+		token, expiresAt, err := c.issueToken(user)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		c.log.Infow("user logged in", "username", user.Username)
+		return &Response{Token: token, ExpiresAt: expiresAt}, http.StatusOK, nil
+	}
+	return fn
+}
+
+// authenticate checks a username and password, returning the user and the status to fail with
+func authenticate(ctx context.Context, username, password string) (*db.User, int, error) {
+	fields := map[string]string{}
+	if username == "" {
+		fields["username"] = "required"
+	}
+	if password == "" {
+		fields["password"] = "required"
+	}
+	if len(fields) > 0 {
+		return nil, http.StatusUnprocessableEntity, ValidationErr(fields)
+	}
+
+	user, err := db.Users(
+		db.UserWhere.Username.EQ(username),
+		db.UserWhere.Archived.EQ(false),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, http.StatusUnauthorized, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		return nil, http.StatusUnauthorized, ErrInvalidCredentials
+	}
+	return user, http.StatusOK, nil
+}
+
+// userClaims returns the claims of a user who is still active
+func userClaims(ctx context.Context, userID int64) (*Claims, error) {
+	user, err := db.Users(
+		db.UserWhere.ID.EQ(null.Int64From(userID)),
+		db.UserWhere.Archived.EQ(false),
+	).OneG(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Claims{UserID: user.ID.Int64, Username: user.Username, Admin: user.Admin}, nil
+}
@@ -0,0 +1,450 @@
+package doco
+
+import (
+	"bytes"
+	"doco/db"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// maxBatchFiles bounds how many file parts one batch upload may carry
+const maxBatchFiles = 100
+
+// ErrBatchFailed is returned when any file of a batch upload can't be stored
+var ErrBatchFailed = errors.New("batch upload failed, no blobs were stored")
+
+// BatchResult reports the outcome of one file in a batch upload
+type BatchResult struct {
+	FileName      string `json:"file_name"`
+	MimeType      string `json:"mime_type,omitempty"`
+	FileSizeBytes int64  `json:"file_size_bytes,omitempty"`
+	Checksum      string `json:"checksum,omitempty"`
+	// Deduplicated is set when the caller already stored the contents, under FileName
+	Deduplicated bool `json:"deduplicated,omitempty"`
+	// Version is set when the file replaced an existing blob's contents
+	Version int64  `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type batchFile struct {
+	plan *uploadPlan
+	// kept and previous are the version a replaced blob's contents were kept as
+	// and its row before, so the replacement can be undone
+	kept     *db.BlobVersion
+	previous db.Blob
+}
+
+// prepareBatchFile reads one part and checks it by the same rules as a single
+// upload, returning the status to fail with. Each part may declare its size and
+// checksum in its own X-Blob-Size and X-Checksum-Sha256 headers.
+func (c *API) prepareBatchFile(r *http.Request, header *multipart.FileHeader, expiresAt null.Time, seen map[string]bool) (*batchFile, int, error) {
+	err := validFileName(header.Filename)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if seen[header.Filename] {
+		return nil, http.StatusBadRequest, errors.New("duplicate file name in batch")
+	}
+	seen[header.Filename] = true
+	if header.Size > c.maxBlobBytes {
+		return nil, http.StatusRequestEntityTooLarge, ErrBlobTooLarge
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	defer f.Close()
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	checksum := blobChecksum(b)
+	code, err := compareDeclared(header.Header.Get(blobSizeHeader), header.Header.Get(checksumHeader), b, checksum)
+	if err != nil {
+		return nil, code, err
+	}
+	plan, code, err := c.planUpload(r, header.Filename, header.Header.Get("Content-Type"), b, checksum, expiresAt)
+	if err != nil {
+		return nil, code, err
+	}
+	return &batchFile{plan: plan}, http.StatusOK, nil
+}
+
+// result reports how file was stored
+func (file *batchFile) result() *BatchResult {
+	if existing := file.plan.existing; existing != nil {
+		return &BatchResult{
+			FileName:      existing.FileName,
+			MimeType:      existing.MimeType,
+			FileSizeBytes: existing.FileSizeBytes,
+			Checksum:      existing.Checksum,
+			Deduplicated:  true,
+		}
+	}
+	result := &BatchResult{
+		FileName:      file.plan.blob.FileName,
+		MimeType:      file.plan.blob.MimeType,
+		FileSizeBytes: file.plan.blob.FileSizeBytes,
+		Checksum:      file.plan.blob.Checksum,
+	}
+	if file.kept != nil {
+		result.Version = file.kept.Version + 1
+	}
+	return result
+}
+
+// blobBatchUploadHandler stores every "file" part of a multipart form as its own
+// blob, held to the same rules as a single upload. An expiry applies to every
+// file. A batch is all or nothing: if any file is invalid nothing is stored and
+// the response, with status 422, carries the error of each failed file. Contents
+// the caller already stored are reported as deduplicated and not stored again.
+// The rows and, with the db store, their contents are written in one transaction.
+// The fs store is written once the rows are committed, and if that fails every
+// blob of the batch is put back as it was.
+func (c *API) blobBatchUploadHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Results []*BatchResult `json:"results"`
+		}
+
+		if _, ok := ClaimsFromContext(r.Context()); !ok {
+			return nil, http.StatusUnauthorized, ErrUnauthorized
+		}
+		w.Header().Set(maxBlobBytesHeader, strconv.FormatInt(c.maxBlobBytes, 10))
+		r.Body = http.MaxBytesReader(w, r.Body, c.maxBlobBytes+multipartOverhead)
+		err := r.ParseMultipartForm(c.multipartMemory)
+		if isBodyTooLarge(err) {
+			return nil, http.StatusRequestEntityTooLarge, ErrBlobTooLarge
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		// remove spilled parts now rather than leave them to build up in the temp dir
+		defer r.MultipartForm.RemoveAll()
+		headers := r.MultipartForm.File["file"]
+		if len(headers) == 0 {
+			return nil, http.StatusBadRequest, errors.New("no file parts")
+		}
+		if len(headers) > maxBatchFiles {
+			return nil, http.StatusBadRequest, fmt.Errorf("at most %d files per batch", maxBatchFiles)
+		}
+		expiresAt, err := blobExpiresAt(r, time.Now())
+		if err != nil {
+			return nil, http.StatusUnprocessableEntity, err
+		}
+
+		result := &Response{Results: []*BatchResult{}}
+		files := []*batchFile{}
+		failed := false
+		seen := map[string]bool{}
+		for _, header := range headers {
+			file, code, err := c.prepareBatchFile(r, header, expiresAt, seen)
+			if code >= http.StatusInternalServerError {
+				return nil, code, err
+			}
+			if err != nil {
+				failed = true
+				result.Results = append(result.Results, &BatchResult{FileName: header.Filename, Error: err.Error()})
+				continue
+			}
+			files = append(files, file)
+			result.Results = append(result.Results, file.result())
+		}
+		if failed {
+			return result, http.StatusUnprocessableEntity, nil
+		}
+
+		// replaced blobs keep their contents as a version first, as a single upload does
+		for i, file := range files {
+			if file.plan.current == nil {
+				continue
+			}
+			file.previous = *file.plan.current
+			file.kept, err = c.keepBlobVersion(r.Context(), file.plan.current)
+			if err != nil {
+				c.undoBatch(r, files[:i], err)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%w: %s: %s", ErrBatchFailed, file.plan.current.FileName, err)
+			}
+			result.Results[i] = file.result()
+		}
+
+		// contents join the transaction where the store allows, so no one sees a
+		// committed blob without them
+		p, inTx := c.store.(txPutter)
+		tx, err := boil.BeginTx(r.Context(), nil)
+		if err != nil {
+			c.undoBatch(r, files, err)
+			return nil, http.StatusInternalServerError, err
+		}
+		for _, file := range files {
+			plan := file.plan
+			switch {
+			case plan.existing != nil:
+				continue
+			case plan.current != nil:
+				err = c.updateBlobContents(r.Context(), tx, plan.current, plan.blob, plan.ciphertext)
+			default:
+				blob := plan.blob
+				err = insert(r.Context(), tx, blob, func() error {
+					inserted, err := db.Blobs(qm.Select(db.BlobColumns.ID), db.BlobWhere.FileName.EQ(blob.FileName)).One(r.Context(), tx)
+					if err != nil {
+						return err
+					}
+					blob.ID = inserted.ID
+					return nil
+				})
+				if err == nil && inTx {
+					err = p.PutTx(r.Context(), tx, blobKey(blob), bytes.NewReader(plan.ciphertext))
+				}
+			}
+			if err != nil {
+				tx.Rollback()
+				c.undoBatch(r, files, err)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%w: %s: %s", ErrBatchFailed, plan.blob.FileName, err)
+			}
+		}
+		err = tx.Commit()
+		if err != nil {
+			c.undoBatch(r, files, err)
+			return nil, http.StatusInternalServerError, fmt.Errorf("%w: %s", ErrBatchFailed, err)
+		}
+
+		for _, file := range files {
+			plan := file.plan
+			if inTx || plan.existing != nil || plan.current != nil {
+				continue
+			}
+			err = c.store.Put(r.Context(), blobKey(plan.blob), bytes.NewReader(plan.ciphertext))
+			if err != nil {
+				c.removeBatch(r, files)
+				return nil, http.StatusInternalServerError, fmt.Errorf("%w: %s: %s", ErrBatchFailed, plan.blob.FileName, err)
+			}
+		}
+
+		stored := 0
+		for _, file := range files {
+			plan := file.plan
+			switch {
+			case plan.existing != nil:
+				continue
+			case plan.current != nil:
+				c.audit(r, AuditUpload, plan.current.FileName)
+				c.publishBlob(EventBlobCreated, plan.current, file.kept.Version+1)
+			default:
+				c.audit(r, AuditUpload, plan.blob.FileName)
+				c.publishBlob(EventBlobCreated, plan.blob, 0)
+			}
+			c.metrics.blobUploadsTotal.Inc()
+			stored++
+		}
+		c.log.Infow("blob batch uploaded", "files", len(files), "stored", stored)
+		return result, http.StatusCreated, nil
+	}
+	return fn
+}
+
+// undoBatch forgets the versions kept for a batch whose transaction failed with err
+func (c *API) undoBatch(r *http.Request, files []*batchFile, err error) {
+	for _, file := range files {
+		if file.kept == nil {
+			continue
+		}
+		undoErr := c.undoReplace(r.Context(), file.plan.current, file.kept, err)
+		if undoErr != err {
+			c.log.Errorw("batch cleanup", "file_name", file.plan.current.FileName, "err", undoErr)
+		}
+	}
+}
+
+// removeBatch undoes a committed batch whose contents couldn't all be stored.
+// New blobs are deleted and replaced ones get their kept version back.
+func (c *API) removeBatch(r *http.Request, files []*batchFile) {
+	for _, file := range files {
+		plan := file.plan
+		switch {
+		case plan.existing != nil:
+			continue
+		case plan.current != nil:
+			*plan.current = file.previous
+			_, err := plan.current.UpdateG(r.Context(), replacedBlobColumns)
+			if err == nil {
+				err = c.copyStored(r.Context(), blobVersionKey(file.kept), blobKey(plan.current))
+			}
+			if err != nil {
+				c.log.Errorw("batch cleanup", "file_name", plan.current.FileName, "err", err)
+				continue
+			}
+			c.dropBlobVersion(r.Context(), file.kept)
+		default:
+			err := c.store.Delete(r.Context(), blobKey(plan.blob))
+			if err != nil {
+				c.log.Errorw("batch cleanup", "file_name", plan.blob.FileName, "err", err)
+			}
+			_, err = plan.blob.DeleteG(r.Context())
+			if err != nil {
+				c.log.Errorw("batch cleanup", "file_name", plan.blob.FileName, "err", err)
+			}
+		}
+	}
+}
+
+// blobSelection names blobs for a bulk action, either by file name or as every
+// blob carrying all of the tags
+type blobSelection struct {
+	FileNames []string `json:"file_names"`
+	Tags      []string `json:"tags"`
+}
+
+// validate checks exactly one way of selecting is used, naming at most max blobs
+func (s *blobSelection) validate(max int) error {
+	switch {
+	case len(s.FileNames) == 0 && len(s.Tags) == 0:
+		return ValidationErr(map[string]string{"file_names": "file_names or tags required"})
+	case len(s.FileNames) > 0 && len(s.Tags) > 0:
+		return ValidationErr(map[string]string{"tags": "not allowed with file_names"})
+	case len(s.FileNames) > max:
+		return ValidationErr(map[string]string{"file_names": fmt.Sprintf("at most %d", max)})
+	}
+	for _, tag := range s.Tags {
+		err := validTag(tag)
+		if err != nil {
+			return ValidationErr(map[string]string{"tags": err.Error()})
+		}
+	}
+	return nil
+}
+
+// filters select the blobs, columns must be qualified as tags has some of the same names
+func (s *blobSelection) filters() []qm.QueryMod {
+	filters := tagFilters(s.Tags)
+	if len(s.FileNames) > 0 {
+		names := []interface{}{}
+		for _, name := range s.FileNames {
+			names = append(names, name)
+		}
+		filters = append(filters, qm.WhereIn(db.TableNames.Blobs+"."+db.BlobColumns.FileName+" in ?", names...))
+	}
+	return filters
+}
+
+// missing lists the named blobs that weren't found
+func (s *blobSelection) missing(blobs db.BlobSlice) []string {
+	found := map[string]bool{}
+	for _, blob := range blobs {
+		found[blob.FileName] = true
+	}
+	missing := []string{}
+	for _, name := range s.FileNames {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// maxBulkDelete bounds how many blobs one bulk delete may remove
+const maxBulkDelete = 1000
+
+// BulkDeleteFailure reports a blob a bulk delete didn't remove
+type BulkDeleteFailure struct {
+	FileName string `json:"file_name"`
+	Error    string `json:"error"`
+}
+
+// blobBulkDeleteHandler removes the selected blobs in one transaction, it is all
+// of them or none, or moves them to the trash when TrashRetention is set. Named
+// blobs that don't exist are reported and skipped. Stored contents go once the
+// rows are committed, any left behind are only logged and show up as orphans.
+func (c *API) blobBulkDeleteHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Deleted int                  `json:"deleted"`
+			Failed  []*BulkDeleteFailure `json:"failed"`
+		}
+
+		req := &blobSelection{}
+		err := decodeJSON(r, req)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		err = req.validate(maxBulkDelete)
+		if err != nil {
+			return nil, http.StatusUnprocessableEntity, err
+		}
+
+		blobs, err := db.Blobs(append(req.filters(),
+			qm.Select(db.TableNames.Blobs+"."+db.BlobColumns.ID, db.TableNames.Blobs+"."+db.BlobColumns.FileName, db.TableNames.Blobs+"."+db.BlobColumns.OwnerID),
+			notTrashed(),
+			qm.Limit(maxBulkDelete+1),
+		)...).AllG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if len(blobs) > maxBulkDelete {
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{"tags": fmt.Sprintf("match more than %d blobs", maxBulkDelete)})
+		}
+		result := &Response{Failed: []*BulkDeleteFailure{}}
+		for _, name := range req.missing(blobs) {
+			result.Failed = append(result.Failed, &BulkDeleteFailure{FileName: name, Error: ErrBlobNotFound.Error()})
+		}
+
+		// versions' rows cascade with their blob's, so find their contents first.
+		// Trashed blobs keep theirs until purged.
+		trash := c.trashRetention > 0
+		keys := map[int64][]string{}
+		for _, blob := range blobs {
+			if trash {
+				continue
+			}
+			keys[blob.ID.Int64], err = blobContentKeys(r.Context(), blob)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+		tx, err := boil.BeginTx(r.Context(), nil)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		now := time.Now()
+		for _, blob := range blobs {
+			if trash {
+				err = trashBlob(r.Context(), tx, blob, now)
+			} else {
+				_, err = blob.Delete(r.Context(), tx)
+			}
+			if err != nil {
+				tx.Rollback()
+				return nil, http.StatusInternalServerError, fmt.Errorf("bulk delete %s: %w", blob.FileName, err)
+			}
+		}
+		err = tx.Commit()
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("bulk delete: %w", err)
+		}
+
+		for _, blob := range blobs {
+			for _, key := range keys[blob.ID.Int64] {
+				err = c.store.Delete(r.Context(), key)
+				if err != nil {
+					c.log.Errorw("bulk delete contents", "file_name", blob.FileName, "err", err)
+				}
+			}
+			c.audit(r, AuditDelete, blob.FileName)
+			c.publishBlob(EventBlobDeleted, blob, 0)
+		}
+		result.Deleted = len(blobs)
+		c.log.Infow("blobs bulk deleted", "deleted", result.Deleted, "failed", len(result.Failed))
+		return result, http.StatusOK, nil
+	}
+	return fn
+}
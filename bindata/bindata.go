@@ -2,6 +2,84 @@
 // sources:
 // migrations/20191225220909_initial_migration.down.sql (0)
 // migrations/20191225220909_initial_migration.up.sql (2.064kB)
+// migrations/20200123093000_blob_checksum.down.sql (655B)
+// migrations/20200123093000_blob_checksum.up.sql (67B)
+// migrations/20200123101500_blob_nonce.down.sql (707B)
+// migrations/20200123101500_blob_nonce.up.sql (62B)
+// migrations/20200124090000_users.down.sql (18B)
+// migrations/20200124090000_users.up.sql (313B)
+// migrations/20200125090000_blob_compressed.down.sql (751B)
+// migrations/20200125090000_blob_compressed.up.sql (68B)
+// migrations/20200126090000_blob_segment_size.down.sql (806B)
+// migrations/20200126090000_blob_segment_size.up.sql (70B)
+// migrations/20200127090000_blob_created_at_immutable.down.sql (41B)
+// migrations/20200127090000_blob_created_at_immutable.up.sql (190B)
+// migrations/20200128090000_audit_log.down.sql (22B)
+// migrations/20200128090000_audit_log.up.sql (316B)
+// migrations/20200129090000_api_keys.down.sql (21B)
+// migrations/20200129090000_api_keys.up.sql (358B)
+// migrations/20200130090000_blobs_tags.down.sql (45B)
+// migrations/20200130090000_blobs_tags.up.sql (246B)
+// migrations/20200131090000_blob_owner.down.sql (2.053kB)
+// migrations/20200131090000_blob_owner.up.sql (180B)
+// migrations/20200201090000_blob_checksum_index.down.sql (27B)
+// migrations/20200201090000_blob_checksum_index.up.sql (49B)
+// migrations/20200202090000_sessions.down.sql (21B)
+// migrations/20200202090000_sessions.up.sql (163B)
+// migrations/20200203090000_idempotency_keys.down.sql (29B)
+// migrations/20200203090000_idempotency_keys.up.sql (416B)
+// migrations/20200204090000_blob_expiry.down.sql (1.519kB)
+// migrations/20200204090000_blob_expiry.up.sql (104B)
+// migrations/20200205090000_blob_versions.down.sql (26B)
+// migrations/20200205090000_blob_versions.up.sql (550B)
+// migrations/20200206090000_blob_trash.down.sql (1.864kB)
+// migrations/20200206090000_blob_trash.up.sql (104B)
+// migrations/20200207090000_blob_sealed_final.down.sql (2.763kB)
+// migrations/20200207090000_blob_sealed_final.up.sql (307B)
+// migrations/20200208090000_blob_content_updated_at.down.sql (2.024kB)
+// migrations/20200208090000_blob_content_updated_at.up.sql (233B)
+// migrations/20200209090000_idempotency_request_hash.down.sql (764B)
+// migrations/20200209090000_idempotency_request_hash.up.sql (218B)
+// migrations/postgres/20191225220909_initial_migration.down.sql (0)
+// migrations/postgres/20191225220909_initial_migration.up.sql (2.135kB)
+// migrations/postgres/20200123093000_blob_checksum.down.sql (40B)
+// migrations/postgres/20200123093000_blob_checksum.up.sql (67B)
+// migrations/postgres/20200123101500_blob_nonce.down.sql (37B)
+// migrations/postgres/20200123101500_blob_nonce.up.sql (64B)
+// migrations/postgres/20200124090000_users.down.sql (18B)
+// migrations/postgres/20200124090000_users.up.sql (328B)
+// migrations/postgres/20200125090000_blob_compressed.down.sql (42B)
+// migrations/postgres/20200125090000_blob_compressed.up.sql (72B)
+// migrations/postgres/20200126090000_blob_segment_size.down.sql (44B)
+// migrations/postgres/20200126090000_blob_segment_size.up.sql (70B)
+// migrations/postgres/20200127090000_blob_created_at_immutable.down.sql (94B)
+// migrations/postgres/20200127090000_blob_created_at_immutable.up.sql (347B)
+// migrations/postgres/20200128090000_audit_log.down.sql (22B)
+// migrations/postgres/20200128090000_audit_log.up.sql (320B)
+// migrations/postgres/20200129090000_api_keys.down.sql (21B)
+// migrations/postgres/20200129090000_api_keys.up.sql (372B)
+// migrations/postgres/20200130090000_blobs_tags.down.sql (45B)
+// migrations/postgres/20200130090000_blobs_tags.up.sql (244B)
+// migrations/postgres/20200131090000_blob_owner.down.sql (104B)
+// migrations/postgres/20200131090000_blob_owner.up.sql (183B)
+// migrations/postgres/20200201090000_blob_checksum_index.down.sql (27B)
+// migrations/postgres/20200201090000_blob_checksum_index.up.sql (49B)
+// migrations/postgres/20200202090000_sessions.down.sql (21B)
+// migrations/postgres/20200202090000_sessions.up.sql (167B)
+// migrations/postgres/20200203090000_idempotency_keys.down.sql (29B)
+// migrations/postgres/20200203090000_idempotency_keys.up.sql (422B)
+// migrations/postgres/20200204090000_blob_expiry.down.sql (71B)
+// migrations/postgres/20200204090000_blob_expiry.up.sql (107B)
+// migrations/postgres/20200205090000_blob_versions.down.sql (26B)
+// migrations/postgres/20200205090000_blob_versions.up.sql (565B)
+// migrations/postgres/20200206090000_blob_trash.down.sql (71B)
+// migrations/postgres/20200206090000_blob_trash.up.sql (107B)
+// migrations/postgres/20200207090000_blob_sealed_final.down.sql (188B)
+// migrations/postgres/20200207090000_blob_sealed_final.up.sql (319B)
+// migrations/postgres/20200208090000_blob_content_updated_at.down.sql (50B)
+// migrations/postgres/20200208090000_blob_content_updated_at.up.sql (236B)
+// migrations/postgres/20200209090000_idempotency_request_hash.down.sql (55B)
+// migrations/postgres/20200209090000_idempotency_request_hash.up.sql (219B)
 
 package bindata
 
@@ -59,7 +137,7 @@ func _20191225220909_initial_migrationDownSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20191225220909_initial_migration.down.sql", size: 0, mode: os.FileMode(0644), modTime: time.Unix(1579685537, 0)}
+	info := bindataFileInfo{name: "20191225220909_initial_migration.down.sql", size: 0, mode: os.FileMode(0664), modTime: time.Unix(1579687223, 0)}
 	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0xfb, 0xf4, 0xc8, 0x99, 0x6f, 0xb9, 0x24, 0x27, 0xae, 0x41, 0xe4, 0x64, 0x9b, 0x93, 0x4c, 0xa4, 0x95, 0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55}}
 	return a, nil
 }
@@ -148,11 +226,2035 @@ func _20191225220909_initial_migrationUpSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "20191225220909_initial_migration.up.sql", size: 2064, mode: os.FileMode(0644), modTime: time.Unix(1579686241, 0)}
+	info := bindataFileInfo{name: "20191225220909_initial_migration.up.sql", size: 2064, mode: os.FileMode(0664), modTime: time.Unix(1579687223, 0)}
 	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf8, 0xcf, 0x53, 0x1a, 0x14, 0x6, 0x3d, 0x93, 0x53, 0x12, 0x8d, 0x7a, 0xbe, 0xb, 0xaf, 0xff, 0xae, 0x34, 0xf4, 0xd6, 0x9c, 0x13, 0x25, 0x14, 0x26, 0xe7, 0x0, 0xd5, 0x6b, 0xe6, 0xa5, 0xc1}}
 	return a, nil
 }
 
+var __20200123093000_blob_checksumDownSql = []byte(`CREATE TABLE blobs_backup (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO blobs_backup
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at
+FROM blobs;
+
+DROP TABLE blobs;
+ALTER TABLE blobs_backup RENAME TO blobs;
+`)
+
+func _20200123093000_blob_checksumDownSqlBytes() ([]byte, error) {
+	return __20200123093000_blob_checksumDownSql, nil
+}
+
+func _20200123093000_blob_checksumDownSql() (*asset, error) {
+	bytes, err := _20200123093000_blob_checksumDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200123093000_blob_checksum.down.sql", size: 655, mode: os.FileMode(0644), modTime: time.Unix(1792141956, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf3, 0x8a, 0x69, 0x7b, 0xf2, 0x54, 0x59, 0xd2, 0xf7, 0xef, 0x36, 0x14, 0xe4, 0x22, 0xe6, 0x2, 0xdb, 0xf2, 0x30, 0x1b, 0xd8, 0x70, 0xc4, 0x7d, 0x33, 0x3e, 0xd3, 0x71, 0x5d, 0x10, 0xfb, 0x46}}
+	return a, nil
+}
+
+var __20200123093000_blob_checksumUpSql = []byte(`ALTER TABLE blobs ADD COLUMN checksum VARCHAR NOT NULL DEFAULT '';
+`)
+
+func _20200123093000_blob_checksumUpSqlBytes() ([]byte, error) {
+	return __20200123093000_blob_checksumUpSql, nil
+}
+
+func _20200123093000_blob_checksumUpSql() (*asset, error) {
+	bytes, err := _20200123093000_blob_checksumUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200123093000_blob_checksum.up.sql", size: 67, mode: os.FileMode(0644), modTime: time.Unix(1792141956, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5b, 0x54, 0x89, 0x33, 0x44, 0x54, 0x47, 0x3b, 0xb5, 0x45, 0xde, 0xb6, 0xa1, 0x67, 0x15, 0x24, 0xc0, 0x97, 0x20, 0x57, 0x9, 0x16, 0x33, 0x2c, 0xce, 0xd3, 0xb0, 0xd1, 0x98, 0x84, 0x98, 0x52}}
+	return a, nil
+}
+
+var __20200123101500_blob_nonceDownSql = []byte(`CREATE TABLE blobs_backup (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT ''
+);
+
+INSERT INTO blobs_backup
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum
+FROM blobs;
+
+DROP TABLE blobs;
+ALTER TABLE blobs_backup RENAME TO blobs;
+`)
+
+func _20200123101500_blob_nonceDownSqlBytes() ([]byte, error) {
+	return __20200123101500_blob_nonceDownSql, nil
+}
+
+func _20200123101500_blob_nonceDownSql() (*asset, error) {
+	bytes, err := _20200123101500_blob_nonceDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200123101500_blob_nonce.down.sql", size: 707, mode: os.FileMode(0644), modTime: time.Unix(1792141981, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2f, 0x1f, 0xf, 0x2a, 0xaa, 0x3c, 0x5b, 0x10, 0x30, 0xdb, 0x70, 0x6, 0x1, 0xc0, 0x9a, 0xa1, 0xe8, 0x4f, 0xd0, 0xab, 0x3b, 0xd3, 0x82, 0x32, 0x69, 0xdb, 0xd0, 0x7d, 0x84, 0x52, 0x9d, 0xd2}}
+	return a, nil
+}
+
+var __20200123101500_blob_nonceUpSql = []byte(`ALTER TABLE blobs ADD COLUMN nonce BLOB NOT NULL DEFAULT X'';
+`)
+
+func _20200123101500_blob_nonceUpSqlBytes() ([]byte, error) {
+	return __20200123101500_blob_nonceUpSql, nil
+}
+
+func _20200123101500_blob_nonceUpSql() (*asset, error) {
+	bytes, err := _20200123101500_blob_nonceUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200123101500_blob_nonce.up.sql", size: 62, mode: os.FileMode(0644), modTime: time.Unix(1792141981, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd2, 0xf1, 0x15, 0x94, 0xd9, 0x74, 0x6f, 0xc3, 0xc1, 0xbb, 0xc9, 0x3a, 0xf1, 0xbe, 0xae, 0xc2, 0x17, 0x44, 0xe4, 0x7a, 0x3b, 0x9c, 0xd0, 0x4c, 0x3f, 0xf5, 0xa1, 0x40, 0xde, 0xf0, 0x95, 0xb}}
+	return a, nil
+}
+
+var __20200124090000_usersDownSql = []byte(`DROP TABLE users;
+`)
+
+func _20200124090000_usersDownSqlBytes() ([]byte, error) {
+	return __20200124090000_usersDownSql, nil
+}
+
+func _20200124090000_usersDownSql() (*asset, error) {
+	bytes, err := _20200124090000_usersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200124090000_users.down.sql", size: 18, mode: os.FileMode(0644), modTime: time.Unix(1792142059, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xde, 0x10, 0x15, 0x70, 0x7e, 0x41, 0xd6, 0x68, 0x21, 0x86, 0xc3, 0x44, 0xc, 0x58, 0xb2, 0x22, 0x51, 0x8e, 0x75, 0xea, 0xe7, 0xbe, 0xcf, 0xba, 0x74, 0x34, 0x64, 0x68, 0xee, 0xfd, 0xfc, 0x5d}}
+	return a, nil
+}
+
+var __20200124090000_usersUpSql = []byte(`CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR UNIQUE NOT NULL,
+    password_hash VARCHAR NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`)
+
+func _20200124090000_usersUpSqlBytes() ([]byte, error) {
+	return __20200124090000_usersUpSql, nil
+}
+
+func _20200124090000_usersUpSql() (*asset, error) {
+	bytes, err := _20200124090000_usersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200124090000_users.up.sql", size: 313, mode: os.FileMode(0644), modTime: time.Unix(1792142059, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x13, 0x8d, 0x6f, 0x52, 0x8e, 0x3, 0xa8, 0x96, 0xd9, 0xd8, 0x7, 0xaf, 0x1d, 0xb9, 0x4e, 0x14, 0xab, 0x6e, 0x68, 0x6c, 0x2f, 0x52, 0x5a, 0x5c, 0x59, 0xa9, 0x65, 0xa1, 0xbf, 0x4b, 0xa, 0x93}}
+	return a, nil
+}
+
+var __20200125090000_blob_compressedDownSql = []byte(`CREATE TABLE blobs_backup (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X''
+);
+
+INSERT INTO blobs_backup
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce
+FROM blobs;
+
+DROP TABLE blobs;
+ALTER TABLE blobs_backup RENAME TO blobs;
+`)
+
+func _20200125090000_blob_compressedDownSqlBytes() ([]byte, error) {
+	return __20200125090000_blob_compressedDownSql, nil
+}
+
+func _20200125090000_blob_compressedDownSql() (*asset, error) {
+	bytes, err := _20200125090000_blob_compressedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200125090000_blob_compressed.down.sql", size: 751, mode: os.FileMode(0644), modTime: time.Unix(1792142245, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8a, 0xdc, 0x5a, 0x1, 0x13, 0x4a, 0xe8, 0x23, 0x7, 0xb2, 0x44, 0x42, 0xb0, 0x7c, 0x60, 0xc7, 0xc3, 0x5f, 0xa9, 0x9c, 0x8e, 0x27, 0x70, 0x3d, 0xbd, 0x93, 0xdb, 0xf9, 0x8d, 0x65, 0xd1, 0xae}}
+	return a, nil
+}
+
+var __20200125090000_blob_compressedUpSql = []byte(`ALTER TABLE blobs ADD COLUMN compressed BOOLEAN NOT NULL DEFAULT 0;
+`)
+
+func _20200125090000_blob_compressedUpSqlBytes() ([]byte, error) {
+	return __20200125090000_blob_compressedUpSql, nil
+}
+
+func _20200125090000_blob_compressedUpSql() (*asset, error) {
+	bytes, err := _20200125090000_blob_compressedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200125090000_blob_compressed.up.sql", size: 68, mode: os.FileMode(0644), modTime: time.Unix(1792142245, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc8, 0x2a, 0xbb, 0x2c, 0xc6, 0x6, 0xf5, 0x66, 0x90, 0xdd, 0x4a, 0xe0, 0xdb, 0x67, 0xff, 0x77, 0x22, 0xb, 0x7c, 0xdd, 0x3f, 0x5b, 0x1c, 0x7b, 0x69, 0x87, 0x49, 0xea, 0xfc, 0xbe, 0xab, 0x84}}
+	return a, nil
+}
+
+var __20200126090000_blob_segment_sizeDownSql = []byte(`CREATE TABLE blobs_backup (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0
+);
+
+INSERT INTO blobs_backup
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed
+FROM blobs;
+
+DROP TABLE blobs;
+ALTER TABLE blobs_backup RENAME TO blobs;
+`)
+
+func _20200126090000_blob_segment_sizeDownSqlBytes() ([]byte, error) {
+	return __20200126090000_blob_segment_sizeDownSql, nil
+}
+
+func _20200126090000_blob_segment_sizeDownSql() (*asset, error) {
+	bytes, err := _20200126090000_blob_segment_sizeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200126090000_blob_segment_size.down.sql", size: 806, mode: os.FileMode(0644), modTime: time.Unix(1792142315, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x12, 0xe6, 0x41, 0xf5, 0xda, 0xe4, 0xa, 0x19, 0x49, 0x22, 0xd, 0xe1, 0x9c, 0x76, 0xd4, 0x12, 0xab, 0xc4, 0x6c, 0x6a, 0xdf, 0xe4, 0xdd, 0xa, 0x6, 0x71, 0x1b, 0x91, 0xbd, 0x65, 0xc3, 0x1}}
+	return a, nil
+}
+
+var __20200126090000_blob_segment_sizeUpSql = []byte(`ALTER TABLE blobs ADD COLUMN segment_size INTEGER NOT NULL DEFAULT 0;
+`)
+
+func _20200126090000_blob_segment_sizeUpSqlBytes() ([]byte, error) {
+	return __20200126090000_blob_segment_sizeUpSql, nil
+}
+
+func _20200126090000_blob_segment_sizeUpSql() (*asset, error) {
+	bytes, err := _20200126090000_blob_segment_sizeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200126090000_blob_segment_size.up.sql", size: 70, mode: os.FileMode(0644), modTime: time.Unix(1792142315, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9a, 0xf8, 0xbf, 0x10, 0xd6, 0x81, 0xb4, 0x7f, 0x45, 0xa2, 0x8a, 0xf7, 0xfd, 0x67, 0xca, 0xd6, 0x73, 0x98, 0x16, 0x81, 0xf5, 0x7a, 0xed, 0x69, 0xde, 0xea, 0x30, 0xd4, 0xa5, 0x1d, 0x9c, 0x37}}
+	return a, nil
+}
+
+var __20200127090000_blob_created_at_immutableDownSql = []byte(`DROP TRIGGER blobs_created_at_immutable;
+`)
+
+func _20200127090000_blob_created_at_immutableDownSqlBytes() ([]byte, error) {
+	return __20200127090000_blob_created_at_immutableDownSql, nil
+}
+
+func _20200127090000_blob_created_at_immutableDownSql() (*asset, error) {
+	bytes, err := _20200127090000_blob_created_at_immutableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200127090000_blob_created_at_immutable.down.sql", size: 41, mode: os.FileMode(0644), modTime: time.Unix(1792142725, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa0, 0xd7, 0x40, 0xc0, 0x9d, 0x6b, 0x5d, 0xc, 0xfd, 0x9c, 0xe3, 0xea, 0x36, 0xa4, 0x20, 0xa6, 0x63, 0xa0, 0xa5, 0xd0, 0x91, 0xd3, 0xb6, 0x86, 0x8d, 0x5e, 0x47, 0x38, 0xba, 0x14, 0x5c, 0x76}}
+	return a, nil
+}
+
+var __20200127090000_blob_created_at_immutableUpSql = []byte(`CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+`)
+
+func _20200127090000_blob_created_at_immutableUpSqlBytes() ([]byte, error) {
+	return __20200127090000_blob_created_at_immutableUpSql, nil
+}
+
+func _20200127090000_blob_created_at_immutableUpSql() (*asset, error) {
+	bytes, err := _20200127090000_blob_created_at_immutableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200127090000_blob_created_at_immutable.up.sql", size: 190, mode: os.FileMode(0644), modTime: time.Unix(1792142725, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x96, 0xf, 0x64, 0xb4, 0xdf, 0xb7, 0xbe, 0x3f, 0x34, 0xd1, 0xbc, 0xd3, 0xe6, 0x63, 0x1a, 0xa0, 0x2c, 0xf3, 0xa8, 0xf8, 0x94, 0xdc, 0x39, 0x26, 0x43, 0x4e, 0x74, 0x57, 0x61, 0x35, 0xc0, 0xc0}}
+	return a, nil
+}
+
+var __20200128090000_audit_logDownSql = []byte(`DROP TABLE audit_log;
+`)
+
+func _20200128090000_audit_logDownSqlBytes() ([]byte, error) {
+	return __20200128090000_audit_logDownSql, nil
+}
+
+func _20200128090000_audit_logDownSql() (*asset, error) {
+	bytes, err := _20200128090000_audit_logDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200128090000_audit_log.down.sql", size: 22, mode: os.FileMode(0644), modTime: time.Unix(1792142881, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x82, 0xca, 0x23, 0xe2, 0x6d, 0xe0, 0x36, 0x7c, 0x9c, 0xc, 0x35, 0x56, 0xbe, 0x2, 0x12, 0x2e, 0x59, 0xb8, 0x39, 0x39, 0x7f, 0x75, 0x5f, 0xe8, 0x8a, 0xb6, 0xd1, 0x89, 0xf5, 0xfb, 0x52, 0xa8}}
+	return a, nil
+}
+
+var __20200128090000_audit_logUpSql = []byte(`CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id),
+    username VARCHAR NOT NULL,
+    action VARCHAR NOT NULL,
+    blob_file_name VARCHAR NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at ON audit_log (created_at);
+`)
+
+func _20200128090000_audit_logUpSqlBytes() ([]byte, error) {
+	return __20200128090000_audit_logUpSql, nil
+}
+
+func _20200128090000_audit_logUpSql() (*asset, error) {
+	bytes, err := _20200128090000_audit_logUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200128090000_audit_log.up.sql", size: 316, mode: os.FileMode(0644), modTime: time.Unix(1792142881, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9a, 0xdc, 0x43, 0xa, 0x29, 0x4c, 0xf, 0x84, 0x95, 0x89, 0x47, 0x29, 0x2a, 0xa, 0xce, 0x58, 0xe8, 0xbc, 0x19, 0x0, 0x69, 0xc, 0x32, 0x4f, 0x71, 0xfe, 0xf1, 0xa5, 0xb3, 0x2a, 0x1b, 0x8e}}
+	return a, nil
+}
+
+var __20200129090000_api_keysDownSql = []byte(`DROP TABLE api_keys;
+`)
+
+func _20200129090000_api_keysDownSqlBytes() ([]byte, error) {
+	return __20200129090000_api_keysDownSql, nil
+}
+
+func _20200129090000_api_keysDownSql() (*asset, error) {
+	bytes, err := _20200129090000_api_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200129090000_api_keys.down.sql", size: 21, mode: os.FileMode(0644), modTime: time.Unix(1792143133, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0xe2, 0x8, 0xed, 0xac, 0x73, 0xca, 0x54, 0xcf, 0x4e, 0x32, 0xaa, 0x66, 0xd7, 0x5b, 0x62, 0x76, 0x81, 0xb9, 0x7, 0xba, 0xf3, 0x71, 0xea, 0x7, 0x13, 0x76, 0xc7, 0xb3, 0xfd, 0x4d, 0xf4}}
+	return a, nil
+}
+
+var __20200129090000_api_keysUpSql = []byte(`CREATE TABLE api_keys (
+    id INTEGER PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR UNIQUE NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`)
+
+func _20200129090000_api_keysUpSqlBytes() ([]byte, error) {
+	return __20200129090000_api_keysUpSql, nil
+}
+
+func _20200129090000_api_keysUpSql() (*asset, error) {
+	bytes, err := _20200129090000_api_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200129090000_api_keys.up.sql", size: 358, mode: os.FileMode(0644), modTime: time.Unix(1792143133, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc, 0xc2, 0x6d, 0x4a, 0xd3, 0x34, 0x91, 0x1b, 0xb5, 0x71, 0xaf, 0x66, 0x68, 0x34, 0x57, 0x9e, 0xd1, 0xcc, 0xe6, 0x27, 0xb, 0xcd, 0x30, 0xc9, 0x3, 0x52, 0xc5, 0x4f, 0x96, 0x76, 0xc6, 0x88}}
+	return a, nil
+}
+
+var __20200130090000_blobs_tagsDownSql = []byte(`DROP TABLE blobs_tags;
+DROP INDEX tags_name;
+`)
+
+func _20200130090000_blobs_tagsDownSqlBytes() ([]byte, error) {
+	return __20200130090000_blobs_tagsDownSql, nil
+}
+
+func _20200130090000_blobs_tagsDownSql() (*asset, error) {
+	bytes, err := _20200130090000_blobs_tagsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200130090000_blobs_tags.down.sql", size: 45, mode: os.FileMode(0644), modTime: time.Unix(1792143161, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x83, 0x7b, 0xde, 0x49, 0xa, 0x2d, 0x1a, 0x2c, 0x4f, 0x5d, 0x9d, 0x93, 0x4b, 0xe8, 0x27, 0x25, 0xba, 0xe1, 0xfd, 0x89, 0x77, 0xf8, 0xd2, 0xe7, 0x9b, 0xdc, 0x8e, 0xa8, 0xa5, 0x94, 0xdd, 0xe2}}
+	return a, nil
+}
+
+var __20200130090000_blobs_tagsUpSql = []byte(`CREATE UNIQUE INDEX tags_name ON tags (name);
+
+CREATE TABLE blobs_tags (
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (blob_id, tag_id)
+);
+`)
+
+func _20200130090000_blobs_tagsUpSqlBytes() ([]byte, error) {
+	return __20200130090000_blobs_tagsUpSql, nil
+}
+
+func _20200130090000_blobs_tagsUpSql() (*asset, error) {
+	bytes, err := _20200130090000_blobs_tagsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200130090000_blobs_tags.up.sql", size: 246, mode: os.FileMode(0644), modTime: time.Unix(1792143161, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5f, 0xec, 0x5, 0x46, 0xf2, 0xbf, 0x3e, 0xf1, 0x28, 0x4e, 0x18, 0xe0, 0xa7, 0xe2, 0xfc, 0xb3, 0x23, 0x46, 0xbb, 0x3d, 0xf7, 0x1, 0xd, 0xaa, 0xc5, 0x8b, 0x6c, 0xa, 0x7f, 0xaa, 0x5e, 0xb6}}
+	return a, nil
+}
+
+var __20200131090000_blob_ownerDownSql = []byte(`-- migrations run in a transaction, where foreign keys can't be switched off.
+-- Defer the checks instead: dropping a table leaves rows referencing it
+-- dangling until the rebuilt table is refilled.
+PRAGMA defer_foreign_keys = ON;
+
+-- blobs_tags cascades from blobs, keep its rows aside
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+
+CREATE TABLE users_backup AS
+SELECT id, username, password_hash, archived, archived_at, updated_at, created_at
+FROM users;
+
+DROP TABLE users;
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR UNIQUE NOT NULL,
+    password_hash VARCHAR NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO users SELECT * FROM users_backup;
+DROP TABLE users_backup;
+`)
+
+func _20200131090000_blob_ownerDownSqlBytes() ([]byte, error) {
+	return __20200131090000_blob_ownerDownSql, nil
+}
+
+func _20200131090000_blob_ownerDownSql() (*asset, error) {
+	bytes, err := _20200131090000_blob_ownerDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200131090000_blob_owner.down.sql", size: 2053, mode: os.FileMode(0644), modTime: time.Unix(1792143474, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcb, 0xb1, 0x81, 0xa2, 0x93, 0x9b, 0x6a, 0xd3, 0xda, 0x8d, 0x8d, 0xa1, 0x1a, 0x36, 0xe8, 0x5c, 0x1, 0x2d, 0xb4, 0xd9, 0xab, 0x21, 0xd5, 0xf9, 0x45, 0x3e, 0x54, 0xc9, 0x83, 0xa1, 0x75, 0x48}}
+	return a, nil
+}
+
+var __20200131090000_blob_ownerUpSql = []byte(`ALTER TABLE users ADD COLUMN admin BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE blobs ADD COLUMN owner_id INTEGER REFERENCES users(id);
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+`)
+
+func _20200131090000_blob_ownerUpSqlBytes() ([]byte, error) {
+	return __20200131090000_blob_ownerUpSql, nil
+}
+
+func _20200131090000_blob_ownerUpSql() (*asset, error) {
+	bytes, err := _20200131090000_blob_ownerUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200131090000_blob_owner.up.sql", size: 180, mode: os.FileMode(0644), modTime: time.Unix(1792143425, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd6, 0x82, 0x3c, 0x80, 0x63, 0xfd, 0xe, 0x3, 0x24, 0xde, 0xa9, 0x0, 0xb4, 0x8b, 0xf1, 0xaa, 0x0, 0x8a, 0xef, 0xb0, 0x14, 0xb9, 0xb, 0xb9, 0x62, 0xc, 0xa0, 0x34, 0xc7, 0xdd, 0x5, 0x25}}
+	return a, nil
+}
+
+var __20200201090000_blob_checksum_indexDownSql = []byte(`DROP INDEX blobs_checksum;
+`)
+
+func _20200201090000_blob_checksum_indexDownSqlBytes() ([]byte, error) {
+	return __20200201090000_blob_checksum_indexDownSql, nil
+}
+
+func _20200201090000_blob_checksum_indexDownSql() (*asset, error) {
+	bytes, err := _20200201090000_blob_checksum_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200201090000_blob_checksum_index.down.sql", size: 27, mode: os.FileMode(0644), modTime: time.Unix(1792143704, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4b, 0xdf, 0xd0, 0xda, 0xa5, 0x17, 0x70, 0xca, 0x67, 0x75, 0x6f, 0x73, 0x6c, 0x6b, 0x7d, 0xf, 0xae, 0x95, 0x36, 0xc6, 0x89, 0x75, 0xa9, 0x88, 0x77, 0xa6, 0x2d, 0x34, 0x49, 0x48, 0x3b, 0x2a}}
+	return a, nil
+}
+
+var __20200201090000_blob_checksum_indexUpSql = []byte(`CREATE INDEX blobs_checksum ON blobs (checksum);
+`)
+
+func _20200201090000_blob_checksum_indexUpSqlBytes() ([]byte, error) {
+	return __20200201090000_blob_checksum_indexUpSql, nil
+}
+
+func _20200201090000_blob_checksum_indexUpSql() (*asset, error) {
+	bytes, err := _20200201090000_blob_checksum_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200201090000_blob_checksum_index.up.sql", size: 49, mode: os.FileMode(0644), modTime: time.Unix(1792143704, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbc, 0x4, 0x1b, 0x6b, 0x1c, 0xc1, 0x5f, 0x0, 0xd1, 0xb, 0x6, 0x0, 0xc2, 0x9, 0xa3, 0x64, 0x61, 0x29, 0x1a, 0x76, 0x96, 0x1a, 0xf4, 0x71, 0xe6, 0xca, 0x40, 0xec, 0xc7, 0x5c, 0xb6, 0x2}}
+	return a, nil
+}
+
+var __20200202090000_sessionsDownSql = []byte(`DROP TABLE sessions;
+`)
+
+func _20200202090000_sessionsDownSqlBytes() ([]byte, error) {
+	return __20200202090000_sessionsDownSql, nil
+}
+
+func _20200202090000_sessionsDownSql() (*asset, error) {
+	bytes, err := _20200202090000_sessionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200202090000_sessions.down.sql", size: 21, mode: os.FileMode(0644), modTime: time.Unix(1792143945, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4c, 0xb7, 0xd5, 0x77, 0xc8, 0x8a, 0xdc, 0xe1, 0x1c, 0x4d, 0x35, 0x2e, 0x6f, 0x6c, 0x63, 0x5c, 0xa6, 0x38, 0x7e, 0x26, 0xae, 0x86, 0xea, 0xbc, 0xe6, 0x7a, 0x0, 0xf7, 0xfa, 0x4c, 0xdf, 0xbb}}
+	return a, nil
+}
+
+var __20200202090000_sessionsUpSql = []byte(`CREATE TABLE sessions (
+    token VARCHAR PRIMARY KEY,
+    data BLOB NOT NULL,
+    expiry DATETIME NOT NULL
+);
+
+CREATE INDEX sessions_expiry ON sessions (expiry);
+`)
+
+func _20200202090000_sessionsUpSqlBytes() ([]byte, error) {
+	return __20200202090000_sessionsUpSql, nil
+}
+
+func _20200202090000_sessionsUpSql() (*asset, error) {
+	bytes, err := _20200202090000_sessionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200202090000_sessions.up.sql", size: 163, mode: os.FileMode(0644), modTime: time.Unix(1792143945, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd6, 0xf8, 0xdc, 0xa3, 0xa2, 0x2c, 0xaa, 0x8a, 0xac, 0x9a, 0xeb, 0xdd, 0x46, 0xed, 0xaf, 0xfa, 0x44, 0xaa, 0x3d, 0xcb, 0x77, 0x6, 0xad, 0x8c, 0x97, 0x34, 0x1d, 0x55, 0x42, 0xcf, 0xe2, 0xd}}
+	return a, nil
+}
+
+var __20200203090000_idempotency_keysDownSql = []byte(`DROP TABLE idempotency_keys;
+`)
+
+func _20200203090000_idempotency_keysDownSqlBytes() ([]byte, error) {
+	return __20200203090000_idempotency_keysDownSql, nil
+}
+
+func _20200203090000_idempotency_keysDownSql() (*asset, error) {
+	bytes, err := _20200203090000_idempotency_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200203090000_idempotency_keys.down.sql", size: 29, mode: os.FileMode(0644), modTime: time.Unix(1792144200, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0x70, 0xb, 0x95, 0xf, 0xe8, 0xa6, 0xc9, 0x43, 0x2c, 0x17, 0x1a, 0x90, 0x29, 0x37, 0xe3, 0x20, 0x71, 0x38, 0xe3, 0xf3, 0xd1, 0xc7, 0x16, 0x73, 0xb8, 0x91, 0x50, 0xab, 0x49, 0xff, 0x21}}
+	return a, nil
+}
+
+var __20200203090000_idempotency_keysUpSql = []byte(`CREATE TABLE idempotency_keys (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    key VARCHAR NOT NULL,
+    -- NULL until the first request with the key completes
+    status_code INTEGER,
+    response BLOB,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
+`)
+
+func _20200203090000_idempotency_keysUpSqlBytes() ([]byte, error) {
+	return __20200203090000_idempotency_keysUpSql, nil
+}
+
+func _20200203090000_idempotency_keysUpSql() (*asset, error) {
+	bytes, err := _20200203090000_idempotency_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200203090000_idempotency_keys.up.sql", size: 416, mode: os.FileMode(0644), modTime: time.Unix(1792144200, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xed, 0x21, 0x4c, 0x2a, 0x37, 0xb1, 0x0, 0x86, 0x79, 0x56, 0x6, 0x5b, 0x19, 0x52, 0xb5, 0xdf, 0x53, 0xc1, 0x48, 0xdb, 0xf2, 0xed, 0x8f, 0xb2, 0x2f, 0x1a, 0xef, 0x17, 0x1f, 0xbb, 0xc0, 0x22}}
+	return a, nil
+}
+
+var __20200204090000_blob_expiryDownSql = []byte(`-- SQLite can't drop a column, rebuild blobs without it. See 20200131090000_blob_owner.down.sql.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id)
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+`)
+
+func _20200204090000_blob_expiryDownSqlBytes() ([]byte, error) {
+	return __20200204090000_blob_expiryDownSql, nil
+}
+
+func _20200204090000_blob_expiryDownSql() (*asset, error) {
+	bytes, err := _20200204090000_blob_expiryDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200204090000_blob_expiry.down.sql", size: 1519, mode: os.FileMode(0644), modTime: time.Unix(1792144267, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8a, 0x6a, 0x45, 0x1b, 0xbb, 0x21, 0x2b, 0x39, 0x1e, 0xb7, 0xbc, 0x5f, 0xb8, 0xf4, 0x1e, 0x41, 0xdf, 0xe1, 0x4c, 0x7c, 0x63, 0xea, 0x5a, 0x10, 0xaf, 0x7f, 0xba, 0xf6, 0x14, 0xf1, 0xae, 0x0}}
+	return a, nil
+}
+
+var __20200204090000_blob_expiryUpSql = []byte(`ALTER TABLE blobs ADD COLUMN expires_at DATETIME;
+
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+`)
+
+func _20200204090000_blob_expiryUpSqlBytes() ([]byte, error) {
+	return __20200204090000_blob_expiryUpSql, nil
+}
+
+func _20200204090000_blob_expiryUpSql() (*asset, error) {
+	bytes, err := _20200204090000_blob_expiryUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200204090000_blob_expiry.up.sql", size: 104, mode: os.FileMode(0644), modTime: time.Unix(1792144267, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0x1f, 0x60, 0x45, 0x47, 0x62, 0x5b, 0xd0, 0xe8, 0x73, 0x7, 0xf3, 0x1c, 0xee, 0x96, 0xd3, 0x9e, 0x7c, 0x80, 0xff, 0x22, 0xe0, 0x7f, 0xb5, 0xb7, 0x32, 0x31, 0x1, 0xa6, 0x19, 0xa1, 0x82}}
+	return a, nil
+}
+
+var __20200205090000_blob_versionsDownSql = []byte(`DROP TABLE blob_versions;
+`)
+
+func _20200205090000_blob_versionsDownSqlBytes() ([]byte, error) {
+	return __20200205090000_blob_versionsDownSql, nil
+}
+
+func _20200205090000_blob_versionsDownSql() (*asset, error) {
+	bytes, err := _20200205090000_blob_versionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200205090000_blob_versions.down.sql", size: 26, mode: os.FileMode(0644), modTime: time.Unix(1792145044, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xca, 0x16, 0xcd, 0x82, 0xf5, 0xd, 0xb8, 0x0, 0x8a, 0x8e, 0x4f, 0x69, 0x38, 0xff, 0x35, 0x12, 0xf9, 0x27, 0x4d, 0x39, 0x64, 0xed, 0xca, 0xc6, 0x15, 0x1a, 0x8, 0x13, 0x39, 0x3d, 0xa3, 0x2f}}
+	return a, nil
+}
+
+var __20200205090000_blob_versionsUpSql = []byte(`CREATE TABLE blob_versions (
+    id INTEGER PRIMARY KEY,
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BLOB NOT NULL DEFAULT X'',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
+`)
+
+func _20200205090000_blob_versionsUpSqlBytes() ([]byte, error) {
+	return __20200205090000_blob_versionsUpSql, nil
+}
+
+func _20200205090000_blob_versionsUpSql() (*asset, error) {
+	bytes, err := _20200205090000_blob_versionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200205090000_blob_versions.up.sql", size: 550, mode: os.FileMode(0644), modTime: time.Unix(1792145044, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd6, 0xf4, 0xc, 0xfe, 0xee, 0x1, 0xa5, 0x67, 0xe4, 0x20, 0xa4, 0x6c, 0x89, 0x64, 0x81, 0x2d, 0xa1, 0x99, 0x7a, 0x8b, 0x51, 0x36, 0xb, 0x3a, 0xf6, 0x1c, 0xcf, 0x91, 0xde, 0x33, 0x61, 0x9a}}
+	return a, nil
+}
+
+var __20200206090000_blob_trashDownSql = []byte(`-- SQLite can't drop a column, rebuild blobs without it. See 20200131090000_blob_owner.down.sql.
+-- Dropping blobs cascades to blobs_tags and blob_versions, so both are kept aside.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blob_versions_backup AS SELECT * FROM blob_versions;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id, expires_at
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id),
+    expires_at DATETIME
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+INSERT OR IGNORE INTO blob_versions SELECT * FROM blob_versions_backup;
+DROP TABLE blob_versions_backup;
+`)
+
+func _20200206090000_blob_trashDownSqlBytes() ([]byte, error) {
+	return __20200206090000_blob_trashDownSql, nil
+}
+
+func _20200206090000_blob_trashDownSql() (*asset, error) {
+	bytes, err := _20200206090000_blob_trashDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200206090000_blob_trash.down.sql", size: 1864, mode: os.FileMode(0644), modTime: time.Unix(1792145714, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x86, 0x7f, 0xff, 0xa2, 0xbf, 0x27, 0x1, 0x4a, 0xe2, 0xd2, 0xe0, 0x10, 0x67, 0xe9, 0xbe, 0xb0, 0xed, 0x81, 0xf3, 0x3c, 0xce, 0xc0, 0xd0, 0x4b, 0xe1, 0xf3, 0xa0, 0xf8, 0x84, 0x4c, 0x4d, 0x81}}
+	return a, nil
+}
+
+var __20200206090000_blob_trashUpSql = []byte(`ALTER TABLE blobs ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
+`)
+
+func _20200206090000_blob_trashUpSqlBytes() ([]byte, error) {
+	return __20200206090000_blob_trashUpSql, nil
+}
+
+func _20200206090000_blob_trashUpSql() (*asset, error) {
+	bytes, err := _20200206090000_blob_trashUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200206090000_blob_trash.up.sql", size: 104, mode: os.FileMode(0644), modTime: time.Unix(1792145705, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x30, 0xfb, 0xa6, 0x82, 0xf9, 0x68, 0x8c, 0x70, 0x97, 0x47, 0xcc, 0x96, 0xc6, 0x26, 0x24, 0x2a, 0x2e, 0xfa, 0xea, 0x44, 0x17, 0x58, 0x10, 0x66, 0x87, 0x3a, 0xde, 0x39, 0x5d, 0xfc, 0xac, 0x42}}
+	return a, nil
+}
+
+var __20200207090000_blob_sealed_finalDownSql = []byte(`-- Blobs stored since can't be opened without this flag, roll back only before storing any.
+-- SQLite can't drop a column, rebuild blobs and blob_versions without it as in
+-- 20200206090000_blob_trash.down.sql. Dropping blobs cascades to blobs_tags and
+-- blob_versions, so both are kept aside.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blob_versions_backup AS
+SELECT id, blob_id, version, mime_type, file_size_bytes, extension, file, nonce, compressed, segment_size, checksum, created_at
+FROM blob_versions;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id, expires_at, deleted_at
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id),
+    expires_at DATETIME,
+    deleted_at DATETIME
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+DROP TABLE blob_versions;
+CREATE TABLE blob_versions (
+    id INTEGER PRIMARY KEY,
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BLOB NOT NULL DEFAULT X'',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
+INSERT OR IGNORE INTO blob_versions SELECT * FROM blob_versions_backup;
+DROP TABLE blob_versions_backup;
+`)
+
+func _20200207090000_blob_sealed_finalDownSqlBytes() ([]byte, error) {
+	return __20200207090000_blob_sealed_finalDownSql, nil
+}
+
+func _20200207090000_blob_sealed_finalDownSql() (*asset, error) {
+	bytes, err := _20200207090000_blob_sealed_finalDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200207090000_blob_sealed_final.down.sql", size: 2763, mode: os.FileMode(0644), modTime: time.Unix(1792147030, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x79, 0x78, 0x83, 0xe5, 0xd8, 0x55, 0xe8, 0x33, 0x86, 0x17, 0x34, 0xda, 0x5e, 0xe6, 0x80, 0x2f, 0x8b, 0x68, 0xc4, 0x1d, 0xa5, 0xd8, 0xc0, 0xa6, 0xd9, 0xb1, 0x7c, 0x25, 0xa0, 0xfe, 0x4d, 0x92}}
+	return a, nil
+}
+
+var __20200207090000_blob_sealed_finalUpSql = []byte(`-- Blobs sealed with a distinct AAD on their last segment, so truncation is detected.
+-- Rows stored before keep 0 and are checked against their size instead.
+ALTER TABLE blobs ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE blob_versions ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT 0;
+`)
+
+func _20200207090000_blob_sealed_finalUpSqlBytes() ([]byte, error) {
+	return __20200207090000_blob_sealed_finalUpSql, nil
+}
+
+func _20200207090000_blob_sealed_finalUpSql() (*asset, error) {
+	bytes, err := _20200207090000_blob_sealed_finalUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200207090000_blob_sealed_final.up.sql", size: 307, mode: os.FileMode(0644), modTime: time.Unix(1792147017, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa2, 0xb7, 0xcd, 0xc6, 0x4d, 0x1f, 0x20, 0x94, 0xf, 0x22, 0x17, 0xaf, 0xba, 0xfd, 0xb5, 0x4a, 0xf1, 0xf1, 0x65, 0x16, 0xa6, 0x96, 0xbe, 0x1b, 0xf8, 0xa5, 0x92, 0x5e, 0x5b, 0x81, 0xa9, 0x9a}}
+	return a, nil
+}
+
+var __20200208090000_blob_content_updated_atDownSql = []byte(`-- SQLite can't drop a column, rebuild blobs without it as in
+-- 20200207090000_blob_sealed_final.down.sql. Dropping blobs cascades to blobs_tags
+-- and blob_versions, so both are kept aside.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blob_versions_backup AS SELECT * FROM blob_versions;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id, expires_at, deleted_at, sealed_final
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id),
+    expires_at DATETIME,
+    deleted_at DATETIME,
+    sealed_final BOOLEAN NOT NULL DEFAULT 0
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+INSERT OR IGNORE INTO blob_versions SELECT * FROM blob_versions_backup;
+DROP TABLE blob_versions_backup;
+`)
+
+func _20200208090000_blob_content_updated_atDownSqlBytes() ([]byte, error) {
+	return __20200208090000_blob_content_updated_atDownSql, nil
+}
+
+func _20200208090000_blob_content_updated_atDownSql() (*asset, error) {
+	bytes, err := _20200208090000_blob_content_updated_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200208090000_blob_content_updated_at.down.sql", size: 2024, mode: os.FileMode(0644), modTime: time.Unix(1792147706, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfb, 0x1f, 0xd9, 0xa8, 0xd2, 0xaa, 0xb0, 0xae, 0x68, 0x1b, 0x91, 0x4e, 0x3e, 0x9f, 0xa4, 0x6, 0xe7, 0x70, 0x4, 0x8a, 0x65, 0xfa, 0x7, 0xef, 0x36, 0x88, 0x46, 0xf6, 0xc3, 0x4, 0x57, 0xc8}}
+	return a, nil
+}
+
+var __20200208090000_blob_content_updated_atUpSql = []byte(`-- When a blob's current contents were stored, set once versioning replaces them.
+-- created_at is immutable, so the blob's first contents leave this NULL and use it instead.
+ALTER TABLE blobs ADD COLUMN content_updated_at DATETIME;
+`)
+
+func _20200208090000_blob_content_updated_atUpSqlBytes() ([]byte, error) {
+	return __20200208090000_blob_content_updated_atUpSql, nil
+}
+
+func _20200208090000_blob_content_updated_atUpSql() (*asset, error) {
+	bytes, err := _20200208090000_blob_content_updated_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200208090000_blob_content_updated_at.up.sql", size: 233, mode: os.FileMode(0644), modTime: time.Unix(1792147706, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0xfb, 0x3, 0x35, 0x5, 0xc8, 0xe3, 0x5a, 0xe4, 0xe2, 0xb4, 0x9f, 0x4d, 0xb3, 0x5, 0xe1, 0x79, 0xfd, 0x8b, 0xc, 0x51, 0x11, 0x50, 0xe2, 0xdd, 0x9e, 0x2e, 0xdf, 0xf7, 0x43, 0x40, 0xc8}}
+	return a, nil
+}
+
+var __20200209090000_idempotency_request_hashDownSql = []byte(`-- SQLite can't drop a column, rebuild the table without it. Nothing references it.
+CREATE TABLE idempotency_keys_backup AS
+SELECT user_id, key, status_code, response, expires_at, created_at
+FROM idempotency_keys;
+
+DROP TABLE idempotency_keys;
+CREATE TABLE idempotency_keys (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    key VARCHAR NOT NULL,
+    -- NULL until the first request with the key completes
+    status_code INTEGER,
+    response BLOB,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+INSERT INTO idempotency_keys SELECT * FROM idempotency_keys_backup;
+DROP TABLE idempotency_keys_backup;
+
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
+`)
+
+func _20200209090000_idempotency_request_hashDownSqlBytes() ([]byte, error) {
+	return __20200209090000_idempotency_request_hashDownSql, nil
+}
+
+func _20200209090000_idempotency_request_hashDownSql() (*asset, error) {
+	bytes, err := _20200209090000_idempotency_request_hashDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200209090000_idempotency_request_hash.down.sql", size: 764, mode: os.FileMode(0644), modTime: time.Unix(1792148068, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0xcd, 0x1c, 0x0, 0x4c, 0xfc, 0x2, 0x49, 0x8b, 0xde, 0xe, 0x90, 0xd3, 0x86, 0xae, 0x7f, 0x43, 0xb8, 0xad, 0xfb, 0xa0, 0xd3, 0x19, 0xe4, 0xfa, 0x87, 0x32, 0x3f, 0xdc, 0x36, 0xe8, 0xf7}}
+	return a, nil
+}
+
+var __20200209090000_idempotency_request_hashUpSql = []byte(`-- Fingerprint of the request that used the key, a repeat must match it to get the
+-- response replayed. NULL for keys saved before, those replay as they did.
+ALTER TABLE idempotency_keys ADD COLUMN request_hash BLOB;
+`)
+
+func _20200209090000_idempotency_request_hashUpSqlBytes() ([]byte, error) {
+	return __20200209090000_idempotency_request_hashUpSql, nil
+}
+
+func _20200209090000_idempotency_request_hashUpSql() (*asset, error) {
+	bytes, err := _20200209090000_idempotency_request_hashUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "20200209090000_idempotency_request_hash.up.sql", size: 218, mode: os.FileMode(0644), modTime: time.Unix(1792148068, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9e, 0x35, 0x44, 0xc2, 0xc4, 0x68, 0xf9, 0x71, 0xea, 0x0, 0xdc, 0xee, 0x8d, 0x8a, 0x3d, 0xf6, 0x39, 0xf7, 0xe8, 0x7b, 0xec, 0xa9, 0xd8, 0xa8, 0x15, 0xa, 0xd, 0xa6, 0xad, 0x8b, 0x5b, 0x61}}
+	return a, nil
+}
+
+var _postgres20191225220909_initial_migrationDownSql = []byte("")
+
+func postgres20191225220909_initial_migrationDownSqlBytes() ([]byte, error) {
+	return _postgres20191225220909_initial_migrationDownSql, nil
+}
+
+func postgres20191225220909_initial_migrationDownSql() (*asset, error) {
+	bytes, err := postgres20191225220909_initial_migrationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20191225220909_initial_migration.down.sql", size: 0, mode: os.FileMode(0644), modTime: time.Unix(1792142780, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe3, 0xb0, 0xc4, 0x42, 0x98, 0xfc, 0x1c, 0x14, 0x9a, 0xfb, 0xf4, 0xc8, 0x99, 0x6f, 0xb9, 0x24, 0x27, 0xae, 0x41, 0xe4, 0x64, 0x9b, 0x93, 0x4c, 0xa4, 0x95, 0x99, 0x1b, 0x78, 0x52, 0xb8, 0x55}}
+	return a, nil
+}
+
+var _postgres20191225220909_initial_migrationUpSql = []byte(`CREATE TABLE tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE taxonomies (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE projects (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    sequence INTEGER NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE documents (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL REFERENCES projects(id),
+    taxonomy_id BIGINT NOT NULL REFERENCES taxonomies(id),
+    sequence INTEGER NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE blobs (
+    id BIGSERIAL PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes BIGINT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BYTEA NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE documents_blobs (
+    document_id BIGINT NOT NULL REFERENCES documents(id),
+    blob_id BIGINT NOT NULL REFERENCES blobs(id),
+    version VARCHAR NOT NULL,
+    PRIMARY KEY (document_id, blob_id)
+);
+
+CREATE TABLE documents_tags (
+    document_id BIGINT NOT NULL REFERENCES documents(id),
+    tag_id BIGINT NOT NULL REFERENCES tags(id),
+    PRIMARY KEY (document_id, tag_id)
+);
+`)
+
+func postgres20191225220909_initial_migrationUpSqlBytes() ([]byte, error) {
+	return _postgres20191225220909_initial_migrationUpSql, nil
+}
+
+func postgres20191225220909_initial_migrationUpSql() (*asset, error) {
+	bytes, err := postgres20191225220909_initial_migrationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20191225220909_initial_migration.up.sql", size: 2135, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x54, 0x83, 0x3c, 0x33, 0xdf, 0xd6, 0xbd, 0x82, 0x4c, 0xc3, 0x3c, 0x14, 0xfd, 0x99, 0x8c, 0xb5, 0xb2, 0x24, 0x65, 0x6a, 0xd7, 0x25, 0x34, 0x86, 0xea, 0x3d, 0x1d, 0x4f, 0x34, 0x88, 0xb, 0xc}}
+	return a, nil
+}
+
+var _postgres20200123093000_blob_checksumDownSql = []byte(`ALTER TABLE blobs DROP COLUMN checksum;
+`)
+
+func postgres20200123093000_blob_checksumDownSqlBytes() ([]byte, error) {
+	return _postgres20200123093000_blob_checksumDownSql, nil
+}
+
+func postgres20200123093000_blob_checksumDownSql() (*asset, error) {
+	bytes, err := postgres20200123093000_blob_checksumDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200123093000_blob_checksum.down.sql", size: 40, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb4, 0x90, 0x29, 0x6, 0x92, 0x67, 0xf6, 0xe3, 0xd, 0xef, 0xb9, 0xf9, 0x88, 0xc7, 0x91, 0x97, 0xd, 0x8c, 0x8b, 0xf4, 0xae, 0x42, 0x18, 0x6a, 0x31, 0x15, 0xb3, 0xc6, 0xe9, 0xb, 0x96, 0xda}}
+	return a, nil
+}
+
+var _postgres20200123093000_blob_checksumUpSql = []byte(`ALTER TABLE blobs ADD COLUMN checksum VARCHAR NOT NULL DEFAULT '';
+`)
+
+func postgres20200123093000_blob_checksumUpSqlBytes() ([]byte, error) {
+	return _postgres20200123093000_blob_checksumUpSql, nil
+}
+
+func postgres20200123093000_blob_checksumUpSql() (*asset, error) {
+	bytes, err := postgres20200123093000_blob_checksumUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200123093000_blob_checksum.up.sql", size: 67, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5b, 0x54, 0x89, 0x33, 0x44, 0x54, 0x47, 0x3b, 0xb5, 0x45, 0xde, 0xb6, 0xa1, 0x67, 0x15, 0x24, 0xc0, 0x97, 0x20, 0x57, 0x9, 0x16, 0x33, 0x2c, 0xce, 0xd3, 0xb0, 0xd1, 0x98, 0x84, 0x98, 0x52}}
+	return a, nil
+}
+
+var _postgres20200123101500_blob_nonceDownSql = []byte(`ALTER TABLE blobs DROP COLUMN nonce;
+`)
+
+func postgres20200123101500_blob_nonceDownSqlBytes() ([]byte, error) {
+	return _postgres20200123101500_blob_nonceDownSql, nil
+}
+
+func postgres20200123101500_blob_nonceDownSql() (*asset, error) {
+	bytes, err := postgres20200123101500_blob_nonceDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200123101500_blob_nonce.down.sql", size: 37, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x58, 0xd6, 0x11, 0xf8, 0x1d, 0xa7, 0x56, 0xe7, 0x92, 0xee, 0xb7, 0xbd, 0x99, 0xc0, 0x6a, 0x3e, 0xb5, 0x57, 0x60, 0x9a, 0xa3, 0xd4, 0x4, 0xf3, 0xa5, 0x4e, 0xaa, 0x1c, 0xa6, 0x76, 0xd6, 0x53}}
+	return a, nil
+}
+
+var _postgres20200123101500_blob_nonceUpSql = []byte(`ALTER TABLE blobs ADD COLUMN nonce BYTEA NOT NULL DEFAULT '\x';
+`)
+
+func postgres20200123101500_blob_nonceUpSqlBytes() ([]byte, error) {
+	return _postgres20200123101500_blob_nonceUpSql, nil
+}
+
+func postgres20200123101500_blob_nonceUpSql() (*asset, error) {
+	bytes, err := postgres20200123101500_blob_nonceUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200123101500_blob_nonce.up.sql", size: 64, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcd, 0xb2, 0xc5, 0xc9, 0xd5, 0x1e, 0xab, 0x9b, 0xf9, 0xd8, 0x39, 0xe7, 0xd1, 0xc4, 0xe8, 0x7b, 0xca, 0x6b, 0x34, 0x73, 0x1c, 0x46, 0x5d, 0xe0, 0xe1, 0x85, 0x8e, 0x6c, 0x71, 0x99, 0x52, 0xf}}
+	return a, nil
+}
+
+var _postgres20200124090000_usersDownSql = []byte(`DROP TABLE users;
+`)
+
+func postgres20200124090000_usersDownSqlBytes() ([]byte, error) {
+	return _postgres20200124090000_usersDownSql, nil
+}
+
+func postgres20200124090000_usersDownSql() (*asset, error) {
+	bytes, err := postgres20200124090000_usersDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200124090000_users.down.sql", size: 18, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xde, 0x10, 0x15, 0x70, 0x7e, 0x41, 0xd6, 0x68, 0x21, 0x86, 0xc3, 0x44, 0xc, 0x58, 0xb2, 0x22, 0x51, 0x8e, 0x75, 0xea, 0xe7, 0xbe, 0xcf, 0xba, 0x74, 0x34, 0x64, 0x68, 0xee, 0xfd, 0xfc, 0x5d}}
+	return a, nil
+}
+
+var _postgres20200124090000_usersUpSql = []byte(`CREATE TABLE users (
+    id BIGSERIAL PRIMARY KEY,
+    username VARCHAR UNIQUE NOT NULL,
+    password_hash VARCHAR NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`)
+
+func postgres20200124090000_usersUpSqlBytes() ([]byte, error) {
+	return _postgres20200124090000_usersUpSql, nil
+}
+
+func postgres20200124090000_usersUpSql() (*asset, error) {
+	bytes, err := postgres20200124090000_usersUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200124090000_users.up.sql", size: 328, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x39, 0x70, 0x33, 0x96, 0xe9, 0x29, 0xc6, 0x40, 0xab, 0x43, 0x4a, 0xbb, 0xe3, 0xaf, 0x79, 0xd0, 0xda, 0x68, 0x95, 0xb7, 0xfc, 0x71, 0x3, 0xef, 0x3f, 0xc0, 0x5a, 0x41, 0x1d, 0x24, 0x1a, 0xc0}}
+	return a, nil
+}
+
+var _postgres20200125090000_blob_compressedDownSql = []byte(`ALTER TABLE blobs DROP COLUMN compressed;
+`)
+
+func postgres20200125090000_blob_compressedDownSqlBytes() ([]byte, error) {
+	return _postgres20200125090000_blob_compressedDownSql, nil
+}
+
+func postgres20200125090000_blob_compressedDownSql() (*asset, error) {
+	bytes, err := postgres20200125090000_blob_compressedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200125090000_blob_compressed.down.sql", size: 42, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc6, 0x4e, 0x1f, 0x99, 0x30, 0x65, 0x97, 0xba, 0x25, 0x15, 0x56, 0x9, 0x50, 0x56, 0x2b, 0xb1, 0x49, 0x6e, 0x9c, 0x7, 0xab, 0x75, 0x25, 0x43, 0x75, 0x19, 0x79, 0xd4, 0xed, 0xf9, 0xbd, 0xde}}
+	return a, nil
+}
+
+var _postgres20200125090000_blob_compressedUpSql = []byte(`ALTER TABLE blobs ADD COLUMN compressed BOOLEAN NOT NULL DEFAULT FALSE;
+`)
+
+func postgres20200125090000_blob_compressedUpSqlBytes() ([]byte, error) {
+	return _postgres20200125090000_blob_compressedUpSql, nil
+}
+
+func postgres20200125090000_blob_compressedUpSql() (*asset, error) {
+	bytes, err := postgres20200125090000_blob_compressedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200125090000_blob_compressed.up.sql", size: 72, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa5, 0x5a, 0xf8, 0x5b, 0x33, 0xd3, 0x85, 0xa, 0xb7, 0x4c, 0x50, 0xdc, 0x5e, 0xdd, 0xfa, 0x8c, 0xb1, 0x69, 0xc6, 0x5d, 0x89, 0x25, 0xbc, 0xf, 0x42, 0x68, 0x9e, 0x78, 0xdc, 0xeb, 0xe9, 0x21}}
+	return a, nil
+}
+
+var _postgres20200126090000_blob_segment_sizeDownSql = []byte(`ALTER TABLE blobs DROP COLUMN segment_size;
+`)
+
+func postgres20200126090000_blob_segment_sizeDownSqlBytes() ([]byte, error) {
+	return _postgres20200126090000_blob_segment_sizeDownSql, nil
+}
+
+func postgres20200126090000_blob_segment_sizeDownSql() (*asset, error) {
+	bytes, err := postgres20200126090000_blob_segment_sizeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200126090000_blob_segment_size.down.sql", size: 44, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x2b, 0xf8, 0x22, 0xb9, 0xe5, 0xbc, 0x27, 0x62, 0x3b, 0xc2, 0xae, 0x6e, 0x87, 0x39, 0xbc, 0x20, 0x79, 0x2b, 0xba, 0x64, 0xca, 0xc5, 0xf9, 0xf9, 0xdc, 0x20, 0x12, 0xed, 0x2b, 0x4c, 0xe8, 0x86}}
+	return a, nil
+}
+
+var _postgres20200126090000_blob_segment_sizeUpSql = []byte(`ALTER TABLE blobs ADD COLUMN segment_size INTEGER NOT NULL DEFAULT 0;
+`)
+
+func postgres20200126090000_blob_segment_sizeUpSqlBytes() ([]byte, error) {
+	return _postgres20200126090000_blob_segment_sizeUpSql, nil
+}
+
+func postgres20200126090000_blob_segment_sizeUpSql() (*asset, error) {
+	bytes, err := postgres20200126090000_blob_segment_sizeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200126090000_blob_segment_size.up.sql", size: 70, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9a, 0xf8, 0xbf, 0x10, 0xd6, 0x81, 0xb4, 0x7f, 0x45, 0xa2, 0x8a, 0xf7, 0xfd, 0x67, 0xca, 0xd6, 0x73, 0x98, 0x16, 0x81, 0xf5, 0x7a, 0xed, 0x69, 0xde, 0xea, 0x30, 0xd4, 0xa5, 0x1d, 0x9c, 0x37}}
+	return a, nil
+}
+
+var _postgres20200127090000_blob_created_at_immutableDownSql = []byte(`DROP TRIGGER blobs_created_at_immutable ON blobs;
+DROP FUNCTION blobs_created_at_immutable();
+`)
+
+func postgres20200127090000_blob_created_at_immutableDownSqlBytes() ([]byte, error) {
+	return _postgres20200127090000_blob_created_at_immutableDownSql, nil
+}
+
+func postgres20200127090000_blob_created_at_immutableDownSql() (*asset, error) {
+	bytes, err := postgres20200127090000_blob_created_at_immutableDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200127090000_blob_created_at_immutable.down.sql", size: 94, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0xbc, 0x68, 0x6f, 0x94, 0xa0, 0x9b, 0x6d, 0xb8, 0x8e, 0x5e, 0x76, 0xe6, 0x58, 0xc0, 0x68, 0x3d, 0x6f, 0x22, 0x71, 0x98, 0x9c, 0xb2, 0x92, 0xcc, 0x93, 0xf2, 0x34, 0x8d, 0x1a, 0x6b, 0xb8}}
+	return a, nil
+}
+
+var _postgres20200127090000_blob_created_at_immutableUpSql = []byte(`CREATE FUNCTION blobs_created_at_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'blobs.created_at is immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+FOR EACH ROW
+WHEN (NEW.created_at IS DISTINCT FROM OLD.created_at)
+EXECUTE PROCEDURE blobs_created_at_immutable();
+`)
+
+func postgres20200127090000_blob_created_at_immutableUpSqlBytes() ([]byte, error) {
+	return _postgres20200127090000_blob_created_at_immutableUpSql, nil
+}
+
+func postgres20200127090000_blob_created_at_immutableUpSql() (*asset, error) {
+	bytes, err := postgres20200127090000_blob_created_at_immutableUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200127090000_blob_created_at_immutable.up.sql", size: 347, mode: os.FileMode(0644), modTime: time.Unix(1792142775, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0xc3, 0xb7, 0x7, 0xa3, 0xe1, 0x84, 0x4, 0x64, 0xdc, 0xf8, 0x5, 0x32, 0x52, 0x33, 0xc8, 0x56, 0xd8, 0x81, 0x1, 0x7a, 0xb, 0x7b, 0x8c, 0x14, 0xd0, 0x72, 0xc3, 0x98, 0x98, 0xf9, 0x33}}
+	return a, nil
+}
+
+var _postgres20200128090000_audit_logDownSql = []byte(`DROP TABLE audit_log;
+`)
+
+func postgres20200128090000_audit_logDownSqlBytes() ([]byte, error) {
+	return _postgres20200128090000_audit_logDownSql, nil
+}
+
+func postgres20200128090000_audit_logDownSql() (*asset, error) {
+	bytes, err := postgres20200128090000_audit_logDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200128090000_audit_log.down.sql", size: 22, mode: os.FileMode(0644), modTime: time.Unix(1792142881, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x82, 0xca, 0x23, 0xe2, 0x6d, 0xe0, 0x36, 0x7c, 0x9c, 0xc, 0x35, 0x56, 0xbe, 0x2, 0x12, 0x2e, 0x59, 0xb8, 0x39, 0x39, 0x7f, 0x75, 0x5f, 0xe8, 0x8a, 0xb6, 0xd1, 0x89, 0xf5, 0xfb, 0x52, 0xa8}}
+	return a, nil
+}
+
+var _postgres20200128090000_audit_logUpSql = []byte(`CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id),
+    username VARCHAR NOT NULL,
+    action VARCHAR NOT NULL,
+    blob_file_name VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at ON audit_log (created_at);
+`)
+
+func postgres20200128090000_audit_logUpSqlBytes() ([]byte, error) {
+	return _postgres20200128090000_audit_logUpSql, nil
+}
+
+func postgres20200128090000_audit_logUpSql() (*asset, error) {
+	bytes, err := postgres20200128090000_audit_logUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200128090000_audit_log.up.sql", size: 320, mode: os.FileMode(0644), modTime: time.Unix(1792142881, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8e, 0xeb, 0xe2, 0xda, 0xc5, 0x59, 0x63, 0x38, 0x21, 0xc, 0xdb, 0x80, 0x69, 0x5d, 0x2a, 0xda, 0x75, 0xd8, 0xb2, 0x0, 0x81, 0xf, 0x1e, 0x91, 0x4a, 0xaa, 0x38, 0xff, 0x97, 0x50, 0xab, 0x38}}
+	return a, nil
+}
+
+var _postgres20200129090000_api_keysDownSql = []byte(`DROP TABLE api_keys;
+`)
+
+func postgres20200129090000_api_keysDownSqlBytes() ([]byte, error) {
+	return _postgres20200129090000_api_keysDownSql, nil
+}
+
+func postgres20200129090000_api_keysDownSql() (*asset, error) {
+	bytes, err := postgres20200129090000_api_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200129090000_api_keys.down.sql", size: 21, mode: os.FileMode(0644), modTime: time.Unix(1792143133, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0xe2, 0x8, 0xed, 0xac, 0x73, 0xca, 0x54, 0xcf, 0x4e, 0x32, 0xaa, 0x66, 0xd7, 0x5b, 0x62, 0x76, 0x81, 0xb9, 0x7, 0xba, 0xf3, 0x71, 0xea, 0x7, 0x13, 0x76, 0xc7, 0xb3, 0xfd, 0x4d, 0xf4}}
+	return a, nil
+}
+
+var _postgres20200129090000_api_keysUpSql = []byte(`CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR UNIQUE NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`)
+
+func postgres20200129090000_api_keysUpSqlBytes() ([]byte, error) {
+	return _postgres20200129090000_api_keysUpSql, nil
+}
+
+func postgres20200129090000_api_keysUpSql() (*asset, error) {
+	bytes, err := postgres20200129090000_api_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200129090000_api_keys.up.sql", size: 372, mode: os.FileMode(0644), modTime: time.Unix(1792143133, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x31, 0x9a, 0x43, 0xef, 0x4b, 0xc2, 0xdc, 0x33, 0x9b, 0x99, 0xb3, 0x46, 0x37, 0x6c, 0x36, 0xf7, 0xaa, 0xb0, 0x5b, 0x21, 0x60, 0x12, 0x80, 0x4d, 0x17, 0x4c, 0x5f, 0xe9, 0xaa, 0xf2, 0xa2, 0x2f}}
+	return a, nil
+}
+
+var _postgres20200130090000_blobs_tagsDownSql = []byte(`DROP TABLE blobs_tags;
+DROP INDEX tags_name;
+`)
+
+func postgres20200130090000_blobs_tagsDownSqlBytes() ([]byte, error) {
+	return _postgres20200130090000_blobs_tagsDownSql, nil
+}
+
+func postgres20200130090000_blobs_tagsDownSql() (*asset, error) {
+	bytes, err := postgres20200130090000_blobs_tagsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200130090000_blobs_tags.down.sql", size: 45, mode: os.FileMode(0644), modTime: time.Unix(1792143161, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x83, 0x7b, 0xde, 0x49, 0xa, 0x2d, 0x1a, 0x2c, 0x4f, 0x5d, 0x9d, 0x93, 0x4b, 0xe8, 0x27, 0x25, 0xba, 0xe1, 0xfd, 0x89, 0x77, 0xf8, 0xd2, 0xe7, 0x9b, 0xdc, 0x8e, 0xa8, 0xa5, 0x94, 0xdd, 0xe2}}
+	return a, nil
+}
+
+var _postgres20200130090000_blobs_tagsUpSql = []byte(`CREATE UNIQUE INDEX tags_name ON tags (name);
+
+CREATE TABLE blobs_tags (
+    blob_id BIGINT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (blob_id, tag_id)
+);
+`)
+
+func postgres20200130090000_blobs_tagsUpSqlBytes() ([]byte, error) {
+	return _postgres20200130090000_blobs_tagsUpSql, nil
+}
+
+func postgres20200130090000_blobs_tagsUpSql() (*asset, error) {
+	bytes, err := postgres20200130090000_blobs_tagsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200130090000_blobs_tags.up.sql", size: 244, mode: os.FileMode(0644), modTime: time.Unix(1792143161, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0xb0, 0x5d, 0x46, 0xa, 0x9d, 0xa3, 0x57, 0xdc, 0xa1, 0xc1, 0xc6, 0x85, 0x5e, 0x49, 0xaf, 0x7, 0x5b, 0x2b, 0xa7, 0xd, 0xf2, 0xbd, 0x60, 0x35, 0xde, 0xdc, 0xf8, 0x6b, 0x5c, 0x46, 0x28}}
+	return a, nil
+}
+
+var _postgres20200131090000_blob_ownerDownSql = []byte(`DROP INDEX blobs_owner_id;
+ALTER TABLE blobs DROP COLUMN owner_id;
+ALTER TABLE users DROP COLUMN admin;
+`)
+
+func postgres20200131090000_blob_ownerDownSqlBytes() ([]byte, error) {
+	return _postgres20200131090000_blob_ownerDownSql, nil
+}
+
+func postgres20200131090000_blob_ownerDownSql() (*asset, error) {
+	bytes, err := postgres20200131090000_blob_ownerDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200131090000_blob_owner.down.sql", size: 104, mode: os.FileMode(0644), modTime: time.Unix(1792143425, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5c, 0x36, 0xc4, 0x25, 0xd4, 0xb3, 0xa6, 0x9e, 0x9d, 0x48, 0xb1, 0x53, 0xb, 0x8, 0xfa, 0x38, 0xb9, 0xd2, 0xf6, 0x73, 0xc, 0xaf, 0x4, 0x79, 0x8c, 0xbc, 0x1d, 0x1d, 0x21, 0x66, 0x32, 0xcf}}
+	return a, nil
+}
+
+var _postgres20200131090000_blob_ownerUpSql = []byte(`ALTER TABLE users ADD COLUMN admin BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE blobs ADD COLUMN owner_id BIGINT REFERENCES users(id);
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+`)
+
+func postgres20200131090000_blob_ownerUpSqlBytes() ([]byte, error) {
+	return _postgres20200131090000_blob_ownerUpSql, nil
+}
+
+func postgres20200131090000_blob_ownerUpSql() (*asset, error) {
+	bytes, err := postgres20200131090000_blob_ownerUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200131090000_blob_owner.up.sql", size: 183, mode: os.FileMode(0644), modTime: time.Unix(1792143425, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9c, 0x66, 0x2e, 0x58, 0xfe, 0x48, 0xe3, 0x15, 0x97, 0xdf, 0x5a, 0x31, 0x53, 0x1b, 0x66, 0xe6, 0xbd, 0x43, 0xf6, 0x14, 0x41, 0x8c, 0x2a, 0xb9, 0xfe, 0x9f, 0x2b, 0x3f, 0x28, 0xcb, 0x8a, 0x80}}
+	return a, nil
+}
+
+var _postgres20200201090000_blob_checksum_indexDownSql = []byte(`DROP INDEX blobs_checksum;
+`)
+
+func postgres20200201090000_blob_checksum_indexDownSqlBytes() ([]byte, error) {
+	return _postgres20200201090000_blob_checksum_indexDownSql, nil
+}
+
+func postgres20200201090000_blob_checksum_indexDownSql() (*asset, error) {
+	bytes, err := postgres20200201090000_blob_checksum_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200201090000_blob_checksum_index.down.sql", size: 27, mode: os.FileMode(0644), modTime: time.Unix(1792143704, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4b, 0xdf, 0xd0, 0xda, 0xa5, 0x17, 0x70, 0xca, 0x67, 0x75, 0x6f, 0x73, 0x6c, 0x6b, 0x7d, 0xf, 0xae, 0x95, 0x36, 0xc6, 0x89, 0x75, 0xa9, 0x88, 0x77, 0xa6, 0x2d, 0x34, 0x49, 0x48, 0x3b, 0x2a}}
+	return a, nil
+}
+
+var _postgres20200201090000_blob_checksum_indexUpSql = []byte(`CREATE INDEX blobs_checksum ON blobs (checksum);
+`)
+
+func postgres20200201090000_blob_checksum_indexUpSqlBytes() ([]byte, error) {
+	return _postgres20200201090000_blob_checksum_indexUpSql, nil
+}
+
+func postgres20200201090000_blob_checksum_indexUpSql() (*asset, error) {
+	bytes, err := postgres20200201090000_blob_checksum_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200201090000_blob_checksum_index.up.sql", size: 49, mode: os.FileMode(0644), modTime: time.Unix(1792143704, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbc, 0x4, 0x1b, 0x6b, 0x1c, 0xc1, 0x5f, 0x0, 0xd1, 0xb, 0x6, 0x0, 0xc2, 0x9, 0xa3, 0x64, 0x61, 0x29, 0x1a, 0x76, 0x96, 0x1a, 0xf4, 0x71, 0xe6, 0xca, 0x40, 0xec, 0xc7, 0x5c, 0xb6, 0x2}}
+	return a, nil
+}
+
+var _postgres20200202090000_sessionsDownSql = []byte(`DROP TABLE sessions;
+`)
+
+func postgres20200202090000_sessionsDownSqlBytes() ([]byte, error) {
+	return _postgres20200202090000_sessionsDownSql, nil
+}
+
+func postgres20200202090000_sessionsDownSql() (*asset, error) {
+	bytes, err := postgres20200202090000_sessionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200202090000_sessions.down.sql", size: 21, mode: os.FileMode(0644), modTime: time.Unix(1792143945, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4c, 0xb7, 0xd5, 0x77, 0xc8, 0x8a, 0xdc, 0xe1, 0x1c, 0x4d, 0x35, 0x2e, 0x6f, 0x6c, 0x63, 0x5c, 0xa6, 0x38, 0x7e, 0x26, 0xae, 0x86, 0xea, 0xbc, 0xe6, 0x7a, 0x0, 0xf7, 0xfa, 0x4c, 0xdf, 0xbb}}
+	return a, nil
+}
+
+var _postgres20200202090000_sessionsUpSql = []byte(`CREATE TABLE sessions (
+    token VARCHAR PRIMARY KEY,
+    data BYTEA NOT NULL,
+    expiry TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX sessions_expiry ON sessions (expiry);
+`)
+
+func postgres20200202090000_sessionsUpSqlBytes() ([]byte, error) {
+	return _postgres20200202090000_sessionsUpSql, nil
+}
+
+func postgres20200202090000_sessionsUpSql() (*asset, error) {
+	bytes, err := postgres20200202090000_sessionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200202090000_sessions.up.sql", size: 167, mode: os.FileMode(0644), modTime: time.Unix(1792143945, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x75, 0xb9, 0x9f, 0xf, 0xdd, 0xa6, 0x64, 0x33, 0xf4, 0x4, 0xde, 0xed, 0x95, 0xaa, 0xa7, 0x3f, 0xba, 0x7b, 0xdf, 0x9, 0xaa, 0x20, 0x1d, 0x50, 0xcf, 0xdf, 0x2f, 0x5d, 0xdd, 0xaf, 0x1f, 0xa0}}
+	return a, nil
+}
+
+var _postgres20200203090000_idempotency_keysDownSql = []byte(`DROP TABLE idempotency_keys;
+`)
+
+func postgres20200203090000_idempotency_keysDownSqlBytes() ([]byte, error) {
+	return _postgres20200203090000_idempotency_keysDownSql, nil
+}
+
+func postgres20200203090000_idempotency_keysDownSql() (*asset, error) {
+	bytes, err := postgres20200203090000_idempotency_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200203090000_idempotency_keys.down.sql", size: 29, mode: os.FileMode(0644), modTime: time.Unix(1792144200, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0x70, 0xb, 0x95, 0xf, 0xe8, 0xa6, 0xc9, 0x43, 0x2c, 0x17, 0x1a, 0x90, 0x29, 0x37, 0xe3, 0x20, 0x71, 0x38, 0xe3, 0xf3, 0xd1, 0xc7, 0x16, 0x73, 0xb8, 0x91, 0x50, 0xab, 0x49, 0xff, 0x21}}
+	return a, nil
+}
+
+var _postgres20200203090000_idempotency_keysUpSql = []byte(`CREATE TABLE idempotency_keys (
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    key VARCHAR NOT NULL,
+    -- NULL until the first request with the key completes
+    status_code INTEGER,
+    response BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
+`)
+
+func postgres20200203090000_idempotency_keysUpSqlBytes() ([]byte, error) {
+	return _postgres20200203090000_idempotency_keysUpSql, nil
+}
+
+func postgres20200203090000_idempotency_keysUpSql() (*asset, error) {
+	bytes, err := postgres20200203090000_idempotency_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200203090000_idempotency_keys.up.sql", size: 422, mode: os.FileMode(0644), modTime: time.Unix(1792144200, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe5, 0x8e, 0x19, 0xc2, 0xc7, 0x83, 0xb1, 0x30, 0xfd, 0x41, 0xcc, 0x68, 0xf3, 0x39, 0x2c, 0xde, 0x46, 0x68, 0x42, 0xcf, 0x19, 0x11, 0xcd, 0x79, 0x7e, 0xfe, 0x76, 0x25, 0xc6, 0x9a, 0xd6, 0x61}}
+	return a, nil
+}
+
+var _postgres20200204090000_blob_expiryDownSql = []byte(`DROP INDEX blobs_expires_at;
+ALTER TABLE blobs DROP COLUMN expires_at;
+`)
+
+func postgres20200204090000_blob_expiryDownSqlBytes() ([]byte, error) {
+	return _postgres20200204090000_blob_expiryDownSql, nil
+}
+
+func postgres20200204090000_blob_expiryDownSql() (*asset, error) {
+	bytes, err := postgres20200204090000_blob_expiryDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200204090000_blob_expiry.down.sql", size: 71, mode: os.FileMode(0644), modTime: time.Unix(1792144267, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x67, 0x5b, 0xf4, 0xd0, 0x17, 0x27, 0xf0, 0x5b, 0xd5, 0x0, 0x40, 0x20, 0xf0, 0x6, 0xfc, 0xb3, 0xbd, 0xc1, 0x61, 0x6c, 0x25, 0x9a, 0x9, 0xd1, 0xa5, 0x3e, 0x89, 0x35, 0x7f, 0x80, 0xbc, 0x51}}
+	return a, nil
+}
+
+var _postgres20200204090000_blob_expiryUpSql = []byte(`ALTER TABLE blobs ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+`)
+
+func postgres20200204090000_blob_expiryUpSqlBytes() ([]byte, error) {
+	return _postgres20200204090000_blob_expiryUpSql, nil
+}
+
+func postgres20200204090000_blob_expiryUpSql() (*asset, error) {
+	bytes, err := postgres20200204090000_blob_expiryUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200204090000_blob_expiry.up.sql", size: 107, mode: os.FileMode(0644), modTime: time.Unix(1792144267, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x52, 0x15, 0xae, 0xf8, 0x2a, 0x85, 0x5c, 0xc8, 0xf5, 0xd7, 0x95, 0x58, 0xbe, 0x3a, 0x7e, 0x12, 0x18, 0xe1, 0x47, 0x2, 0xf6, 0x59, 0xba, 0x57, 0x0, 0x3f, 0x57, 0x86, 0x43, 0xe0, 0xf, 0xe9}}
+	return a, nil
+}
+
+var _postgres20200205090000_blob_versionsDownSql = []byte(`DROP TABLE blob_versions;
+`)
+
+func postgres20200205090000_blob_versionsDownSqlBytes() ([]byte, error) {
+	return _postgres20200205090000_blob_versionsDownSql, nil
+}
+
+func postgres20200205090000_blob_versionsDownSql() (*asset, error) {
+	bytes, err := postgres20200205090000_blob_versionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200205090000_blob_versions.down.sql", size: 26, mode: os.FileMode(0644), modTime: time.Unix(1792145044, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xca, 0x16, 0xcd, 0x82, 0xf5, 0xd, 0xb8, 0x0, 0x8a, 0x8e, 0x4f, 0x69, 0x38, 0xff, 0x35, 0x12, 0xf9, 0x27, 0x4d, 0x39, 0x64, 0xed, 0xca, 0xc6, 0x15, 0x1a, 0x8, 0x13, 0x39, 0x3d, 0xa3, 0x2f}}
+	return a, nil
+}
+
+var _postgres20200205090000_blob_versionsUpSql = []byte(`CREATE TABLE blob_versions (
+    id BIGSERIAL PRIMARY KEY,
+    blob_id BIGINT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes BIGINT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BYTEA NOT NULL DEFAULT '\x',
+    nonce BYTEA NOT NULL DEFAULT '\x',
+    compressed BOOLEAN NOT NULL DEFAULT FALSE,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
+`)
+
+func postgres20200205090000_blob_versionsUpSqlBytes() ([]byte, error) {
+	return _postgres20200205090000_blob_versionsUpSql, nil
+}
+
+func postgres20200205090000_blob_versionsUpSql() (*asset, error) {
+	bytes, err := postgres20200205090000_blob_versionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200205090000_blob_versions.up.sql", size: 565, mode: os.FileMode(0644), modTime: time.Unix(1792145044, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x44, 0xe0, 0xe2, 0xc7, 0x59, 0xbb, 0x3e, 0x40, 0x2, 0x78, 0x5f, 0xe8, 0x69, 0x6a, 0x6b, 0x78, 0x21, 0x1d, 0x89, 0x31, 0x6f, 0xe9, 0x9c, 0x4, 0xd0, 0x33, 0x58, 0x8e, 0x21, 0x6b, 0x60, 0xdd}}
+	return a, nil
+}
+
+var _postgres20200206090000_blob_trashDownSql = []byte(`DROP INDEX blobs_deleted_at;
+ALTER TABLE blobs DROP COLUMN deleted_at;
+`)
+
+func postgres20200206090000_blob_trashDownSqlBytes() ([]byte, error) {
+	return _postgres20200206090000_blob_trashDownSql, nil
+}
+
+func postgres20200206090000_blob_trashDownSql() (*asset, error) {
+	bytes, err := postgres20200206090000_blob_trashDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200206090000_blob_trash.down.sql", size: 71, mode: os.FileMode(0644), modTime: time.Unix(1792145705, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdb, 0xc1, 0xea, 0xb7, 0x1b, 0x1, 0x13, 0x96, 0x41, 0x55, 0xb8, 0xfc, 0x73, 0x66, 0x8e, 0x2a, 0x1f, 0x0, 0x88, 0xfc, 0xbc, 0x1d, 0x56, 0xc, 0x9a, 0xb3, 0x2d, 0x27, 0x9a, 0x54, 0xa5, 0xe6}}
+	return a, nil
+}
+
+var _postgres20200206090000_blob_trashUpSql = []byte(`ALTER TABLE blobs ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
+`)
+
+func postgres20200206090000_blob_trashUpSqlBytes() ([]byte, error) {
+	return _postgres20200206090000_blob_trashUpSql, nil
+}
+
+func postgres20200206090000_blob_trashUpSql() (*asset, error) {
+	bytes, err := postgres20200206090000_blob_trashUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200206090000_blob_trash.up.sql", size: 107, mode: os.FileMode(0644), modTime: time.Unix(1792145705, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6f, 0x64, 0xfb, 0x70, 0x8d, 0xbb, 0x35, 0xd7, 0x52, 0x76, 0x87, 0x8b, 0x31, 0xe3, 0xbf, 0x4f, 0x2b, 0xb8, 0xe1, 0x42, 0x4a, 0x92, 0xc7, 0x97, 0xa1, 0xa2, 0xfa, 0x93, 0x67, 0x77, 0x79, 0xdd}}
+	return a, nil
+}
+
+var _postgres20200207090000_blob_sealed_finalDownSql = []byte(`-- Blobs stored since can't be opened without this flag, roll back only before storing any.
+ALTER TABLE blob_versions DROP COLUMN sealed_final;
+ALTER TABLE blobs DROP COLUMN sealed_final;
+`)
+
+func postgres20200207090000_blob_sealed_finalDownSqlBytes() ([]byte, error) {
+	return _postgres20200207090000_blob_sealed_finalDownSql, nil
+}
+
+func postgres20200207090000_blob_sealed_finalDownSql() (*asset, error) {
+	bytes, err := postgres20200207090000_blob_sealed_finalDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200207090000_blob_sealed_final.down.sql", size: 188, mode: os.FileMode(0644), modTime: time.Unix(1792147026, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9e, 0x3b, 0x22, 0xd9, 0x69, 0xd3, 0x77, 0x4d, 0x2c, 0x17, 0xef, 0x63, 0xb3, 0x51, 0x2a, 0x29, 0x1f, 0xa5, 0x71, 0xe4, 0x81, 0x82, 0xa5, 0x78, 0x98, 0x7a, 0x93, 0xa8, 0xd6, 0x6a, 0x8a, 0x6e}}
+	return a, nil
+}
+
+var _postgres20200207090000_blob_sealed_finalUpSql = []byte(`-- Blobs sealed with a distinct AAD on their last segment, so truncation is detected.
+-- Rows stored before keep false and are checked against their size instead.
+ALTER TABLE blobs ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE blob_versions ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT FALSE;
+`)
+
+func postgres20200207090000_blob_sealed_finalUpSqlBytes() ([]byte, error) {
+	return _postgres20200207090000_blob_sealed_finalUpSql, nil
+}
+
+func postgres20200207090000_blob_sealed_finalUpSql() (*asset, error) {
+	bytes, err := postgres20200207090000_blob_sealed_finalUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200207090000_blob_sealed_final.up.sql", size: 319, mode: os.FileMode(0644), modTime: time.Unix(1792147017, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5d, 0x9b, 0x33, 0xaf, 0x70, 0x9e, 0x51, 0x15, 0x11, 0xc5, 0xf5, 0x90, 0x9a, 0x7c, 0xfc, 0x41, 0x3b, 0xd9, 0xe9, 0x9d, 0x20, 0x3a, 0x20, 0xe2, 0x6, 0x27, 0xe4, 0x3f, 0x8f, 0x2a, 0x27, 0x6c}}
+	return a, nil
+}
+
+var _postgres20200208090000_blob_content_updated_atDownSql = []byte(`ALTER TABLE blobs DROP COLUMN content_updated_at;
+`)
+
+func postgres20200208090000_blob_content_updated_atDownSqlBytes() ([]byte, error) {
+	return _postgres20200208090000_blob_content_updated_atDownSql, nil
+}
+
+func postgres20200208090000_blob_content_updated_atDownSql() (*asset, error) {
+	bytes, err := postgres20200208090000_blob_content_updated_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200208090000_blob_content_updated_at.down.sql", size: 50, mode: os.FileMode(0644), modTime: time.Unix(1792147706, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5, 0xfe, 0x2c, 0x36, 0x96, 0x6a, 0x53, 0x2b, 0xd6, 0xcd, 0xa5, 0x35, 0xb3, 0x2c, 0x90, 0xb0, 0x69, 0x7e, 0x3d, 0x2b, 0x33, 0x9a, 0x3, 0xec, 0xd9, 0x19, 0xd8, 0xff, 0x11, 0x64, 0x6, 0x46}}
+	return a, nil
+}
+
+var _postgres20200208090000_blob_content_updated_atUpSql = []byte(`-- When a blob's current contents were stored, set once versioning replaces them.
+-- created_at is immutable, so the blob's first contents leave this NULL and use it instead.
+ALTER TABLE blobs ADD COLUMN content_updated_at TIMESTAMPTZ;
+`)
+
+func postgres20200208090000_blob_content_updated_atUpSqlBytes() ([]byte, error) {
+	return _postgres20200208090000_blob_content_updated_atUpSql, nil
+}
+
+func postgres20200208090000_blob_content_updated_atUpSql() (*asset, error) {
+	bytes, err := postgres20200208090000_blob_content_updated_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200208090000_blob_content_updated_at.up.sql", size: 236, mode: os.FileMode(0644), modTime: time.Unix(1792147706, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x21, 0xbd, 0x18, 0x3b, 0x10, 0xb8, 0xd3, 0x2f, 0x77, 0x1e, 0x72, 0x7d, 0x7d, 0x10, 0xee, 0x6c, 0x96, 0x26, 0x13, 0x79, 0xd6, 0xff, 0xa2, 0x99, 0xd7, 0xcc, 0xe9, 0x75, 0xd0, 0x45, 0x8e, 0x1a}}
+	return a, nil
+}
+
+var _postgres20200209090000_idempotency_request_hashDownSql = []byte(`ALTER TABLE idempotency_keys DROP COLUMN request_hash;
+`)
+
+func postgres20200209090000_idempotency_request_hashDownSqlBytes() ([]byte, error) {
+	return _postgres20200209090000_idempotency_request_hashDownSql, nil
+}
+
+func postgres20200209090000_idempotency_request_hashDownSql() (*asset, error) {
+	bytes, err := postgres20200209090000_idempotency_request_hashDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200209090000_idempotency_request_hash.down.sql", size: 55, mode: os.FileMode(0644), modTime: time.Unix(1792148068, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x47, 0x2f, 0x8b, 0xe5, 0x83, 0xf2, 0xa5, 0x45, 0x7b, 0x83, 0xdf, 0xb1, 0x8b, 0x83, 0x2a, 0x27, 0x23, 0xed, 0x65, 0x71, 0xbb, 0x40, 0xa2, 0x3f, 0x3f, 0x5f, 0xc6, 0x60, 0xb6, 0xd, 0x2d, 0x91}}
+	return a, nil
+}
+
+var _postgres20200209090000_idempotency_request_hashUpSql = []byte(`-- Fingerprint of the request that used the key, a repeat must match it to get the
+-- response replayed. NULL for keys saved before, those replay as they did.
+ALTER TABLE idempotency_keys ADD COLUMN request_hash BYTEA;
+`)
+
+func postgres20200209090000_idempotency_request_hashUpSqlBytes() ([]byte, error) {
+	return _postgres20200209090000_idempotency_request_hashUpSql, nil
+}
+
+func postgres20200209090000_idempotency_request_hashUpSql() (*asset, error) {
+	bytes, err := postgres20200209090000_idempotency_request_hashUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "postgres/20200209090000_idempotency_request_hash.up.sql", size: 219, mode: os.FileMode(0644), modTime: time.Unix(1792148068, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x0, 0xcb, 0x1a, 0x2f, 0xbc, 0xdf, 0xa2, 0x23, 0x91, 0x74, 0x1e, 0xbd, 0x5f, 0x5d, 0xa6, 0x4, 0x6d, 0x28, 0xe1, 0x89, 0x79, 0x52, 0x49, 0x5d, 0x8c, 0xfc, 0x1e, 0xe4, 0x58, 0x16, 0x90, 0x52}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -244,8 +2346,86 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"20191225220909_initial_migration.down.sql": _20191225220909_initial_migrationDownSql,
-	"20191225220909_initial_migration.up.sql":   _20191225220909_initial_migrationUpSql,
+	"20191225220909_initial_migration.down.sql":                  _20191225220909_initial_migrationDownSql,
+	"20191225220909_initial_migration.up.sql":                    _20191225220909_initial_migrationUpSql,
+	"20200123093000_blob_checksum.down.sql":                      _20200123093000_blob_checksumDownSql,
+	"20200123093000_blob_checksum.up.sql":                        _20200123093000_blob_checksumUpSql,
+	"20200123101500_blob_nonce.down.sql":                         _20200123101500_blob_nonceDownSql,
+	"20200123101500_blob_nonce.up.sql":                           _20200123101500_blob_nonceUpSql,
+	"20200124090000_users.down.sql":                              _20200124090000_usersDownSql,
+	"20200124090000_users.up.sql":                                _20200124090000_usersUpSql,
+	"20200125090000_blob_compressed.down.sql":                    _20200125090000_blob_compressedDownSql,
+	"20200125090000_blob_compressed.up.sql":                      _20200125090000_blob_compressedUpSql,
+	"20200126090000_blob_segment_size.down.sql":                  _20200126090000_blob_segment_sizeDownSql,
+	"20200126090000_blob_segment_size.up.sql":                    _20200126090000_blob_segment_sizeUpSql,
+	"20200127090000_blob_created_at_immutable.down.sql":          _20200127090000_blob_created_at_immutableDownSql,
+	"20200127090000_blob_created_at_immutable.up.sql":            _20200127090000_blob_created_at_immutableUpSql,
+	"20200128090000_audit_log.down.sql":                          _20200128090000_audit_logDownSql,
+	"20200128090000_audit_log.up.sql":                            _20200128090000_audit_logUpSql,
+	"20200129090000_api_keys.down.sql":                           _20200129090000_api_keysDownSql,
+	"20200129090000_api_keys.up.sql":                             _20200129090000_api_keysUpSql,
+	"20200130090000_blobs_tags.down.sql":                         _20200130090000_blobs_tagsDownSql,
+	"20200130090000_blobs_tags.up.sql":                           _20200130090000_blobs_tagsUpSql,
+	"20200131090000_blob_owner.down.sql":                         _20200131090000_blob_ownerDownSql,
+	"20200131090000_blob_owner.up.sql":                           _20200131090000_blob_ownerUpSql,
+	"20200201090000_blob_checksum_index.down.sql":                _20200201090000_blob_checksum_indexDownSql,
+	"20200201090000_blob_checksum_index.up.sql":                  _20200201090000_blob_checksum_indexUpSql,
+	"20200202090000_sessions.down.sql":                           _20200202090000_sessionsDownSql,
+	"20200202090000_sessions.up.sql":                             _20200202090000_sessionsUpSql,
+	"20200203090000_idempotency_keys.down.sql":                   _20200203090000_idempotency_keysDownSql,
+	"20200203090000_idempotency_keys.up.sql":                     _20200203090000_idempotency_keysUpSql,
+	"20200204090000_blob_expiry.down.sql":                        _20200204090000_blob_expiryDownSql,
+	"20200204090000_blob_expiry.up.sql":                          _20200204090000_blob_expiryUpSql,
+	"20200205090000_blob_versions.down.sql":                      _20200205090000_blob_versionsDownSql,
+	"20200205090000_blob_versions.up.sql":                        _20200205090000_blob_versionsUpSql,
+	"20200206090000_blob_trash.down.sql":                         _20200206090000_blob_trashDownSql,
+	"20200206090000_blob_trash.up.sql":                           _20200206090000_blob_trashUpSql,
+	"20200207090000_blob_sealed_final.down.sql":                  _20200207090000_blob_sealed_finalDownSql,
+	"20200207090000_blob_sealed_final.up.sql":                    _20200207090000_blob_sealed_finalUpSql,
+	"20200208090000_blob_content_updated_at.down.sql":            _20200208090000_blob_content_updated_atDownSql,
+	"20200208090000_blob_content_updated_at.up.sql":              _20200208090000_blob_content_updated_atUpSql,
+	"20200209090000_idempotency_request_hash.down.sql":           _20200209090000_idempotency_request_hashDownSql,
+	"20200209090000_idempotency_request_hash.up.sql":             _20200209090000_idempotency_request_hashUpSql,
+	"postgres/20191225220909_initial_migration.down.sql":         postgres20191225220909_initial_migrationDownSql,
+	"postgres/20191225220909_initial_migration.up.sql":           postgres20191225220909_initial_migrationUpSql,
+	"postgres/20200123093000_blob_checksum.down.sql":             postgres20200123093000_blob_checksumDownSql,
+	"postgres/20200123093000_blob_checksum.up.sql":               postgres20200123093000_blob_checksumUpSql,
+	"postgres/20200123101500_blob_nonce.down.sql":                postgres20200123101500_blob_nonceDownSql,
+	"postgres/20200123101500_blob_nonce.up.sql":                  postgres20200123101500_blob_nonceUpSql,
+	"postgres/20200124090000_users.down.sql":                     postgres20200124090000_usersDownSql,
+	"postgres/20200124090000_users.up.sql":                       postgres20200124090000_usersUpSql,
+	"postgres/20200125090000_blob_compressed.down.sql":           postgres20200125090000_blob_compressedDownSql,
+	"postgres/20200125090000_blob_compressed.up.sql":             postgres20200125090000_blob_compressedUpSql,
+	"postgres/20200126090000_blob_segment_size.down.sql":         postgres20200126090000_blob_segment_sizeDownSql,
+	"postgres/20200126090000_blob_segment_size.up.sql":           postgres20200126090000_blob_segment_sizeUpSql,
+	"postgres/20200127090000_blob_created_at_immutable.down.sql": postgres20200127090000_blob_created_at_immutableDownSql,
+	"postgres/20200127090000_blob_created_at_immutable.up.sql":   postgres20200127090000_blob_created_at_immutableUpSql,
+	"postgres/20200128090000_audit_log.down.sql":                 postgres20200128090000_audit_logDownSql,
+	"postgres/20200128090000_audit_log.up.sql":                   postgres20200128090000_audit_logUpSql,
+	"postgres/20200129090000_api_keys.down.sql":                  postgres20200129090000_api_keysDownSql,
+	"postgres/20200129090000_api_keys.up.sql":                    postgres20200129090000_api_keysUpSql,
+	"postgres/20200130090000_blobs_tags.down.sql":                postgres20200130090000_blobs_tagsDownSql,
+	"postgres/20200130090000_blobs_tags.up.sql":                  postgres20200130090000_blobs_tagsUpSql,
+	"postgres/20200131090000_blob_owner.down.sql":                postgres20200131090000_blob_ownerDownSql,
+	"postgres/20200131090000_blob_owner.up.sql":                  postgres20200131090000_blob_ownerUpSql,
+	"postgres/20200201090000_blob_checksum_index.down.sql":       postgres20200201090000_blob_checksum_indexDownSql,
+	"postgres/20200201090000_blob_checksum_index.up.sql":         postgres20200201090000_blob_checksum_indexUpSql,
+	"postgres/20200202090000_sessions.down.sql":                  postgres20200202090000_sessionsDownSql,
+	"postgres/20200202090000_sessions.up.sql":                    postgres20200202090000_sessionsUpSql,
+	"postgres/20200203090000_idempotency_keys.down.sql":          postgres20200203090000_idempotency_keysDownSql,
+	"postgres/20200203090000_idempotency_keys.up.sql":            postgres20200203090000_idempotency_keysUpSql,
+	"postgres/20200204090000_blob_expiry.down.sql":               postgres20200204090000_blob_expiryDownSql,
+	"postgres/20200204090000_blob_expiry.up.sql":                 postgres20200204090000_blob_expiryUpSql,
+	"postgres/20200205090000_blob_versions.down.sql":             postgres20200205090000_blob_versionsDownSql,
+	"postgres/20200205090000_blob_versions.up.sql":               postgres20200205090000_blob_versionsUpSql,
+	"postgres/20200206090000_blob_trash.down.sql":                postgres20200206090000_blob_trashDownSql,
+	"postgres/20200206090000_blob_trash.up.sql":                  postgres20200206090000_blob_trashUpSql,
+	"postgres/20200207090000_blob_sealed_final.down.sql":         postgres20200207090000_blob_sealed_finalDownSql,
+	"postgres/20200207090000_blob_sealed_final.up.sql":           postgres20200207090000_blob_sealed_finalUpSql,
+	"postgres/20200208090000_blob_content_updated_at.down.sql":   postgres20200208090000_blob_content_updated_atDownSql,
+	"postgres/20200208090000_blob_content_updated_at.up.sql":     postgres20200208090000_blob_content_updated_atUpSql,
+	"postgres/20200209090000_idempotency_request_hash.down.sql":  postgres20200209090000_idempotency_request_hashDownSql,
+	"postgres/20200209090000_idempotency_request_hash.up.sql":    postgres20200209090000_idempotency_request_hashUpSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -289,8 +2469,88 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"20191225220909_initial_migration.down.sql": &bintree{_20191225220909_initial_migrationDownSql, map[string]*bintree{}},
-	"20191225220909_initial_migration.up.sql":   &bintree{_20191225220909_initial_migrationUpSql, map[string]*bintree{}},
+	"20191225220909_initial_migration.down.sql":         &bintree{_20191225220909_initial_migrationDownSql, map[string]*bintree{}},
+	"20191225220909_initial_migration.up.sql":           &bintree{_20191225220909_initial_migrationUpSql, map[string]*bintree{}},
+	"20200123093000_blob_checksum.down.sql":             &bintree{_20200123093000_blob_checksumDownSql, map[string]*bintree{}},
+	"20200123093000_blob_checksum.up.sql":               &bintree{_20200123093000_blob_checksumUpSql, map[string]*bintree{}},
+	"20200123101500_blob_nonce.down.sql":                &bintree{_20200123101500_blob_nonceDownSql, map[string]*bintree{}},
+	"20200123101500_blob_nonce.up.sql":                  &bintree{_20200123101500_blob_nonceUpSql, map[string]*bintree{}},
+	"20200124090000_users.down.sql":                     &bintree{_20200124090000_usersDownSql, map[string]*bintree{}},
+	"20200124090000_users.up.sql":                       &bintree{_20200124090000_usersUpSql, map[string]*bintree{}},
+	"20200125090000_blob_compressed.down.sql":           &bintree{_20200125090000_blob_compressedDownSql, map[string]*bintree{}},
+	"20200125090000_blob_compressed.up.sql":             &bintree{_20200125090000_blob_compressedUpSql, map[string]*bintree{}},
+	"20200126090000_blob_segment_size.down.sql":         &bintree{_20200126090000_blob_segment_sizeDownSql, map[string]*bintree{}},
+	"20200126090000_blob_segment_size.up.sql":           &bintree{_20200126090000_blob_segment_sizeUpSql, map[string]*bintree{}},
+	"20200127090000_blob_created_at_immutable.down.sql": &bintree{_20200127090000_blob_created_at_immutableDownSql, map[string]*bintree{}},
+	"20200127090000_blob_created_at_immutable.up.sql":   &bintree{_20200127090000_blob_created_at_immutableUpSql, map[string]*bintree{}},
+	"20200128090000_audit_log.down.sql":                 &bintree{_20200128090000_audit_logDownSql, map[string]*bintree{}},
+	"20200128090000_audit_log.up.sql":                   &bintree{_20200128090000_audit_logUpSql, map[string]*bintree{}},
+	"20200129090000_api_keys.down.sql":                  &bintree{_20200129090000_api_keysDownSql, map[string]*bintree{}},
+	"20200129090000_api_keys.up.sql":                    &bintree{_20200129090000_api_keysUpSql, map[string]*bintree{}},
+	"20200130090000_blobs_tags.down.sql":                &bintree{_20200130090000_blobs_tagsDownSql, map[string]*bintree{}},
+	"20200130090000_blobs_tags.up.sql":                  &bintree{_20200130090000_blobs_tagsUpSql, map[string]*bintree{}},
+	"20200131090000_blob_owner.down.sql":                &bintree{_20200131090000_blob_ownerDownSql, map[string]*bintree{}},
+	"20200131090000_blob_owner.up.sql":                  &bintree{_20200131090000_blob_ownerUpSql, map[string]*bintree{}},
+	"20200201090000_blob_checksum_index.down.sql":       &bintree{_20200201090000_blob_checksum_indexDownSql, map[string]*bintree{}},
+	"20200201090000_blob_checksum_index.up.sql":         &bintree{_20200201090000_blob_checksum_indexUpSql, map[string]*bintree{}},
+	"20200202090000_sessions.down.sql":                  &bintree{_20200202090000_sessionsDownSql, map[string]*bintree{}},
+	"20200202090000_sessions.up.sql":                    &bintree{_20200202090000_sessionsUpSql, map[string]*bintree{}},
+	"20200203090000_idempotency_keys.down.sql":          &bintree{_20200203090000_idempotency_keysDownSql, map[string]*bintree{}},
+	"20200203090000_idempotency_keys.up.sql":            &bintree{_20200203090000_idempotency_keysUpSql, map[string]*bintree{}},
+	"20200204090000_blob_expiry.down.sql":               &bintree{_20200204090000_blob_expiryDownSql, map[string]*bintree{}},
+	"20200204090000_blob_expiry.up.sql":                 &bintree{_20200204090000_blob_expiryUpSql, map[string]*bintree{}},
+	"20200205090000_blob_versions.down.sql":             &bintree{_20200205090000_blob_versionsDownSql, map[string]*bintree{}},
+	"20200205090000_blob_versions.up.sql":               &bintree{_20200205090000_blob_versionsUpSql, map[string]*bintree{}},
+	"20200206090000_blob_trash.down.sql":                &bintree{_20200206090000_blob_trashDownSql, map[string]*bintree{}},
+	"20200206090000_blob_trash.up.sql":                  &bintree{_20200206090000_blob_trashUpSql, map[string]*bintree{}},
+	"20200207090000_blob_sealed_final.down.sql":         &bintree{_20200207090000_blob_sealed_finalDownSql, map[string]*bintree{}},
+	"20200207090000_blob_sealed_final.up.sql":           &bintree{_20200207090000_blob_sealed_finalUpSql, map[string]*bintree{}},
+	"20200208090000_blob_content_updated_at.down.sql":   &bintree{_20200208090000_blob_content_updated_atDownSql, map[string]*bintree{}},
+	"20200208090000_blob_content_updated_at.up.sql":     &bintree{_20200208090000_blob_content_updated_atUpSql, map[string]*bintree{}},
+	"20200209090000_idempotency_request_hash.down.sql":  &bintree{_20200209090000_idempotency_request_hashDownSql, map[string]*bintree{}},
+	"20200209090000_idempotency_request_hash.up.sql":    &bintree{_20200209090000_idempotency_request_hashUpSql, map[string]*bintree{}},
+	"postgres": &bintree{nil, map[string]*bintree{
+		"20191225220909_initial_migration.down.sql":         &bintree{postgres20191225220909_initial_migrationDownSql, map[string]*bintree{}},
+		"20191225220909_initial_migration.up.sql":           &bintree{postgres20191225220909_initial_migrationUpSql, map[string]*bintree{}},
+		"20200123093000_blob_checksum.down.sql":             &bintree{postgres20200123093000_blob_checksumDownSql, map[string]*bintree{}},
+		"20200123093000_blob_checksum.up.sql":               &bintree{postgres20200123093000_blob_checksumUpSql, map[string]*bintree{}},
+		"20200123101500_blob_nonce.down.sql":                &bintree{postgres20200123101500_blob_nonceDownSql, map[string]*bintree{}},
+		"20200123101500_blob_nonce.up.sql":                  &bintree{postgres20200123101500_blob_nonceUpSql, map[string]*bintree{}},
+		"20200124090000_users.down.sql":                     &bintree{postgres20200124090000_usersDownSql, map[string]*bintree{}},
+		"20200124090000_users.up.sql":                       &bintree{postgres20200124090000_usersUpSql, map[string]*bintree{}},
+		"20200125090000_blob_compressed.down.sql":           &bintree{postgres20200125090000_blob_compressedDownSql, map[string]*bintree{}},
+		"20200125090000_blob_compressed.up.sql":             &bintree{postgres20200125090000_blob_compressedUpSql, map[string]*bintree{}},
+		"20200126090000_blob_segment_size.down.sql":         &bintree{postgres20200126090000_blob_segment_sizeDownSql, map[string]*bintree{}},
+		"20200126090000_blob_segment_size.up.sql":           &bintree{postgres20200126090000_blob_segment_sizeUpSql, map[string]*bintree{}},
+		"20200127090000_blob_created_at_immutable.down.sql": &bintree{postgres20200127090000_blob_created_at_immutableDownSql, map[string]*bintree{}},
+		"20200127090000_blob_created_at_immutable.up.sql":   &bintree{postgres20200127090000_blob_created_at_immutableUpSql, map[string]*bintree{}},
+		"20200128090000_audit_log.down.sql":                 &bintree{postgres20200128090000_audit_logDownSql, map[string]*bintree{}},
+		"20200128090000_audit_log.up.sql":                   &bintree{postgres20200128090000_audit_logUpSql, map[string]*bintree{}},
+		"20200129090000_api_keys.down.sql":                  &bintree{postgres20200129090000_api_keysDownSql, map[string]*bintree{}},
+		"20200129090000_api_keys.up.sql":                    &bintree{postgres20200129090000_api_keysUpSql, map[string]*bintree{}},
+		"20200130090000_blobs_tags.down.sql":                &bintree{postgres20200130090000_blobs_tagsDownSql, map[string]*bintree{}},
+		"20200130090000_blobs_tags.up.sql":                  &bintree{postgres20200130090000_blobs_tagsUpSql, map[string]*bintree{}},
+		"20200131090000_blob_owner.down.sql":                &bintree{postgres20200131090000_blob_ownerDownSql, map[string]*bintree{}},
+		"20200131090000_blob_owner.up.sql":                  &bintree{postgres20200131090000_blob_ownerUpSql, map[string]*bintree{}},
+		"20200201090000_blob_checksum_index.down.sql":       &bintree{postgres20200201090000_blob_checksum_indexDownSql, map[string]*bintree{}},
+		"20200201090000_blob_checksum_index.up.sql":         &bintree{postgres20200201090000_blob_checksum_indexUpSql, map[string]*bintree{}},
+		"20200202090000_sessions.down.sql":                  &bintree{postgres20200202090000_sessionsDownSql, map[string]*bintree{}},
+		"20200202090000_sessions.up.sql":                    &bintree{postgres20200202090000_sessionsUpSql, map[string]*bintree{}},
+		"20200203090000_idempotency_keys.down.sql":          &bintree{postgres20200203090000_idempotency_keysDownSql, map[string]*bintree{}},
+		"20200203090000_idempotency_keys.up.sql":            &bintree{postgres20200203090000_idempotency_keysUpSql, map[string]*bintree{}},
+		"20200204090000_blob_expiry.down.sql":               &bintree{postgres20200204090000_blob_expiryDownSql, map[string]*bintree{}},
+		"20200204090000_blob_expiry.up.sql":                 &bintree{postgres20200204090000_blob_expiryUpSql, map[string]*bintree{}},
+		"20200205090000_blob_versions.down.sql":             &bintree{postgres20200205090000_blob_versionsDownSql, map[string]*bintree{}},
+		"20200205090000_blob_versions.up.sql":               &bintree{postgres20200205090000_blob_versionsUpSql, map[string]*bintree{}},
+		"20200206090000_blob_trash.down.sql":                &bintree{postgres20200206090000_blob_trashDownSql, map[string]*bintree{}},
+		"20200206090000_blob_trash.up.sql":                  &bintree{postgres20200206090000_blob_trashUpSql, map[string]*bintree{}},
+		"20200207090000_blob_sealed_final.down.sql":         &bintree{postgres20200207090000_blob_sealed_finalDownSql, map[string]*bintree{}},
+		"20200207090000_blob_sealed_final.up.sql":           &bintree{postgres20200207090000_blob_sealed_finalUpSql, map[string]*bintree{}},
+		"20200208090000_blob_content_updated_at.down.sql":   &bintree{postgres20200208090000_blob_content_updated_atDownSql, map[string]*bintree{}},
+		"20200208090000_blob_content_updated_at.up.sql":     &bintree{postgres20200208090000_blob_content_updated_atUpSql, map[string]*bintree{}},
+		"20200209090000_idempotency_request_hash.down.sql":  &bintree{postgres20200209090000_idempotency_request_hashDownSql, map[string]*bintree{}},
+		"20200209090000_idempotency_request_hash.up.sql":    &bintree{postgres20200209090000_idempotency_request_hashUpSql, map[string]*bintree{}},
+	}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
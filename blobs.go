@@ -0,0 +1,789 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"doco/db"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"go.uber.org/zap"
+)
+
+// ErrBlobExists is returned when a blob with the same filename is already stored
+var ErrBlobExists = errors.New("blob already exists")
+
+// ErrBlobNotFound is returned when no blob matches the requested filename
+var ErrBlobNotFound = errors.New("blob not found")
+
+// ErrPreconditionFailed is returned when If-Match names a version of the blob that isn't current
+var ErrPreconditionFailed = errors.New("blob changed, If-Match does not match its ETag")
+
+// ErrInvalidFileName is returned for blob names that can't safely be stored and downloaded
+var ErrInvalidFileName = errors.New("invalid file name")
+
+// ErrChecksumMismatch is returned when stored bytes no longer hash to the recorded checksum
+var ErrChecksumMismatch = errors.New("blob checksum mismatch")
+
+const (
+	defaultBlobListLimit = 50
+	maxBlobListLimit     = 200
+)
+
+// BlobMetadata describes a stored blob without its contents
+type BlobMetadata struct {
+	FileName      string    `json:"file_name"`
+	MimeType      string    `json:"mime_type"`
+	FileSizeBytes int64     `json:"file_size_bytes"`
+	Checksum      string    `json:"checksum"`
+	CreatedAt     time.Time `json:"created_at"`
+	// ExpiresAt is only set on blobs uploaded with a lifetime
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// blobMetadataColumns are selected when listing so the file bytes are never loaded
+var blobMetadataColumns = []string{
+	db.BlobColumns.FileName,
+	db.BlobColumns.MimeType,
+	db.BlobColumns.FileSizeBytes,
+	db.BlobColumns.Checksum,
+	db.BlobColumns.CreatedAt,
+	db.BlobColumns.ExpiresAt,
+}
+
+func newBlobMetadata(blob *db.Blob) *BlobMetadata {
+	return &BlobMetadata{
+		FileName:      blob.FileName,
+		MimeType:      blob.MimeType,
+		FileSizeBytes: blob.FileSizeBytes,
+		Checksum:      blob.Checksum,
+		CreatedAt:     blob.CreatedAt,
+		ExpiresAt:     blob.ExpiresAt.Ptr(),
+	}
+}
+
+// ErrBlobTooLarge is returned when an upload exceeds the configured size limit
+var ErrBlobTooLarge = errors.New("blob too large")
+
+// defaultMultipartMemory is the amount of an upload held in memory before spilling to disk
+const defaultMultipartMemory = 32 << 20
+
+// multipartOverhead allows for boundaries and form fields around the file part
+const multipartOverhead = 1 << 20
+
+// maxBlobBytesHeader advertises the upload size limit to clients
+const maxBlobBytesHeader = "X-Max-Blob-Bytes"
+
+// blobSizeHeader declares an upload's size in bytes, the file_size_bytes form field does the same
+const blobSizeHeader = "X-Blob-Size"
+
+// ErrUploadSizeMismatch is returned when an upload's contents aren't the size the client declared
+var ErrUploadSizeMismatch = errors.New("upload size doesn't match the declared size, it may have been truncated")
+
+// ErrUploadChecksumMismatch is returned when an upload's contents don't hash to the checksum the client declared
+var ErrUploadChecksumMismatch = errors.New("upload checksum doesn't match the declared checksum, it may have been corrupted")
+
+// checkDeclared compares what was received against the optional size and SHA-256
+// the client declared, so a truncated or corrupted upload isn't stored as complete
+func checkDeclared(r *http.Request, b []byte, checksum string) (int, error) {
+	size := r.Header.Get(blobSizeHeader)
+	if size == "" {
+		size = r.FormValue("file_size_bytes")
+	}
+	declared := r.Header.Get(checksumHeader)
+	if declared == "" {
+		declared = r.FormValue("checksum")
+	}
+	return compareDeclared(size, declared, b, checksum)
+}
+
+// compareDeclared is checkDeclared for a size and checksum already read, either may be empty
+func compareDeclared(size, declared string, b []byte, checksum string) (int, error) {
+	if size != "" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || n < 0 {
+			return http.StatusUnprocessableEntity, ValidationErr(map[string]string{"file_size_bytes": "must be a whole number of bytes"})
+		}
+		if n != int64(len(b)) {
+			return http.StatusBadRequest, fmt.Errorf("%w: declared %d bytes, received %d", ErrUploadSizeMismatch, n, len(b))
+		}
+	}
+	if declared != "" && !strings.EqualFold(declared, checksum) {
+		return http.StatusBadRequest, ErrUploadChecksumMismatch
+	}
+	return http.StatusOK, nil
+}
+
+// maxFileNameLength bounds blob names in bytes, what most filesystems allow
+const maxFileNameLength = 255
+
+// validFileName is checked wherever a blob gets its name. Names end up in
+// Content-Disposition and in files saved from downloads, so control characters,
+// path separators and dot segments are refused rather than escaped later.
+func validFileName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: required", ErrInvalidFileName)
+	case len(name) > maxFileNameLength:
+		return fmt.Errorf("%w: at most %d bytes", ErrInvalidFileName, maxFileNameLength)
+	case !utf8.ValidString(name):
+		return fmt.Errorf("%w: must be UTF-8", ErrInvalidFileName)
+	case name == "." || name == "..":
+		return fmt.Errorf("%w: must not be a dot segment", ErrInvalidFileName)
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("%w: must not contain / or \\", ErrInvalidFileName)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("%w: must not contain control characters", ErrInvalidFileName)
+	}
+	return nil
+}
+
+func isBodyTooLarge(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "request body too large")
+}
+
+// detectMimeType keeps a meaningful declared type, otherwise guesses from the
+// extension and finally sniffs the first 512 bytes of content
+func detectMimeType(declared, fileName string, b []byte) string {
+	if declared != "" && declared != "unknown" && declared != "application/octet-stream" {
+		return declared
+	}
+	if byExt := mime.TypeByExtension(filepath.Ext(fileName)); byExt != "" {
+		return byExt
+	}
+	return http.DetectContentType(b)
+}
+
+// BackfillMimeTypes replaces mime types stored as "unknown" with sniffed ones
+func BackfillMimeTypes(ctx context.Context, store BlobStore, masterKey []byte, log *zap.SugaredLogger) (int, error) {
+	blobs, err := db.Blobs(
+		qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName, db.BlobColumns.FileSizeBytes, db.BlobColumns.Nonce, db.BlobColumns.Compressed, db.BlobColumns.SegmentSize, db.BlobColumns.SealedFinal),
+		db.BlobWhere.MimeType.IN([]string{"", "unknown"}),
+	).AllG(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("backfill: %w", err)
+	}
+	for _, blob := range blobs {
+		b, err := openBlob(ctx, store, blobKey(blob), masterKey, blob)
+		if err != nil {
+			return 0, fmt.Errorf("backfill %s: %w", blob.FileName, err)
+		}
+		if blob.Compressed {
+			b, err = gunzipBytes(b)
+			if err != nil {
+				return 0, fmt.Errorf("backfill %s: %w", blob.FileName, err)
+			}
+		}
+		blob.MimeType = detectMimeType("", blob.FileName, b)
+		_, err = blob.UpdateG(ctx, boil.Whitelist(db.BlobColumns.MimeType))
+		if err != nil {
+			return 0, fmt.Errorf("backfill %s: %w", blob.FileName, err)
+		}
+		log.Infow("backfilled mime type", "file_name", blob.FileName, "mime_type", blob.MimeType)
+	}
+	return len(blobs), nil
+}
+
+// openBlob reads and decrypts the bytes stored under id for blob, the result is still gzipped for compressed blobs
+func openBlob(ctx context.Context, store BlobStore, id string, key []byte, blob *db.Blob) ([]byte, error) {
+	rc, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	switch {
+	case len(blob.Nonce) == 0:
+		// stored before at-rest encryption
+		return ioutil.ReadAll(rc)
+	case blob.SegmentSize == 0:
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		return decryptBlob(key, b, blob.Nonce)
+	}
+	src, size, err := readerAt(rc)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := openSegments(key, blob, src, size)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(sr)
+}
+
+// openSegments reads a segmented blob's stored plaintext. The last segment is opened
+// up front, so a truncated blob fails before anything is sent. Blobs sealed before it
+// had its own AAD can't tell a cut off ciphertext from a short one, so an uncompressed
+// one must at least add up to its recorded size.
+func openSegments(key []byte, blob *db.Blob, src io.ReaderAt, size int64) (*segmentReader, error) {
+	sr, err := newSegmentReader(key, blob.Nonce, blob.SegmentSize, blob.SealedFinal, src, size)
+	if err != nil {
+		return nil, err
+	}
+	if blob.SealedFinal {
+		err = sr.load(sr.segments - 1)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, err)
+		}
+	}
+	if !blob.SealedFinal && !blob.Compressed && sr.size != blob.FileSizeBytes {
+		return nil, fmt.Errorf("%w: %s stores %d bytes, expected %d", ErrChecksumMismatch, blob.FileName, sr.size, blob.FileSizeBytes)
+	}
+	return sr, nil
+}
+
+// blobKey is the store key for a blob row
+func blobKey(blob *db.Blob) string {
+	return strconv.FormatInt(blob.ID.Int64, 10)
+}
+
+// storeBlob inserts the blob row and writes its contents to the store,
+// removing the row again if the store write fails
+func storeBlob(ctx context.Context, store BlobStore, blob *db.Blob, ciphertext []byte) error {
+	err := insertG(ctx, blob, func() error {
+		// SQLite didn't hand the ID back, look it up by the unique filename
+		inserted, err := db.Blobs(qm.Select(db.BlobColumns.ID), db.BlobWhere.FileName.EQ(blob.FileName)).OneG(ctx)
+		if err != nil {
+			return err
+		}
+		blob.ID = inserted.ID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = store.Put(ctx, blobKey(blob), bytes.NewReader(ciphertext))
+	if err != nil {
+		_, delErr := blob.DeleteG(ctx)
+		if delErr != nil {
+			return fmt.Errorf("%v, cleanup: %w", err, delErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// newBlob builds a blob row and its encrypted, and where worthwhile compressed,
+// contents ready for storeBlob
+func newBlob(key []byte, fileName, mimeType string, b []byte) (*db.Blob, []byte, error) {
+	stored := b
+	compressed := false
+	if shouldCompress(mimeType) {
+		z, err := gzipBytes(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(z) < len(b) {
+			stored = z
+			compressed = true
+		}
+	}
+	ciphertext, nonce, err := encryptSegments(key, stored)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &db.Blob{
+		FileName:      fileName,
+		MimeType:      mimeType,
+		FileSizeBytes: int64(len(b)),
+		EXTENSION:     strings.TrimPrefix(filepath.Ext(fileName), "."),
+		File:          []byte{},
+		Nonce:         nonce,
+		Compressed:    compressed,
+		SegmentSize:   segmentSize,
+		SealedFinal:   true,
+		Checksum:      blobChecksum(b),
+	}, ciphertext, nil
+}
+
+// blobChecksum is the hex encoded SHA-256 of the blob contents
+func blobChecksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// blobETag is a strong validator derived from the blob checksum
+func blobETag(checksum string) string {
+	return `"` + checksum + `"`
+}
+
+// ifMatch reports whether the request's If-Match, if any, names the blob as it is
+// now. Either of the ETags a download hands out will do. Blobs stored before
+// checksums have no ETag, so only * matches them.
+func ifMatch(r *http.Request, blob *db.Blob) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if blob.Checksum != "" && (tag == blobETag(blob.Checksum) || tag == blobETag(blob.Checksum+"-gzip")) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryInt reads a non-negative integer query param, falling back to def when absent
+func queryInt(r *http.Request, key string, def int) (int, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", key, v)
+	}
+	return i, nil
+}
+
+func (c *API) blobListHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Total  int64           `json:"total"`
+			Limit  int             `json:"limit"`
+			Offset int             `json:"offset"`
+			Blobs  []*BlobMetadata `json:"blobs"`
+		}
+
+		limit, err := queryInt(r, "limit", defaultBlobListLimit)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if limit == 0 {
+			limit = defaultBlobListLimit
+		}
+		if limit > maxBlobListLimit {
+			limit = maxBlobListLimit
+		}
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		// every ?tag= must match, columns are qualified as tags has some of the same names
+		tags := r.URL.Query()["tag"]
+		for _, tag := range tags {
+			err = validTag(tag)
+			if err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+		}
+		filters := append(tagFilters(tags), notExpired(time.Now()), notTrashed())
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			return nil, http.StatusUnauthorized, ErrUnauthorized
+		}
+		if !claims.Admin {
+			filters = append(filters, db.BlobWhere.OwnerID.EQ(null.Int64From(claims.UserID)))
+		}
+		columns := []string{}
+		for _, column := range blobMetadataColumns {
+			columns = append(columns, db.TableNames.Blobs+"."+column)
+		}
+
+		total, err := db.Blobs(filters...).CountG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		blobs, err := db.Blobs(append(filters,
+			qm.Select(columns...),
+			qm.OrderBy(db.TableNames.Blobs+"."+db.BlobColumns.CreatedAt+" desc, "+db.TableNames.Blobs+"."+db.BlobColumns.ID+" desc"),
+			qm.Limit(limit),
+			qm.Offset(offset),
+		)...).AllG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		result := &Response{
+			Total:  total,
+			Limit:  limit,
+			Offset: offset,
+			Blobs:  []*BlobMetadata{},
+		}
+		for _, blob := range blobs {
+			result.Blobs = append(result.Blobs, newBlobMetadata(blob))
+		}
+		return result, http.StatusOK, nil
+	}
+	return fn
+}
+
+func (c *API) blobUploadHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			FileName      string     `json:"file_name"`
+			MimeType      string     `json:"mime_type"`
+			FileSizeBytes int64      `json:"file_size_bytes"`
+			Checksum      string     `json:"checksum"`
+			ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+			Deduplicated  bool       `json:"deduplicated"`
+			// Version is set when the upload replaced an existing blob's contents
+			Version int64 `json:"version,omitempty"`
+		}
+
+		w.Header().Set(maxBlobBytesHeader, strconv.FormatInt(c.maxBlobBytes, 10))
+		r.Body = http.MaxBytesReader(w, r.Body, c.maxBlobBytes+multipartOverhead)
+		err := r.ParseMultipartForm(c.multipartMemory)
+		if isBodyTooLarge(err) {
+			return nil, http.StatusRequestEntityTooLarge, ErrBlobTooLarge
+		}
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		// remove spilled parts now rather than leave them to build up in the temp dir
+		defer r.MultipartForm.RemoveAll()
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		defer f.Close()
+		if header.Size > c.maxBlobBytes {
+			return nil, http.StatusRequestEntityTooLarge, ErrBlobTooLarge
+		}
+
+		fileName := r.FormValue("file_name")
+		if fileName == "" {
+			fileName = header.Filename
+		}
+		if fileName == "" {
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{"file_name": "required, the file part has no name either"})
+		}
+		err = validFileName(fileName)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		expiresAt, err := blobExpiresAt(r, time.Now())
+		if err != nil {
+			return nil, http.StatusUnprocessableEntity, err
+		}
+
+		b, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		checksum := blobChecksum(b)
+		code, err := checkDeclared(r, b, checksum)
+		if err != nil {
+			return nil, code, err
+		}
+
+		// prefer an explicit mime type, then whatever the client attached to the part
+		mimeType := r.FormValue("mime_type")
+		if mimeType == "" {
+			mimeType = header.Header.Get("Content-Type")
+		}
+		plan, code, err := c.planUpload(r, fileName, mimeType, b, checksum, expiresAt)
+		if err != nil {
+			return nil, code, err
+		}
+		if existing := plan.existing; existing != nil {
+			c.log.Infow("blob deduplicated", "file_name", fileName, "existing_file_name", existing.FileName)
+			return &Response{
+				FileName:      existing.FileName,
+				MimeType:      existing.MimeType,
+				FileSizeBytes: existing.FileSizeBytes,
+				Checksum:      existing.Checksum,
+				Deduplicated:  true,
+			}, http.StatusOK, nil
+		}
+		blob, ciphertext, current := plan.blob, plan.ciphertext, plan.current
+		if current != nil {
+			version, err := c.replaceBlob(r.Context(), current, blob, ciphertext)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			c.metrics.blobUploadsTotal.Inc()
+			c.audit(r, AuditUpload, current.FileName)
+			c.publishBlob(EventBlobCreated, current, version)
+			c.log.Infow("blob version uploaded", "file_name", current.FileName, "size", current.FileSizeBytes, "version", version)
+			return &Response{
+				FileName:      current.FileName,
+				MimeType:      current.MimeType,
+				FileSizeBytes: current.FileSizeBytes,
+				Checksum:      current.Checksum,
+				ExpiresAt:     current.ExpiresAt.Ptr(),
+				Version:       version,
+			}, http.StatusCreated, nil
+		}
+		err = storeBlob(r.Context(), c.store, blob, ciphertext)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		c.metrics.blobUploadsTotal.Inc()
+		c.audit(r, AuditUpload, blob.FileName)
+		c.publishBlob(EventBlobCreated, blob, 0)
+		c.log.Infow("blob uploaded", "file_name", blob.FileName, "size", blob.FileSizeBytes)
+		return &Response{
+			FileName:      blob.FileName,
+			MimeType:      blob.MimeType,
+			FileSizeBytes: blob.FileSizeBytes,
+			Checksum:      blob.Checksum,
+			ExpiresAt:     blob.ExpiresAt.Ptr(),
+		}, http.StatusCreated, nil
+	}
+	return fn
+}
+
+// uploadPlan is how an upload that passed planUpload gets stored, exactly one of
+// existing, current or neither is set
+type uploadPlan struct {
+	// existing already holds the same contents, nothing is stored
+	existing *db.Blob
+	// current is the caller's blob under the name, versioning replaces its contents with blob's
+	current *db.Blob
+	// blob and ciphertext are the new row and its encrypted contents
+	blob       *db.Blob
+	ciphertext []byte
+}
+
+// planUpload applies the rules every upload is held to, whether on its own or in
+// a batch, returning the status to fail with. An expired blob no longer holds its
+// name but a trashed one does. With versioning the owner can upload over a name,
+// anyone else still conflicts. Contents the caller already stored aren't stored
+// again under any name, which also makes retried uploads idempotent.
+func (c *API) planUpload(r *http.Request, fileName, mimeType string, b []byte, checksum string, expiresAt null.Time) (*uploadPlan, int, error) {
+	ctx := r.Context()
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil, http.StatusUnauthorized, ErrUnauthorized
+	}
+	_, err := c.deleteExpiredBlobs(ctx, time.Now(), db.BlobWhere.FileName.EQ(fileName))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	current, err := db.Blobs(qm.Select(blobColumnsWithoutFile...), db.BlobWhere.FileName.EQ(fileName)).OneG(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, http.StatusInternalServerError, err
+	}
+	taken := err == nil
+	if taken && current.DeletedAt.Valid {
+		return nil, http.StatusConflict, ErrBlobTrashed
+	}
+	replacing := taken && c.blobVersioning && canAccessBlob(r, current)
+
+	// Only permanent blobs are reused, so the result never expires sooner than asked.
+	// A new version of a name is only compared with the name's latest contents.
+	existing, err := blobByChecksum(ctx, checksum, null.Int64From(claims.UserID))
+	if replacing {
+		existing, err = current, sql.ErrNoRows
+		if current.Checksum == checksum && current.ExpiresAt.IsNull() {
+			err = nil
+		}
+	}
+	if err == nil {
+		return &uploadPlan{existing: existing}, http.StatusOK, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, http.StatusInternalServerError, err
+	}
+	if taken && !replacing {
+		return nil, http.StatusConflict, ErrBlobExists
+	}
+
+	blob, ciphertext, err := newBlob(c.masterKey, fileName, detectMimeType(mimeType, fileName, b), b)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	blob.OwnerID = null.Int64From(claims.UserID)
+	blob.ExpiresAt = expiresAt
+	plan := &uploadPlan{blob: blob, ciphertext: ciphertext}
+	if replacing {
+		plan.current = current
+	}
+	return plan, http.StatusOK, nil
+}
+
+// blobByChecksum finds the oldest permanent blob with the given content, only among ownerID's blobs when it's set
+func blobByChecksum(ctx context.Context, checksum string, ownerID null.Int64) (*db.Blob, error) {
+	filters := []qm.QueryMod{
+		qm.Select(blobMetadataColumns...),
+		db.BlobWhere.Checksum.EQ(checksum),
+		db.BlobWhere.ExpiresAt.IsNull(),
+		db.BlobWhere.DeletedAt.IsNull(),
+		qm.OrderBy(db.BlobColumns.ID),
+	}
+	if ownerID.Valid {
+		filters = append(filters, db.BlobWhere.OwnerID.EQ(ownerID))
+	}
+	return db.Blobs(filters...).OneG(ctx)
+}
+
+// blobExistsHandler lets sync clients skip uploading content the server already
+// has. Only the caller's own blobs are searched unless they are an admin.
+func (c *API) blobExistsHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Exists   bool   `json:"exists"`
+			FileName string `json:"file_name,omitempty"`
+		}
+
+		checksum := strings.ToLower(r.URL.Query().Get("checksum"))
+		b, err := hex.DecodeString(checksum)
+		if err != nil || len(b) != sha256.Size {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid checksum: %q", checksum)
+		}
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			return nil, http.StatusUnauthorized, ErrUnauthorized
+		}
+		ownerID := null.Int64From(claims.UserID)
+		if claims.Admin {
+			ownerID = null.Int64{}
+		}
+
+		blob, err := blobByChecksum(r.Context(), checksum, ownerID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return &Response{Exists: false}, http.StatusOK, nil
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return &Response{Exists: true, FileName: blob.FileName}, http.StatusOK, nil
+	}
+	return fn
+}
+
+// blobOptionsHandler lets clients discover the upload limit before sending a file
+func (c *API) blobOptionsHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", "GET, POST, OPTIONS")
+		w.Header().Set(maxBlobBytesHeader, strconv.FormatInt(c.maxBlobBytes, 10))
+		w.WriteHeader(http.StatusNoContent)
+	}
+	return fn
+}
+
+// blobDeleteHandler moves a blob to the trash when TrashRetention is set,
+// otherwise it and its stored contents are removed straight away
+func (c *API) blobDeleteHandler() SecureHandlerFunc {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		blobFilename := chi.URLParam(r, "blob_id")
+		blob, err := db.Blobs(
+			qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName, db.BlobColumns.OwnerID),
+			db.BlobWhere.FileName.EQ(blobFilename),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, ErrBlobNotFound
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !canAccessBlob(r, blob) {
+			return nil, http.StatusForbidden, ErrForbidden
+		}
+
+		if c.trashRetention > 0 {
+			err = trashBlob(r.Context(), boil.GetContextDB(), blob, time.Now())
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		} else {
+			err = c.deleteBlobContents(r.Context(), blob)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			_, err = blob.DeleteG(r.Context())
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+
+		c.audit(r, AuditDelete, blob.FileName)
+		c.publishBlob(EventBlobDeleted, blob, 0)
+		c.log.Infow("blob deleted", "file_name", blob.FileName, "trashed", blob.DeletedAt.Valid)
+		return nil, http.StatusNoContent, nil
+	}
+	return fn
+}
+
+// blobRenameHandler changes a blob's filename, the stored contents are keyed by ID and don't move
+func (c *API) blobRenameHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Request struct {
+			NewFilename string `json:"new_filename"`
+		}
+
+		req := &Request{}
+		err := decodeJSON(r, req)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if req.NewFilename == "" {
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{"new_filename": "required"})
+		}
+		err = validFileName(req.NewFilename)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		blobFilename := chi.URLParam(r, "blob_id")
+		blob, err := db.Blobs(
+			qm.Select(append([]string{db.BlobColumns.ID, db.BlobColumns.OwnerID}, blobMetadataColumns...)...),
+			db.BlobWhere.FileName.EQ(blobFilename),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http.StatusNotFound, ErrBlobNotFound
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !canAccessBlob(r, blob) {
+			return nil, http.StatusForbidden, ErrForbidden
+		}
+		if !ifMatch(r, blob) {
+			return nil, http.StatusPreconditionFailed, ErrPreconditionFailed
+		}
+		if blob.Checksum != "" {
+			w.Header().Set("ETag", blobETag(blob.Checksum))
+		}
+		if req.NewFilename == blob.FileName {
+			return newBlobMetadata(blob), http.StatusOK, nil
+		}
+
+		exists, err := db.Blobs(db.BlobWhere.FileName.EQ(req.NewFilename)).ExistsG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if exists {
+			return nil, http.StatusConflict, ErrBlobExists
+		}
+
+		blob.FileName = req.NewFilename
+		_, err = blob.UpdateG(r.Context(), boil.Whitelist(db.BlobColumns.FileName, db.BlobColumns.UpdatedAt))
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		c.audit(r, AuditRename, blobFilename)
+		c.log.Infow("blob renamed", "file_name", blobFilename, "new_file_name", blob.FileName)
+		return newBlobMetadata(blob), http.StatusOK, nil
+	}
+	return fn
+}
@@ -0,0 +1,194 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"doco/db"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"go.uber.org/zap"
+)
+
+const testJWTSecret = "test-secret"
+
+// newTestServer serves the API over a fresh test database and returns a bearer
+// token for a user in it. config only needs the settings under test, the rest
+// are filled in. Call the returned func when done.
+func newTestServer(t *testing.T, config ServerConfig) (*httptest.Server, string, func()) {
+	t.Helper()
+	conn, teardown := NewTestDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := func() {
+		cancel()
+		teardown()
+	}
+	store, err := NewBlobStore("db", "", conn)
+	if err != nil {
+		stop()
+		t.Fatal(err)
+	}
+	config.JWTSecret = testJWTSecret
+	config.MasterKey = make([]byte, masterKeySize)
+	config.Store = store
+	if config.MaxBlobBytes == 0 {
+		config.MaxBlobBytes = 1 << 20
+	}
+	h, err := newRouter(ctx, conn, config, zap.NewNop().Sugar())
+	if err != nil {
+		stop()
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(h)
+	stop = func() {
+		srv.Close()
+		cancel()
+		teardown()
+	}
+
+	user := &db.User{Username: "alice", PasswordHash: "unused"}
+	err = insertG(ctx, user, func() error {
+		inserted, err := db.Users(qm.Select(db.UserColumns.ID), db.UserWhere.Username.EQ(user.Username)).OneG(ctx)
+		if err != nil {
+			return err
+		}
+		user.ID = inserted.ID
+		return nil
+	})
+	if err != nil {
+		stop()
+		t.Fatal(err)
+	}
+	token, _, err := (&API{jwtSecret: []byte(testJWTSecret)}).issueToken(user)
+	if err != nil {
+		stop()
+		t.Fatal(err)
+	}
+
+	// requests get 503 until the schema check has run
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		ready, err := http.Get(srv.URL + DefaultAPIPrefix + "/ready")
+		if err == nil {
+			ready.Body.Close()
+			if ready.StatusCode == http.StatusOK {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			stop()
+			t.Fatal("schema not ready")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return srv, token, stop
+}
+
+func doRequest(t *testing.T, token, method, url string, body io.Reader, contentType string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, b
+}
+
+// uploadBlob posts contents as fileName
+func uploadBlob(t *testing.T, srv *httptest.Server, token, fileName string, contents []byte) (*http.Response, []byte) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(contents)
+	mw.Close()
+	return doRequest(t, token, http.MethodPost, srv.URL+DefaultAPIPrefix+"/blobs", body, mw.FormDataContentType())
+}
+
+func TestBlobUploadDownloadDelete(t *testing.T) {
+	srv, token, stop := newTestServer(t, ServerConfig{})
+	defer stop()
+	blobURL := srv.URL + DefaultAPIPrefix + "/blobs/hello.txt"
+	contents := []byte("hello, doco")
+
+	resp, b := uploadBlob(t, srv, token, "hello.txt", contents)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("upload: got %d %s", resp.StatusCode, b)
+	}
+
+	resp, b = doRequest(t, token, http.MethodGet, blobURL, nil, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("download: got %d %s", resp.StatusCode, b)
+	}
+	if !bytes.Equal(b, contents) {
+		t.Fatalf("download: got %q, want %q", b, contents)
+	}
+
+	resp, b = doRequest(t, token, http.MethodDelete, blobURL, nil, "")
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("delete: got %d %s", resp.StatusCode, b)
+	}
+
+	resp, b = doRequest(t, token, http.MethodGet, blobURL, nil, "")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("download after delete: got %d %s", resp.StatusCode, b)
+	}
+}
+
+func TestValidFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		ok       bool
+	}{
+		{"plain", "report.pdf", true},
+		{"unicode", "résumé 2020.pdf", true},
+		{"dotfile", ".env", true},
+		{"longest", strings.Repeat("a", maxFileNameLength), true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxFileNameLength+1), false},
+		{"invalid utf-8", "a\xffb", false},
+		{"dot", ".", false},
+		{"dot dot", "..", false},
+		{"slash", "a/b", false},
+		{"backslash", `a\b`, false},
+		{"traversal", "../etc/passwd", false},
+		{"newline", "a\nb", false},
+		{"nul", "a\x00b", false},
+		{"delete", "a\x7fb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validFileName(tt.fileName)
+			if (err == nil) != tt.ok {
+				t.Fatalf("got %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidFileName) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidFileName)
+			}
+		})
+	}
+}
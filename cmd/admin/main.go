@@ -1,20 +1,18 @@
 package main
 
 import (
+	"doco"
 	"flag"
 	"fmt"
 
-	"doco/bindata"
-
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/sqlite3"
-	migrate_bindata "github.com/golang-migrate/migrate/v4/source/go_bindata"
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/volatiletech/sqlboiler/boil"
 )
 
-func connect() (*sqlx.DB, error) {
-	conn, err := sqlx.Connect("sqlite3", "./doco.db")
+func connect(driver, dsn string) (*sqlx.DB, error) {
+	conn, err := sqlx.Connect(driver, dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -24,9 +22,11 @@ func main() {
 	dbversion := flag.Bool("db-version", false, "Get the DB version")
 	dbmigrate := flag.Bool("db-migrate", false, "Migrate DB")
 	dbdrop := flag.Bool("db-drop", false, "Drop DB")
+	dbdriver := flag.String("db-driver", doco.DriverSQLite, "Database driver, sqlite3 or postgres")
+	dbpath := flag.String("db-path", "./doco.db", "Path to the SQLite database, or the Postgres connection URL")
 	flag.Parse()
 
-	conn, err := connect()
+	conn, err := connect(*dbdriver, *dbpath)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -34,7 +34,7 @@ func main() {
 	boil.SetDB(conn)
 	if *dbversion {
 		fmt.Println("Getting DB version...")
-		v, d, err := Version(conn)
+		v, d, err := doco.Version(conn)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -44,7 +44,7 @@ func main() {
 	}
 	if *dbmigrate {
 		fmt.Println("Migrating doco system...")
-		err = Migrate(conn)
+		err = doco.Migrate(conn)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -53,7 +53,7 @@ func main() {
 	}
 	if *dbdrop {
 		fmt.Println("Dropping doco system...")
-		err = Drop(conn)
+		err = doco.Drop(conn)
 		if err != nil {
 			fmt.Println(err)
 			return
@@ -62,56 +62,3 @@ func main() {
 	}
 
 }
-
-func newMigrateInstance(conn *sqlx.DB) (*migrate.Migrate, error) {
-	s := migrate_bindata.Resource(bindata.AssetNames(),
-		func(name string) ([]byte, error) {
-			return bindata.Asset(name)
-		})
-	d, err := migrate_bindata.WithInstance(s)
-	if err != nil {
-		return nil, fmt.Errorf("bindata instance: %w", err)
-	}
-	dbDriver, err := sqlite3.WithInstance(conn.DB, &sqlite3.Config{})
-	if err != nil {
-		return nil, fmt.Errorf("db instance: %w", err)
-	}
-	m, err := migrate.NewWithInstance("go-bindata", d, "sqlite", dbDriver)
-	if err != nil {
-		return nil, fmt.Errorf("migrate instance: %w", err)
-	}
-	return m, nil
-}
-func Migrate(conn *sqlx.DB) error {
-	m, err := newMigrateInstance(conn)
-	if err != nil {
-		return fmt.Errorf("migrate: %w", err)
-	}
-	err = m.Up()
-	if err != nil {
-		return fmt.Errorf("migrate: %w", err)
-	}
-	return nil
-}
-func Drop(conn *sqlx.DB) error {
-	m, err := newMigrateInstance(conn)
-	if err != nil {
-		return fmt.Errorf("migrate: %w", err)
-	}
-	err = m.Drop()
-	if err != nil {
-		return fmt.Errorf("migrate: %w", err)
-	}
-	return nil
-}
-func Version(conn *sqlx.DB) (uint, bool, error) {
-	m, err := newMigrateInstance(conn)
-	if err != nil {
-		return 0, false, fmt.Errorf("migrate: %w", err)
-	}
-	v, d, err := m.Version()
-	if err != nil {
-		return 0, false, fmt.Errorf("migrate: %w", err)
-	}
-	return v, d, nil
-}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"go.uber.org/zap"
+)
+
+// envPrefix is the envconfig prefix, DOCO_DBPATH and so on
+const envPrefix = "doco"
+
+// redactedConfigFields are never printed, they hold secrets or carry credentials
+var redactedConfigFields = map[string]bool{
+	"MasterKey":     true,
+	"JWTSecret":     true,
+	"DBURL":         true,
+	"WebhookSecret": true,
+}
+
+// redactedURLFields are printed without their userinfo, query or fragment, which
+// can carry credentials, so where they point is still visible
+var redactedURLFields = map[string]bool{
+	"WebhookURL": true,
+}
+
+// redactURL strips what can carry credentials from raw, an unparsable URL is redacted whole
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// envKey is the variable envconfig reads a Config field from
+func envKey(field string) string {
+	return strings.ToUpper(envPrefix + "_" + field)
+}
+
+// loadConfigFile reads a JSON object keyed by Config field name, for example
+// {"DBDriver": "postgres", "RequestTimeout": "2m", "AllowedOrigins": ["https://doco.example"]}.
+// Each value is exported as the field's environment variable unless that is already
+// set, so envconfig parses it like any other and the environment still wins.
+func loadConfigFile(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	values := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err = dec.Decode(&values)
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	fields := map[string]string{}
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		fields[strings.ToLower(t.Field(i).Name)] = t.Field(i).Name
+	}
+	for key, value := range values {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("config file %s: unknown setting %q", path, key)
+		}
+		if _, set := os.LookupEnv(envKey(field)); set {
+			continue
+		}
+		s, err := envValue(value)
+		if err != nil {
+			return fmt.Errorf("config file %s: %s: %w", path, key, err)
+		}
+		err = os.Setenv(envKey(field), s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// envValue renders a JSON value the way envconfig expects it in the environment
+func envValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		items := []string{}
+		for _, item := range v {
+			s, err := envValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
+
+// redacted stands in for the value of a redactedConfigFields field
+const redacted = "<redacted>"
+
+// configEntry is one Config field's effective value, keyed by its environment variable
+type configEntry struct {
+	key   string
+	value interface{}
+}
+
+// effectiveConfig lists the Config fields in declaration order, with set secrets redacted
+func effectiveConfig(c *Config) []configEntry {
+	entries := []configEntry{}
+	v := reflect.ValueOf(*c)
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		value := v.Field(i).Interface()
+		if redactedConfigFields[name] && !v.Field(i).IsZero() {
+			value = redacted
+		}
+		if redactedURLFields[name] && !v.Field(i).IsZero() {
+			value = redactURL(v.Field(i).String())
+		}
+		entries = append(entries, configEntry{envKey(name), value})
+	}
+	return entries
+}
+
+// printConfig writes the effective config, after defaults, file and environment are merged
+func printConfig(c *Config) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tEFFECTIVE VALUE")
+	for _, entry := range effectiveConfig(c) {
+		value := ""
+		switch field := entry.value.(type) {
+		case []string:
+			value = strings.Join(field, ",")
+		default:
+			value = fmt.Sprint(field)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", entry.key, value)
+	}
+	tw.Flush()
+}
+
+// logConfig logs the effective config as structured fields, so a process's logs
+// show the settings it actually started with
+func logConfig(c *Config, log *zap.SugaredLogger) {
+	fields := []interface{}{}
+	for _, entry := range effectiveConfig(c) {
+		fields = append(fields, entry.key, entry.value)
+	}
+	log.Infow("effective config", fields...)
+}
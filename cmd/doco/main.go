@@ -1,13 +1,21 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"doco"
 	"flag"
 	"fmt"
 	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/kelseyhightower/envconfig"
@@ -15,64 +23,431 @@ import (
 	"github.com/volatiletech/sqlboiler/boil"
 )
 
-func connect() (*sqlx.DB, error) {
-	conn, err := sqlx.Connect("sqlite3", "./doco.db")
+// sqlitePragmas enable concurrent reads during writes, retry on a locked
+// database rather than failing, and enforce foreign keys
+const sqlitePragmas = "_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=on"
+
+func sqliteDSN(path string) string {
+	if strings.Contains(path, "?") {
+		return path + "&" + sqlitePragmas
+	}
+	return path + "?" + sqlitePragmas
+}
+
+// maxConnectBackoff caps the wait between pings while the database comes up
+const maxConnectBackoff = 5 * time.Second
+
+// connect opens dsn with the driver, a file path for sqlite3 or a connection URL for postgres.
+// A database that isn't reachable yet is pinged again with backoff until timeout
+// runs out, in containers it often starts alongside doco.
+func connect(driver, dsn string, maxOpenConns, maxIdleConns int, connMaxLifetime, timeout time.Duration) (*sqlx.DB, error) {
+	conn, err := sqlx.Open(driver, dsn)
 	if err != nil {
 		return nil, err
 	}
+	deadline := time.Now().Add(timeout)
+	backoff := 250 * time.Millisecond
+	for {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		err = conn.PingContext(ctx)
+		cancel()
+		if err == nil {
+			break
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			conn.Close()
+			return nil, fmt.Errorf("database not reachable after %s: %w", timeout, err)
+		}
+		log.Printf("database not reachable, retrying in %s: %s", backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxConnectBackoff {
+			backoff = maxConnectBackoff
+		}
+	}
+	// SQLite allows one writer, more connections only contend for the lock
+	if maxOpenConns == 0 && driver == doco.DriverSQLite {
+		maxOpenConns = 1
+	}
+	conn.SetMaxOpenConns(maxOpenConns)
+	conn.SetMaxIdleConns(maxIdleConns)
+	conn.SetConnMaxLifetime(connMaxLifetime)
 	return conn, nil
 }
 
 type Config struct {
-	MasterKey        string `default:"9A1F3DE2BB279CB966CC1167BC6C538FDE97268E3EE5F581D918309409520AE3"`
-	JWTSecret        string `default:"contractible-roasted-mollusk"`
-	StepMinutes      int    `default:"5"`
-	RootPath         string `default:"./web/dist"`
-	ServerAddr       string `default:":8081"`
-	LoadBalancerAddr string `default:":8080"`
+	DBDriver            string `default:"sqlite3"`
+	DBURL               string
+	DBMaxOpenConns      int
+	DBMaxIdleConns      int           `default:"2"`
+	DBConnMaxLifetime   time.Duration `default:"1h"`
+	DBConnectTimeout    time.Duration `default:"30s"`
+	DBPath              string        `default:"./doco.db"`
+	MasterKey           string
+	JWTSecret           string
+	StepMinutes         int           `default:"5"`
+	RootPath            string        `default:"./web/dist"`
+	ServerAddr          string        `default:":8081"`
+	LoadBalancerAddr    string        `default:":8080"`
+	APIPrefix           string        `default:"/api"`
+	AllowedOrigins      []string      `default:"http://localhost:8080"`
+	MaxBlobBytes        int64         `default:"104857600"`
+	MultipartMemory     int64         `default:"33554432"`
+	BlobStore           string        `default:"db"`
+	BlobStorePath       string        `default:"./blobs"`
+	RequestTimeout      time.Duration `default:"5m"`
+	ReadTimeout         time.Duration `default:"5m"`
+	WriteTimeout        time.Duration `default:"10m"`
+	IdleTimeout         time.Duration `default:"2m"`
+	ShutdownTimeout     time.Duration `default:"30s"`
+	RateLimit           float64       `default:"10"`
+	RateLimitBurst      int           `default:"20"`
+	MaxDownloads        int
+	DownloadQueueWait   time.Duration `default:"5s"`
+	HealthCheckInterval time.Duration `default:"30s"`
+	GatewayErrorPage    string
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSEmail            string
+	LogLevel            string        `default:"info"`
+	SeedAvatarTimeout   time.Duration `default:"10s"`
+	SeedAvatarAttempts  int           `default:"3"`
+	SeedOffline         bool
+	SeedSkipAvatar      bool
+	LogJSON             bool
+	PrettyJSON          bool
+	CompressResponses   bool
+	BlobVersioning      bool
+	AutoMigrate         bool
+	TrashDays           int
+	WebhookURL          string
+	WebhookSecret       string
+	SessionStore        string        `default:"memory"`
+	SessionLifetime     time.Duration `default:"24h"`
+}
+
+// publishedJWTSecrets were committed to the repo as defaults, anyone can sign
+// tokens and share links with them
+var publishedJWTSecrets = map[string]bool{
+	"contractible-roasted-mollusk": true,
+}
+
+// publishedMasterKeys were committed to the repo as defaults, blobs encrypted with
+// them are readable by anyone. Keys are upper case hex.
+var publishedMasterKeys = map[string]bool{
+	"9A1F3DE2BB279CB966CC1167BC6C538FDE97268E3EE5F581D918309409520AE3": true,
+}
+
+// apiPrefixPattern accepts plain path segments, the prefix is also used in a Caddyfile regex
+var apiPrefixPattern = regexp.MustCompile(`^(/[A-Za-z0-9_-]+)+$`)
+
+// Validate checks the config is coherent before anything boots, reporting every problem at once
+func (c *Config) Validate() error {
+	problems := []string{}
+	switch c.DBDriver {
+	case doco.DriverSQLite:
+	case doco.DriverPostgres:
+		if c.DBURL == "" {
+			problems = append(problems, "db url is required for postgres")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("db driver must be %s or %s, got %q", doco.DriverSQLite, doco.DriverPostgres, c.DBDriver))
+	}
+	if c.DBMaxOpenConns < 0 || c.DBMaxIdleConns < 0 || c.DBConnMaxLifetime < 0 || c.DBConnectTimeout < 0 {
+		problems = append(problems, "db pool settings can't be negative")
+	}
+	if c.ServerAddr == c.LoadBalancerAddr {
+		problems = append(problems, fmt.Sprintf("server and load balancer both listen on %q", c.ServerAddr))
+	}
+	if !apiPrefixPattern.MatchString(c.APIPrefix) {
+		problems = append(problems, fmt.Sprintf("api prefix must be a path like /api without a trailing slash, got %q", c.APIPrefix))
+	}
+	if c.MasterKey == "" {
+		problems = append(problems, "master key is required")
+	} else if publishedMasterKeys[strings.ToUpper(c.MasterKey)] {
+		problems = append(problems, "master key is a published default, generate one with: openssl rand -hex 32")
+	}
+	if c.JWTSecret == "" {
+		problems = append(problems, "jwt secret is required")
+	} else if publishedJWTSecrets[c.JWTSecret] {
+		problems = append(problems, "jwt secret is a published default, set a random one")
+	}
+	if c.StepMinutes <= 0 {
+		problems = append(problems, fmt.Sprintf("step minutes must be positive, got %d", c.StepMinutes))
+	}
+	info, err := os.Stat(c.RootPath)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("web root not found: %s", err))
+	} else if !info.IsDir() {
+		problems = append(problems, fmt.Sprintf("web root not found: %s is not a directory", c.RootPath))
+	}
+	if c.TrashDays < 0 {
+		problems = append(problems, fmt.Sprintf("trash days can't be negative, got %d", c.TrashDays))
+	}
+	if c.WebhookURL != "" {
+		u, err := url.Parse(c.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("webhook url must be an http or https URL, got %q", c.WebhookURL))
+		}
+		if c.WebhookSecret == "" {
+			problems = append(problems, "webhook secret is required with a webhook url")
+		}
+	}
+	if c.MaxBlobBytes <= 0 {
+		problems = append(problems, fmt.Sprintf("max blob bytes must be positive, got %d", c.MaxBlobBytes))
+	}
+	if c.MultipartMemory <= 0 {
+		problems = append(problems, fmt.Sprintf("multipart memory must be positive, got %d", c.MultipartMemory))
+	}
+	if c.BlobStore != "db" && c.BlobStore != "fs" {
+		problems = append(problems, fmt.Sprintf("blob store must be db or fs, got %q", c.BlobStore))
+	}
+	if c.SessionStore != "memory" && c.SessionStore != "db" {
+		problems = append(problems, fmt.Sprintf("session store must be memory or db, got %q", c.SessionStore))
+	}
+	if c.SessionLifetime <= 0 {
+		problems = append(problems, fmt.Sprintf("session lifetime must be positive, got %s", c.SessionLifetime))
+	}
+	if c.RequestTimeout < 0 {
+		problems = append(problems, fmt.Sprintf("request timeout can't be negative, got %s", c.RequestTimeout))
+	}
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.IdleTimeout < 0 || c.ShutdownTimeout < 0 {
+		problems = append(problems, "server read, write, idle and shutdown timeouts can't be negative")
+	}
+	if c.WriteTimeout > 0 && c.RequestTimeout > c.WriteTimeout {
+		problems = append(problems, fmt.Sprintf("write timeout %s would cut off requests the %s request timeout allows", c.WriteTimeout, c.RequestTimeout))
+	}
+	if c.RateLimit < 0 {
+		problems = append(problems, fmt.Sprintf("rate limit can't be negative, got %v", c.RateLimit))
+	}
+	if c.RateLimit > 0 && c.RateLimitBurst < 1 {
+		problems = append(problems, fmt.Sprintf("rate limit burst must be at least 1, got %d", c.RateLimitBurst))
+	}
+	if c.MaxDownloads < 0 || c.DownloadQueueWait < 0 {
+		problems = append(problems, "max downloads and download queue wait can't be negative")
+	}
+	if c.HealthCheckInterval < 0 {
+		problems = append(problems, fmt.Sprintf("health check interval can't be negative, got %s", c.HealthCheckInterval))
+	}
+	if c.GatewayErrorPage != "" {
+		_, err = os.Stat(c.GatewayErrorPage)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("gateway error page not found: %s", err))
+		}
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		problems = append(problems, "tls cert file and key file must be set together")
+	}
+	_, err = doco.ParseLogLevel(c.LogLevel)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// confirm asks a yes/no question on stdin, anything but y or yes is a no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
 }
 
 func main() {
 	dbseed := flag.Bool("db-seed", false, "Seed fake data")
-	showConfig := flag.Bool("config", false, "Show config variables")
+	dbseedCount := flag.Int("db-seed-count", 0, "Generate N blobs, and a user for every 10, on top of the samples when seeding")
+	seedRandomSeed := flag.Int64("seed-random-seed", 0, "Seed generated data from this value so it is reproducible, picked and logged when 0")
+	showVersion := flag.Bool("version", false, "Show build info")
+	showConfig := flag.Bool("config", false, "Show config variables and their effective values")
+	configFile := flag.String("config-file", "", "Read config from a JSON file, environment variables take precedence")
+	migrateUp := flag.Bool("migrate-up", false, "Apply all pending migrations")
+	migrateDown := flag.Int("migrate-down", 0, "Roll back N migrations")
+	migrateVersion := flag.Bool("migrate-version", false, "Show the current migration version")
+	migratePlan := flag.Bool("migrate-plan", false, "Show the pending migrations and their SQL without applying them")
+	backfillMime := flag.Bool("backfill-mime", false, "Sniff mime types for blobs stored as unknown")
+	dbDrop := flag.Bool("db-drop", false, "Drop all tables, asks for confirmation unless -force is set")
+	force := flag.Bool("force", false, "Skip confirmation prompts")
 
+	flag.Parse()
+	if *showVersion {
+		info := doco.GetBuildInfo()
+		fmt.Printf("doco %s, commit %s, built %s with %s\n", info.Version, info.Commit, info.BuildDate, info.GoVersion)
+		return
+	}
+	if *configFile != "" {
+		err := loadConfigFile(*configFile)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 	c := &Config{}
-	err := envconfig.Process("doco", c)
+	err := envconfig.Process(envPrefix, c)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	flag.Parse()
-	conn, err := connect()
-	if err != nil {
-		fmt.Println(err)
+	if *showConfig {
+		envconfig.Usage(envPrefix, c)
+		fmt.Println()
+		printConfig(c)
 		return
 	}
+	err = c.Validate()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	// fail fast, the key protects every blob at rest
+	masterKey, err := doco.ParseMasterKey(c.MasterKey)
+	if err != nil {
+		log.Fatalf("invalid DOCO_MASTERKEY: %s", err)
+	}
+	dsn := sqliteDSN(c.DBPath)
+	if c.DBDriver == doco.DriverPostgres {
+		dsn = c.DBURL
+	}
+	conn, err := connect(c.DBDriver, dsn, c.DBMaxOpenConns, c.DBMaxIdleConns, c.DBConnMaxLifetime, c.DBConnectTimeout)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 	boil.SetDB(conn)
-	if *showConfig {
-		envconfig.Usage("doco", c)
+	if *migrateUp {
+		fmt.Println("Migrating doco system...")
+		err = doco.Migrate(conn)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+	if *migrateDown > 0 {
+		fmt.Printf("Rolling back %d migrations...\n", *migrateDown)
+		err = doco.Rollback(conn, *migrateDown)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+	if *dbDrop {
+		// don't echo the postgres URL, it usually carries a password
+		target := c.DBPath
+		if c.DBDriver == doco.DriverPostgres {
+			target = "DOCO_DBURL"
+		}
+		if !*force && !confirm(fmt.Sprintf("Drop every table in %s?", target)) {
+			fmt.Println("Aborted")
+			return
+		}
+		fmt.Println("Dropping doco system...")
+		err = doco.Drop(conn)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+	if *migratePlan {
+		pending, err := doco.Plan(conn)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		if len(pending) == 0 {
+			fmt.Println("No pending migrations")
+			return
+		}
+		for _, m := range pending {
+			fmt.Printf("-- %s\n%s\n", m.Name, strings.TrimSpace(m.UpSQL))
+		}
+		return
+	}
+	if *migrateVersion {
+		v, d, err := doco.Version(conn)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Version: %d, Dirty: %v\n", v, d)
+		return
+	}
+	store, err := doco.NewBlobStore(c.BlobStore, c.BlobStorePath, conn)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if *backfillMime {
+		fmt.Println("Backfilling blob mime types...")
+		n, err := doco.BackfillMimeTypes(context.Background(), store, masterKey, doco.NewLogToStdOut("backfill", c.LogLevel, c.LogJSON))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Updated %d blobs\n", n)
 		return
 	}
 	if *dbseed {
 		fmt.Println("Seeding doco system...")
-		err = doco.Seed(c.MasterKey)
+		seedOptions := doco.SeedOptions{
+			AvatarTimeout:  c.SeedAvatarTimeout,
+			AvatarAttempts: c.SeedAvatarAttempts,
+			OfflineAvatar:  c.SeedOffline,
+			SkipAvatar:     c.SeedSkipAvatar,
+			Count:          *dbseedCount,
+			RandomSeed:     *seedRandomSeed,
+		}
+		err = doco.Seed(conn, store, c.MasterKey, seedOptions, doco.NewLogToStdOut("seed", c.LogLevel, c.LogJSON))
 		if err != nil {
-			fmt.Println(err)
-			return
+			log.Fatal(err.Error())
 		}
 		return
 	}
 
+	serverConfig := doco.ServerConfig{
+		Addr:           c.ServerAddr,
+		APIPrefix:      c.APIPrefix,
+		JWTSecret:      c.JWTSecret,
+		MasterKey:      masterKey,
+		AllowedOrigins: c.AllowedOrigins,
+		MaxBlobBytes:   c.MaxBlobBytes,
+		Store:          store,
+		RequestTimeout: c.RequestTimeout,
+		RateLimit:      c.RateLimit,
+		RateLimitBurst: c.RateLimitBurst,
+		PrettyJSON:     c.PrettyJSON,
+
+		CompressResponses: c.CompressResponses,
+		SessionStore:      c.SessionStore,
+		SessionLifetime:   c.SessionLifetime,
+		SecureCookies:     c.TLSCertFile != "" || c.TLSEmail != "",
+		StepInterval:      time.Duration(c.StepMinutes) * time.Minute,
+		BlobVersioning:    c.BlobVersioning,
+		AutoMigrate:       c.AutoMigrate,
+		TrashRetention:    time.Duration(c.TrashDays) * 24 * time.Hour,
+		WebhookURL:        c.WebhookURL,
+		WebhookSecret:     c.WebhookSecret,
+		MaxDownloads:      c.MaxDownloads,
+		DownloadQueueWait: c.DownloadQueueWait,
+		MultipartMemory:   c.MultipartMemory,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		ShutdownTimeout:   c.ShutdownTimeout,
+	}
+	tlsConfig := doco.TLSConfig{
+		CertFile: c.TLSCertFile,
+		KeyFile:  c.TLSKeyFile,
+		Email:    c.TLSEmail,
+	}
+
 	fmt.Println("Booting up doco system...")
+	logConfig(c, doco.NewLogToStdOut("config", c.LogLevel, c.LogJSON))
 	g := &run.Group{}
 	ctx, cancel := context.WithCancel(context.Background())
+	// SIGTERM from a deploy drains the server rather than killing transfers
+	g.Add(run.SignalHandler(ctx, os.Interrupt, syscall.SIGTERM))
 	g.Add(func() error {
-		return doco.RunServer(ctx, conn, c.ServerAddr, c.JWTSecret, doco.NewLogToStdOut("server", "0.0.1", false))
+		return doco.RunServer(ctx, conn, serverConfig, doco.NewLogToStdOut("server", c.LogLevel, c.LogJSON))
 	}, func(err error) {
 		fmt.Println(err)
 		cancel()
 	})
 	g.Add(func() error {
-		return doco.RunLoadBalancer(ctx, conn, c.LoadBalancerAddr, c.ServerAddr, c.RootPath, doco.NewLogToStdOut("lb", "0.0.1", false))
+		return doco.RunLoadBalancer(ctx, conn, c.LoadBalancerAddr, c.ServerAddr, c.APIPrefix, c.RootPath, c.GatewayErrorPage, c.HealthCheckInterval, tlsConfig, doco.NewLogToStdOut("lb", c.LogLevel, c.LogJSON))
 	}, func(err error) {
 		fmt.Println(err)
 		cancel()
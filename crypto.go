@@ -0,0 +1,208 @@
+package doco
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// segmentSize is the plaintext length of each independently sealed chunk, so
+// large blobs can be decrypted piece by piece while streaming
+const segmentSize = 64 << 10
+
+// finalSegmentAAD is authenticated with the last segment only, so a ciphertext with
+// whole segments cut off the end fails to open rather than reading as a shorter blob
+var finalSegmentAAD = []byte("final=1")
+
+// masterKeySize is the AES-256 key length in bytes
+const masterKeySize = 32
+
+// ErrInvalidMasterKey is returned when the master key isn't a hex encoded AES-256 key
+var ErrInvalidMasterKey = errors.New("master key must be 64 hex characters (32 bytes)")
+
+// ParseMasterKey decodes the hex master key and checks it is an AES-256 key
+func ParseMasterKey(masterKeyHex string) ([]byte, error) {
+	key, err := hex.DecodeString(masterKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMasterKey, err)
+	}
+	if len(key) != masterKeySize {
+		return nil, fmt.Errorf("%w: got %d bytes", ErrInvalidMasterKey, len(key))
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptBlob seals the plaintext with a fresh random nonce
+func encryptBlob(key, plaintext []byte) ([]byte, []byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypt: %w", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypt: %w", err)
+	}
+	return gcm.Seal(nil, nonce, plaintext, nil), nonce, nil
+}
+
+// decryptBlob opens ciphertext sealed by encryptBlob
+func decryptBlob(key, ciphertext, nonce []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// segmentNonce derives the nonce for segment i from the blob's base nonce
+func segmentNonce(base []byte, i int64) []byte {
+	nonce := make([]byte, len(base))
+	copy(nonce, base)
+	tail := nonce[len(nonce)-8:]
+	binary.BigEndian.PutUint64(tail, binary.BigEndian.Uint64(tail)^uint64(i))
+	return nonce
+}
+
+// encryptSegments seals the plaintext in segmentSize chunks under one random base nonce,
+// the last with finalSegmentAAD. Empty input still produces a single sealed segment so
+// it is authenticated.
+func encryptSegments(key, plaintext []byte) ([]byte, []byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypt: %w", err)
+	}
+	base := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encrypt: %w", err)
+	}
+	ciphertext := make([]byte, 0, len(plaintext)+(len(plaintext)/segmentSize+1)*gcm.Overhead())
+	for i := int64(0); ; i++ {
+		end := len(plaintext)
+		if end > segmentSize {
+			end = segmentSize
+		}
+		var aad []byte
+		if end == len(plaintext) {
+			aad = finalSegmentAAD
+		}
+		ciphertext = gcm.Seal(ciphertext, segmentNonce(base, i), plaintext[:end], aad)
+		plaintext = plaintext[end:]
+		if len(plaintext) == 0 {
+			break
+		}
+	}
+	return ciphertext, base, nil
+}
+
+// segmentReader decrypts a segmented ciphertext on demand, one segment at a time
+type segmentReader struct {
+	gcm      cipher.AEAD
+	base     []byte
+	segSize  int64
+	src      io.ReaderAt
+	size     int64
+	segments int64
+	// final is set for ciphertexts whose last segment was sealed with finalSegmentAAD
+	final bool
+
+	pos    int64
+	cur    []byte
+	curIdx int64
+}
+
+// newSegmentReader reads the plaintext of a ciphertext of ciphertextSize bytes
+// sealed by encryptSegments with the given segment size, final unless it was
+// sealed before the last segment had its own AAD
+func newSegmentReader(key, base []byte, segSize int64, final bool, src io.ReaderAt, ciphertextSize int64) (*segmentReader, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	sealed := segSize + int64(gcm.Overhead())
+	segments := (ciphertextSize + sealed - 1) / sealed
+	if segments == 0 {
+		return nil, errors.New("decrypt: empty ciphertext")
+	}
+	return &segmentReader{
+		gcm:      gcm,
+		base:     base,
+		segSize:  segSize,
+		src:      src,
+		size:     ciphertextSize - segments*int64(gcm.Overhead()),
+		segments: segments,
+		final:    final,
+		curIdx:   -1,
+	}, nil
+}
+
+func (s *segmentReader) load(idx int64) error {
+	sealed := s.segSize + int64(s.gcm.Overhead())
+	buf := make([]byte, sealed)
+	n, err := s.src.ReadAt(buf, idx*sealed)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	var aad []byte
+	if s.final && idx == s.segments-1 {
+		aad = finalSegmentAAD
+	}
+	plaintext, err := s.gcm.Open(buf[:0], segmentNonce(s.base, idx), buf[:n], aad)
+	if err != nil {
+		return fmt.Errorf("decrypt segment %d: %w", idx, err)
+	}
+	s.cur = plaintext
+	s.curIdx = idx
+	return nil
+}
+
+func (s *segmentReader) Read(p []byte) (int, error) {
+	if s.pos >= s.size {
+		return 0, io.EOF
+	}
+	idx := s.pos / s.segSize
+	if idx != s.curIdx {
+		err := s.load(idx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, s.cur[s.pos-idx*s.segSize:])
+	s.pos += int64(n)
+	return n, nil
+}
+
+func (s *segmentReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.pos
+	case io.SeekEnd:
+		offset += s.size
+	default:
+		return 0, errors.New("seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("seek: negative position")
+	}
+	s.pos = offset
+	return offset, nil
+}
@@ -0,0 +1,122 @@
+package doco
+
+import (
+	"bytes"
+	"doco/db"
+	"errors"
+	"io/ioutil"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, masterKeySize)
+}
+
+func TestSegmentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"empty", 0},
+		{"one byte", 1},
+		{"just under a segment", segmentSize - 1},
+		{"one segment", segmentSize},
+		{"just over a segment", segmentSize + 1},
+		{"several segments", 3*segmentSize + 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext := bytes.Repeat([]byte("doco"), tt.size/4+1)[:tt.size]
+			ciphertext, nonce, err := encryptSegments(testKey(1), plaintext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sr, err := newSegmentReader(testKey(1), nonce, segmentSize, true, bytes.NewReader(ciphertext), int64(len(ciphertext)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadAll(sr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Fatalf("got %d bytes back, want %d", len(got), len(plaintext))
+			}
+		})
+	}
+}
+
+func TestOpenSegmentsDetectsTampering(t *testing.T) {
+	plaintext := bytes.Repeat([]byte("x"), 3*segmentSize+7)
+	ciphertext, nonce, err := encryptSegments(testKey(1), plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed := segmentSize + 16
+	flipped := append([]byte{}, ciphertext...)
+	flipped[10] ^= 1
+
+	tests := []struct {
+		name       string
+		key        []byte
+		ciphertext []byte
+		final      bool
+	}{
+		{"last segment dropped", testKey(1), ciphertext[:3*sealed], true},
+		{"cut mid segment", testKey(1), ciphertext[:2*sealed+100], true},
+		{"byte flipped", testKey(1), flipped, true},
+		{"wrong key", testKey(2), ciphertext, true},
+		{"legacy blob cut short", testKey(1), ciphertext[:3*sealed], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob := &db.Blob{
+				FileName:      "x.bin",
+				FileSizeBytes: int64(len(plaintext)),
+				Nonce:         nonce,
+				SegmentSize:   segmentSize,
+				SealedFinal:   tt.final,
+			}
+			sr, err := openSegments(tt.key, blob, bytes.NewReader(tt.ciphertext), int64(len(tt.ciphertext)))
+			if err == nil {
+				_, err = ioutil.ReadAll(sr)
+			}
+			if err == nil {
+				t.Fatal("tampered ciphertext read without error")
+			}
+		})
+	}
+
+	t.Run("truncation is reported before anything is read", func(t *testing.T) {
+		blob := &db.Blob{FileName: "x.bin", FileSizeBytes: int64(len(plaintext)), Nonce: nonce, SegmentSize: segmentSize, SealedFinal: true}
+		_, err := openSegments(testKey(1), blob, bytes.NewReader(ciphertext[:3*sealed]), int64(3*sealed))
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("got %v, want %v", err, ErrChecksumMismatch)
+		}
+	})
+}
+
+func TestParseMasterKey(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		ok   bool
+	}{
+		{"32 bytes", "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", true},
+		{"upper case", "000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F", true},
+		{"too short", "0001", false},
+		{"not hex", "zz0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMasterKey(tt.hex)
+			if (err == nil) != tt.ok {
+				t.Fatalf("got %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidMasterKey) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidMasterKey)
+			}
+		})
+	}
+}
@@ -1,43 +1,174 @@
 package doco
 
 import (
+	"bytes"
+	"context"
+	"database/sql"
 	"doco/bindata"
+	"doco/db"
+	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	migrate_bindata "github.com/golang-migrate/migrate/v4/source/go_bindata"
 	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"go.uber.org/zap"
 )
 
-func randomAvatar() ([]byte, error) {
-	resp, err := http.Get("https://i.pravatar.cc/300")
+type inserter interface {
+	Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error
+}
+
+// insert adds o through exec, accepting ErrUnableToPopulate since the row is
+// written by then. Retrying would insert it twice, so instead reload, when not
+// nil, is called to fetch the ID and defaults back by some other unique key.
+func insert(ctx context.Context, exec boil.ContextExecutor, o inserter, reload func() error) error {
+	err := o.Insert(ctx, exec, boil.Infer())
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(err.Error(), ErrUnableToPopulate) {
+		return err
+	}
+	if reload == nil {
+		return nil
+	}
+	return reload()
+}
+
+// insertG is insert on the global database
+func insertG(ctx context.Context, o inserter, reload func() error) error {
+	return insert(ctx, boil.GetContextDB(), o, reload)
+}
+
+// avatarURL serves a random portrait on every request
+const avatarURL = "https://i.pravatar.cc/300"
+
+// avatarBackoff is the wait before the first retry, doubling after each failed attempt
+const avatarBackoff = 500 * time.Millisecond
+
+// randomAvatar downloads an avatar, retrying with exponential backoff
+func randomAvatar(opts SeedOptions) ([]byte, error) {
+	client := &http.Client{Timeout: opts.AvatarTimeout}
+	attempts := opts.AvatarAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	wait := avatarBackoff
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		var b []byte
+		b, err = fetchAvatar(client)
+		if err == nil {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("avatar after %d attempts: %w", attempts, err)
+}
+
+// placeholderAvatar is a deterministic solid colour 300x300 JPEG for seeding without network access
+func placeholderAvatar() ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 300))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{0x4a, 0x90, 0xd9, 0xff}}, image.Point{}, draw.Src)
+	buf := &bytes.Buffer{}
+	err := jpeg.Encode(buf, img, nil)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("avatar: %w", err)
 	}
-	b, err := ioutil.ReadAll(resp.Body)
+	return buf.Bytes(), nil
+}
+
+// avatar picks the placeholder or a downloaded avatar depending on opts
+func avatar(opts SeedOptions) ([]byte, error) {
+	if opts.OfflineAvatar {
+		return placeholderAvatar()
+	}
+	return randomAvatar(opts)
+}
+
+func fetchAvatar(client *http.Client) ([]byte, error) {
+	resp, err := client.Get(avatarURL)
 	if err != nil {
 		return nil, err
 	}
-	return b, err
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("avatar: unexpected status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// Supported database drivers, the names registered with database/sql
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// postgresMigrations is the bindata directory holding the Postgres dialect of each migration
+const postgresMigrations = "postgres/"
+
+// migrationNames lists the embedded migrations for the driver, relative to their directory
+func migrationNames(driver string) []string {
+	names := []string{}
+	for _, name := range bindata.AssetNames() {
+		switch {
+		case driver == DriverPostgres && strings.HasPrefix(name, postgresMigrations):
+			names = append(names, strings.TrimPrefix(name, postgresMigrations))
+		case driver != DriverPostgres && !strings.Contains(name, "/"):
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 func newMigrateInstance(conn *sqlx.DB) (*migrate.Migrate, error) {
-	s := migrate_bindata.Resource(bindata.AssetNames(),
+	driver := conn.DriverName()
+	dir := ""
+	if driver == DriverPostgres {
+		dir = postgresMigrations
+	}
+	s := migrate_bindata.Resource(migrationNames(driver),
 		func(name string) ([]byte, error) {
-			return bindata.Asset(name)
+			return bindata.Asset(dir + name)
 		})
 	d, err := migrate_bindata.WithInstance(s)
 	if err != nil {
 		return nil, fmt.Errorf("bindata instance: %w", err)
 	}
-	dbDriver, err := sqlite3.WithInstance(conn.DB, &sqlite3.Config{})
+	var dbDriver database.Driver
+	switch driver {
+	case DriverSQLite:
+		dbDriver, err = sqlite3.WithInstance(conn.DB, &sqlite3.Config{})
+	case DriverPostgres:
+		dbDriver, err = postgres.WithInstance(conn.DB, &postgres.Config{})
+	default:
+		err = fmt.Errorf("unsupported driver %q", driver)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("db instance: %w", err)
 	}
-	m, err := migrate.NewWithInstance("go-bindata", d, "sqlite", dbDriver)
+	m, err := migrate.NewWithInstance("go-bindata", d, driver, dbDriver)
 	if err != nil {
 		return nil, fmt.Errorf("migrate instance: %w", err)
 	}
@@ -54,6 +185,24 @@ func Migrate(conn *sqlx.DB) error {
 	}
 	return nil
 }
+
+// Steps migrates n versions forward, or back when n is negative
+func Steps(conn *sqlx.DB, n int) error {
+	m, err := newMigrateInstance(conn)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	err = m.Steps(n)
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	return nil
+}
+
+// Rollback reverts the last n applied migrations
+func Rollback(conn *sqlx.DB, n int) error {
+	return Steps(conn, -n)
+}
 func Drop(conn *sqlx.DB) error {
 	m, err := newMigrateInstance(conn)
 	if err != nil {
@@ -76,7 +225,321 @@ func Version(conn *sqlx.DB) (uint, bool, error) {
 	}
 	return v, d, nil
 }
-func Seed(masterKeyHex string) error {
 
+// migrationVersion parses the version a migration file name starts with
+func migrationVersion(name string) (uint, error) {
+	v, err := strconv.ParseUint(strings.SplitN(name, "_", 2)[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("migration %s: %w", name, err)
+	}
+	return uint(v), nil
+}
+
+// LatestVersion is the newest migration embedded in bindata for the driver
+func LatestVersion(driver string) (uint, error) {
+	var latest uint
+	for _, name := range migrationNames(driver) {
+		v, err := migrationVersion(name)
+		if err != nil {
+			return 0, err
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+// PendingMigration is a migration Migrate would apply
+type PendingMigration struct {
+	Version uint
+	Name    string
+	UpSQL   string
+}
+
+// Plan lists the migrations Migrate would apply, oldest first, without running
+// anything. They are read from the same bindata as newMigrateInstance uses.
+func Plan(conn *sqlx.DB) ([]*PendingMigration, error) {
+	current, dirty, err := Version(conn)
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("migrate: version %d is dirty, fix it before migrating", current)
+	}
+	driver := conn.DriverName()
+	dir := ""
+	if driver == DriverPostgres {
+		dir = postgresMigrations
+	}
+	pending := []*PendingMigration{}
+	for _, name := range migrationNames(driver) {
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		v, err := migrationVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		if v <= current {
+			continue
+		}
+		b, err := bindata.Asset(dir + name)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", name, err)
+		}
+		pending = append(pending, &PendingMigration{Version: v, Name: name, UpSQL: string(b)})
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+	return pending, nil
+}
+
+// MigrationStatus compares the applied schema version with the embedded migrations
+type MigrationStatus struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest"`
+	Pending bool `json:"pending"`
+}
+
+// Status reports the current migration state of the database
+func Status(conn *sqlx.DB) (*MigrationStatus, error) {
+	latest, err := LatestVersion(conn.DriverName())
+	if err != nil {
+		return nil, fmt.Errorf("migrate: %w", err)
+	}
+	v, d, err := Version(conn)
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+	return &MigrationStatus{
+		Version: v,
+		Dirty:   d,
+		Latest:  latest,
+		Pending: v < latest,
+	}, nil
+}
+
+// SeedOptions tune how Seed fetches sample content
+type SeedOptions struct {
+	AvatarTimeout  time.Duration
+	AvatarAttempts int
+	// OfflineAvatar generates a placeholder rather than downloading one
+	OfflineAvatar bool
+	// SkipAvatar leaves the avatar out altogether
+	SkipAvatar bool
+	// Count is how many blobs to generate on top of the samples, with a user
+	// for every seedBlobsPerUser of them
+	Count int
+	// RandomSeed makes generated blobs reproducible, the same seed and count give
+	// the same names, owners and contents. Zero picks one, which is logged.
+	RandomSeed int64
+}
+
+type seedBlob struct {
+	fileName string
+	mimeType string
+	file     func(opts SeedOptions) ([]byte, error)
+	owner    string
+}
+
+func staticFile(s string) func(opts SeedOptions) ([]byte, error) {
+	return func(opts SeedOptions) ([]byte, error) {
+		return []byte(s), nil
+	}
+}
+
+var seedBlobs = []seedBlob{
+	{"welcome.txt", "text/plain; charset=utf-8", staticFile("Welcome to doco.\n"), ""},
+	{"example.json", "application/json", staticFile(`{"project":"doco","documents":[]}`), ""},
+	{"example.csv", "text/csv", staticFile("name,sequence\nfirst,1\nsecond,2\n"), ""},
+	{"avatar.jpg", "image/jpeg", avatar, ""},
+}
+
+type seedUser struct {
+	username string
+	password string
+	admin    bool
+}
+
+// seedUsers are development logins, never seed a production database
+var seedUsers = []seedUser{
+	// an admin, the seeded blobs have no owner
+	{"doco", "doco", true},
+}
+
+// seedBlobsPerUser is how many generated blobs share an owner
+const seedBlobsPerUser = 10
+
+// seedMaxBytes bounds a generated blob's contents
+const seedMaxBytes = 64 << 10
+
+// seedWords make up generated contents
+var seedWords = strings.Fields("doco blob store archive document version share tag upload download trash restore export import schedule")
+
+// generatedFile makes up contents from seed when the blob is inserted, so a big
+// seed isn't held in memory and skipping a blob doesn't change the others
+func generatedFile(seed int64) func(opts SeedOptions) ([]byte, error) {
+	return func(opts SeedOptions) ([]byte, error) {
+		rnd := rand.New(rand.NewSource(seed))
+		buf := &bytes.Buffer{}
+		limit := 1 + rnd.Intn(seedMaxBytes)
+		for buf.Len() < limit {
+			buf.WriteString(seedWords[rnd.Intn(len(seedWords))])
+			buf.WriteByte(' ')
+		}
+		buf.WriteByte('\n')
+		return buf.Bytes(), nil
+	}
+}
+
+// generatedSeed returns count blobs and the users owning them, drawn from rnd
+func generatedSeed(rnd *rand.Rand, count int) ([]seedBlob, []seedUser) {
+	users := []seedUser{}
+	for i := 0; i < (count+seedBlobsPerUser-1)/seedBlobsPerUser; i++ {
+		users = append(users, seedUser{fmt.Sprintf("seed-user-%04d", i), seedPassword, false})
+	}
+	blobs := []seedBlob{}
+	for i := 0; i < count; i++ {
+		blobs = append(blobs, seedBlob{
+			fileName: fmt.Sprintf("seed-%06d.txt", i),
+			mimeType: "text/plain; charset=utf-8",
+			file:     generatedFile(rnd.Int63()),
+			owner:    users[rnd.Intn(len(users))].username,
+		})
+	}
+	return blobs, users
+}
+
+// seedPassword is every generated user's password
+const seedPassword = "doco"
+
+// Seed migrates the database if needed and inserts sample blobs and users, skipping any
+// already present. Everything is inserted in one transaction, so a failed seed leaves
+// nothing behind. Stores outside the database are cleaned up by hand on failure.
+func Seed(conn *sqlx.DB, store BlobStore, masterKeyHex string, opts SeedOptions, log *zap.SugaredLogger) error {
+	masterKey, err := ParseMasterKey(masterKeyHex)
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+	if opts.RandomSeed == 0 {
+		opts.RandomSeed = time.Now().UnixNano()
+	}
+	log.Infow("seed", "count", opts.Count, "random_seed", opts.RandomSeed)
+	blobs := []seedBlob{}
+	for _, s := range seedBlobs {
+		if opts.SkipAvatar && s.fileName == "avatar.jpg" {
+			continue
+		}
+		blobs = append(blobs, s)
+	}
+	generatedBlobs, generatedUsers := generatedSeed(rand.New(rand.NewSource(opts.RandomSeed)), opts.Count)
+	blobs = append(blobs, generatedBlobs...)
+	err = Migrate(conn)
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("seed: %w", err)
+	}
+
+	ctx := context.Background()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+	stored := []string{}
+	rollback := func(err error) error {
+		tx.Rollback()
+		for _, key := range stored {
+			delErr := store.Delete(ctx, key)
+			if delErr != nil {
+				log.Errorw("seed cleanup", "key", key, "err", delErr)
+			}
+		}
+		return err
+	}
+
+	usersCreated := 0
+	// bcrypt is slow on purpose, users with the same password share a hash so big seeds stay quick
+	hashes := map[string]string{}
+	userIDs := map[string]null.Int64{}
+	for _, u := range append(seedUsers, generatedUsers...) {
+		user, err := db.Users(qm.Select(db.UserColumns.ID), db.UserWhere.Username.EQ(u.username)).One(ctx, tx)
+		if err == nil {
+			userIDs[u.username] = user.ID
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return rollback(fmt.Errorf("seed: %w", err))
+		}
+		hash, ok := hashes[u.password]
+		if !ok {
+			hash, err = HashPassword(u.password)
+			if err != nil {
+				return rollback(fmt.Errorf("seed %s: %w", u.username, err))
+			}
+			hashes[u.password] = hash
+		}
+		user = &db.User{Username: u.username, PasswordHash: hash, Admin: u.admin}
+		err = insert(ctx, tx, user, func() error {
+			inserted, err := db.Users(qm.Select(db.UserColumns.ID), db.UserWhere.Username.EQ(user.Username)).One(ctx, tx)
+			if err != nil {
+				return err
+			}
+			user.ID = inserted.ID
+			return nil
+		})
+		if err != nil {
+			return rollback(fmt.Errorf("seed %s: %w", u.username, err))
+		}
+		userIDs[u.username] = user.ID
+		usersCreated++
+	}
+
+	created, skipped := 0, 0
+	for _, s := range blobs {
+		exists, err := db.Blobs(db.BlobWhere.FileName.EQ(s.fileName)).Exists(ctx, tx)
+		if err != nil {
+			return rollback(fmt.Errorf("seed: %w", err))
+		}
+		if exists {
+			skipped++
+			continue
+		}
+		b, err := s.file(opts)
+		if err != nil {
+			return rollback(fmt.Errorf("seed %s: %w", s.fileName, err))
+		}
+		blob, ciphertext, err := newBlob(masterKey, s.fileName, s.mimeType, b)
+		if err != nil {
+			return rollback(fmt.Errorf("seed %s: %w", s.fileName, err))
+		}
+		blob.OwnerID = userIDs[s.owner]
+		err = insert(ctx, tx, blob, func() error {
+			inserted, err := db.Blobs(qm.Select(db.BlobColumns.ID), db.BlobWhere.FileName.EQ(blob.FileName)).One(ctx, tx)
+			if err != nil {
+				return err
+			}
+			blob.ID = inserted.ID
+			return nil
+		})
+		if err != nil {
+			return rollback(fmt.Errorf("seed %s: %w", s.fileName, err))
+		}
+		if p, ok := store.(txPutter); ok {
+			err = p.PutTx(ctx, tx, blobKey(blob), bytes.NewReader(ciphertext))
+		} else {
+			err = store.Put(ctx, blobKey(blob), bytes.NewReader(ciphertext))
+			stored = append(stored, blobKey(blob))
+		}
+		if err != nil {
+			return rollback(fmt.Errorf("seed %s: %w", s.fileName, err))
+		}
+		created++
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return rollback(fmt.Errorf("seed: %w", err))
+	}
+	log.Infow("seeded", "blobs_created", created, "blobs_skipped", skipped, "users_created", usersCreated)
 	return nil
 }
@@ -0,0 +1,1099 @@
+// Code generated by SQLBoiler 3.5.0 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+	"github.com/volatiletech/sqlboiler/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/strmangle"
+)
+
+// BlobVersion is an object representing the database table.
+type BlobVersion struct {
+	ID            null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
+	BlobID        int64      `boil:"blob_id" json:"blob_id" toml:"blob_id" yaml:"blob_id"`
+	Version       int64      `boil:"version" json:"version" toml:"version" yaml:"version"`
+	MimeType      string     `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
+	FileSizeBytes int64      `boil:"file_size_bytes" json:"file_size_bytes" toml:"file_size_bytes" yaml:"file_size_bytes"`
+	Extension     string     `boil:"extension" json:"extension" toml:"extension" yaml:"extension"`
+	File          []byte     `boil:"file" json:"file" toml:"file" yaml:"file"`
+	Nonce         []byte     `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	Compressed    bool       `boil:"compressed" json:"compressed" toml:"compressed" yaml:"compressed"`
+	SegmentSize   int64      `boil:"segment_size" json:"segment_size" toml:"segment_size" yaml:"segment_size"`
+	Checksum      string     `boil:"checksum" json:"checksum" toml:"checksum" yaml:"checksum"`
+	CreatedAt     time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	SealedFinal   bool       `boil:"sealed_final" json:"sealed_final" toml:"sealed_final" yaml:"sealed_final"`
+
+	R *blobVersionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L blobVersionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var BlobVersionColumns = struct {
+	ID            string
+	BlobID        string
+	Version       string
+	MimeType      string
+	FileSizeBytes string
+	Extension     string
+	File          string
+	Nonce         string
+	Compressed    string
+	SegmentSize   string
+	Checksum      string
+	CreatedAt     string
+	SealedFinal   string
+}{
+	ID:            "id",
+	BlobID:        "blob_id",
+	Version:       "version",
+	MimeType:      "mime_type",
+	FileSizeBytes: "file_size_bytes",
+	Extension:     "extension",
+	File:          "file",
+	Nonce:         "nonce",
+	Compressed:    "compressed",
+	SegmentSize:   "segment_size",
+	Checksum:      "checksum",
+	CreatedAt:     "created_at",
+	SealedFinal:   "sealed_final",
+}
+
+// Generated where
+
+type whereHelper__byte struct{ field string }
+
+func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var BlobVersionWhere = struct {
+	ID            whereHelpernull_Int64
+	BlobID        whereHelperint64
+	Version       whereHelperint64
+	MimeType      whereHelperstring
+	FileSizeBytes whereHelperint64
+	Extension     whereHelperstring
+	File          whereHelper__byte
+	Nonce         whereHelper__byte
+	Compressed    whereHelperbool
+	SegmentSize   whereHelperint64
+	Checksum      whereHelperstring
+	CreatedAt     whereHelpertime_Time
+	SealedFinal   whereHelperbool
+}{
+	ID:            whereHelpernull_Int64{field: "\"blob_versions\".\"id\""},
+	BlobID:        whereHelperint64{field: "\"blob_versions\".\"blob_id\""},
+	Version:       whereHelperint64{field: "\"blob_versions\".\"version\""},
+	MimeType:      whereHelperstring{field: "\"blob_versions\".\"mime_type\""},
+	FileSizeBytes: whereHelperint64{field: "\"blob_versions\".\"file_size_bytes\""},
+	Extension:     whereHelperstring{field: "\"blob_versions\".\"extension\""},
+	File:          whereHelper__byte{field: "\"blob_versions\".\"file\""},
+	Nonce:         whereHelper__byte{field: "\"blob_versions\".\"nonce\""},
+	Compressed:    whereHelperbool{field: "\"blob_versions\".\"compressed\""},
+	SegmentSize:   whereHelperint64{field: "\"blob_versions\".\"segment_size\""},
+	Checksum:      whereHelperstring{field: "\"blob_versions\".\"checksum\""},
+	CreatedAt:     whereHelpertime_Time{field: "\"blob_versions\".\"created_at\""},
+	SealedFinal:   whereHelperbool{field: "\"blob_versions\".\"sealed_final\""},
+}
+
+// BlobVersionRels is where relationship names are stored.
+var BlobVersionRels = struct {
+	Blob string
+}{
+	Blob: "Blob",
+}
+
+// blobVersionR is where relationships are stored.
+type blobVersionR struct {
+	Blob *Blob
+}
+
+// NewStruct creates a new relationship struct
+func (*blobVersionR) NewStruct() *blobVersionR {
+	return &blobVersionR{}
+}
+
+// blobVersionL is where Load methods for each relationship are stored.
+type blobVersionL struct{}
+
+var (
+	blobVersionAllColumns            = []string{"id", "blob_id", "version", "mime_type", "file_size_bytes", "extension", "file", "nonce", "compressed", "segment_size", "checksum", "created_at", "sealed_final"}
+	blobVersionColumnsWithoutDefault = []string{"blob_id", "version", "mime_type", "file_size_bytes", "extension"}
+	blobVersionColumnsWithDefault    = []string{"id", "file", "nonce", "compressed", "segment_size", "checksum", "created_at", "sealed_final"}
+	blobVersionPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// BlobVersionSlice is an alias for a slice of pointers to BlobVersion.
+	// This should generally be used opposed to []BlobVersion.
+	BlobVersionSlice []*BlobVersion
+	// BlobVersionHook is the signature for custom BlobVersion hook methods
+	BlobVersionHook func(context.Context, boil.ContextExecutor, *BlobVersion) error
+
+	blobVersionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	blobVersionType                 = reflect.TypeOf(&BlobVersion{})
+	blobVersionMapping              = queries.MakeStructMapping(blobVersionType)
+	blobVersionPrimaryKeyMapping, _ = queries.BindMapping(blobVersionType, blobVersionMapping, blobVersionPrimaryKeyColumns)
+	blobVersionInsertCacheMut       sync.RWMutex
+	blobVersionInsertCache          = make(map[string]insertCache)
+	blobVersionUpdateCacheMut       sync.RWMutex
+	blobVersionUpdateCache          = make(map[string]updateCache)
+	blobVersionUpsertCacheMut       sync.RWMutex
+	blobVersionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var blobVersionBeforeInsertHooks []BlobVersionHook
+var blobVersionBeforeUpdateHooks []BlobVersionHook
+var blobVersionBeforeDeleteHooks []BlobVersionHook
+var blobVersionBeforeUpsertHooks []BlobVersionHook
+
+var blobVersionAfterInsertHooks []BlobVersionHook
+var blobVersionAfterSelectHooks []BlobVersionHook
+var blobVersionAfterUpdateHooks []BlobVersionHook
+var blobVersionAfterDeleteHooks []BlobVersionHook
+var blobVersionAfterUpsertHooks []BlobVersionHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *BlobVersion) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *BlobVersion) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *BlobVersion) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *BlobVersion) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *BlobVersion) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *BlobVersion) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *BlobVersion) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *BlobVersion) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *BlobVersion) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range blobVersionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddBlobVersionHook registers your hook function for all future operations.
+func AddBlobVersionHook(hookPoint boil.HookPoint, blobVersionHook BlobVersionHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		blobVersionBeforeInsertHooks = append(blobVersionBeforeInsertHooks, blobVersionHook)
+	case boil.BeforeUpdateHook:
+		blobVersionBeforeUpdateHooks = append(blobVersionBeforeUpdateHooks, blobVersionHook)
+	case boil.BeforeDeleteHook:
+		blobVersionBeforeDeleteHooks = append(blobVersionBeforeDeleteHooks, blobVersionHook)
+	case boil.BeforeUpsertHook:
+		blobVersionBeforeUpsertHooks = append(blobVersionBeforeUpsertHooks, blobVersionHook)
+	case boil.AfterInsertHook:
+		blobVersionAfterInsertHooks = append(blobVersionAfterInsertHooks, blobVersionHook)
+	case boil.AfterSelectHook:
+		blobVersionAfterSelectHooks = append(blobVersionAfterSelectHooks, blobVersionHook)
+	case boil.AfterUpdateHook:
+		blobVersionAfterUpdateHooks = append(blobVersionAfterUpdateHooks, blobVersionHook)
+	case boil.AfterDeleteHook:
+		blobVersionAfterDeleteHooks = append(blobVersionAfterDeleteHooks, blobVersionHook)
+	case boil.AfterUpsertHook:
+		blobVersionAfterUpsertHooks = append(blobVersionAfterUpsertHooks, blobVersionHook)
+	}
+}
+
+// OneG returns a single blobVersion record from the query using the global executor.
+func (q blobVersionQuery) OneG(ctx context.Context) (*BlobVersion, error) {
+	return q.One(ctx, boil.GetContextDB())
+}
+
+// One returns a single blobVersion record from the query.
+func (q blobVersionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*BlobVersion, error) {
+	o := &BlobVersion{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "db: failed to execute a one query for blob_versions")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// AllG returns all BlobVersion records from the query using the global executor.
+func (q blobVersionQuery) AllG(ctx context.Context) (BlobVersionSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
+}
+
+// All returns all BlobVersion records from the query.
+func (q blobVersionQuery) All(ctx context.Context, exec boil.ContextExecutor) (BlobVersionSlice, error) {
+	var o []*BlobVersion
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "db: failed to assign all query results to BlobVersion slice")
+	}
+
+	if len(blobVersionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// CountG returns the count of all BlobVersion records in the query, and panics on error.
+func (q blobVersionQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
+}
+
+// Count returns the count of all BlobVersion records in the query.
+func (q blobVersionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: failed to count blob_versions rows")
+	}
+
+	return count, nil
+}
+
+// ExistsG checks if the row exists in the table, and panics on error.
+func (q blobVersionQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
+}
+
+// Exists checks if the row exists in the table.
+func (q blobVersionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "db: failed to check if blob_versions exists")
+	}
+
+	return count > 0, nil
+}
+
+// Blob pointed to by the foreign key.
+func (o *BlobVersion) Blob(mods ...qm.QueryMod) blobQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.BlobID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Blobs(queryMods...)
+	queries.SetFrom(query.Query, "\"blobs\"")
+
+	return query
+}
+
+// LoadBlob allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (blobVersionL) LoadBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlobVersion interface{}, mods queries.Applicator) error {
+	var slice []*BlobVersion
+	var object *BlobVersion
+
+	if singular {
+		object = maybeBlobVersion.(*BlobVersion)
+	} else {
+		slice = *maybeBlobVersion.(*[]*BlobVersion)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobVersionR{}
+		}
+		if !queries.IsNil(object.BlobID) {
+			args = append(args, object.BlobID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobVersionR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.BlobID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.BlobID) {
+				args = append(args, obj.BlobID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`blobs`), qm.WhereIn(`blobs.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Blob")
+	}
+
+	var resultSlice []*Blob
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Blob")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for blobs")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blobs")
+	}
+
+	if len(blobVersionAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Blob = foreign
+		if foreign.R == nil {
+			foreign.R = &blobR{}
+		}
+		foreign.R.BlobVersion = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.BlobID, foreign.ID) {
+				local.R.Blob = foreign
+				if foreign.R == nil {
+					foreign.R = &blobR{}
+				}
+				foreign.R.BlobVersion = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetBlobG of the blobVersion to the related item.
+// Sets o.R.Blob to related.
+// Adds o to related.R.BlobVersion.
+// Uses the global database handle.
+func (o *BlobVersion) SetBlobG(ctx context.Context, insert bool, related *Blob) error {
+	return o.SetBlob(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetBlob of the blobVersion to the related item.
+// Sets o.R.Blob to related.
+// Adds o to related.R.BlobVersion.
+func (o *BlobVersion) SetBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Blob) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"blob_versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"blob_id"}),
+		strmangle.WhereClause("\"", "\"", 0, blobVersionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.BlobID, related.ID)
+	if o.R == nil {
+		o.R = &blobVersionR{
+			Blob: related,
+		}
+	} else {
+		o.R.Blob = related
+	}
+
+	if related.R == nil {
+		related.R = &blobR{
+			BlobVersion: o,
+		}
+	} else {
+		related.R.BlobVersion = o
+	}
+
+	return nil
+}
+
+// BlobVersions retrieves all the records using an executor.
+func BlobVersions(mods ...qm.QueryMod) blobVersionQuery {
+	mods = append(mods, qm.From("\"blob_versions\""))
+	return blobVersionQuery{NewQuery(mods...)}
+}
+
+// FindBlobVersionG retrieves a single record by ID.
+func FindBlobVersionG(ctx context.Context, iD null.Int64, selectCols ...string) (*BlobVersion, error) {
+	return FindBlobVersion(ctx, boil.GetContextDB(), iD, selectCols...)
+}
+
+// FindBlobVersion retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindBlobVersion(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*BlobVersion, error) {
+	blobVersionObj := &BlobVersion{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"blob_versions\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, blobVersionObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "db: unable to select from blob_versions")
+	}
+
+	return blobVersionObj, nil
+}
+
+// InsertG a single record. See Insert for whitelist behavior description.
+func (o *BlobVersion) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *BlobVersion) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("db: no blob_versions provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(blobVersionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	blobVersionInsertCacheMut.RLock()
+	cache, cached := blobVersionInsertCache[key]
+	blobVersionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			blobVersionAllColumns,
+			blobVersionColumnsWithDefault,
+			blobVersionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(blobVersionType, blobVersionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(blobVersionType, blobVersionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"blob_versions\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"blob_versions\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"blob_versions\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, blobVersionPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "db: unable to insert into blob_versions")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "db: unable to populate default values for blob_versions")
+	}
+
+CacheNoHooks:
+	if !cached {
+		blobVersionInsertCacheMut.Lock()
+		blobVersionInsertCache[key] = cache
+		blobVersionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// UpdateG a single BlobVersion record using the global executor.
+// See Update for more documentation.
+func (o *BlobVersion) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
+}
+
+// Update uses an executor to update the BlobVersion.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *BlobVersion) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	blobVersionUpdateCacheMut.RLock()
+	cache, cached := blobVersionUpdateCache[key]
+	blobVersionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			blobVersionAllColumns,
+			blobVersionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("db: unable to update blob_versions, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"blob_versions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, blobVersionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(blobVersionType, blobVersionMapping, append(wl, blobVersionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to update blob_versions row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: failed to get rows affected by update for blob_versions")
+	}
+
+	if !cached {
+		blobVersionUpdateCacheMut.Lock()
+		blobVersionUpdateCache[key] = cache
+		blobVersionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (q blobVersionQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q blobVersionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to update all for blob_versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to retrieve rows affected for blob_versions")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAllG updates all rows with the specified column values.
+func (o BlobVersionSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o BlobVersionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("db: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobVersionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"blob_versions\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, blobVersionPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to update all in blobVersion slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to retrieve rows affected all in update all blobVersion")
+	}
+	return rowsAff, nil
+}
+
+// DeleteG deletes a single BlobVersion record.
+// DeleteG will match against the primary key column to find the record to delete.
+func (o *BlobVersion) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
+}
+
+// Delete deletes a single BlobVersion record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *BlobVersion) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("db: no BlobVersion provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), blobVersionPrimaryKeyMapping)
+	sql := "DELETE FROM \"blob_versions\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to delete from blob_versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for blob_versions")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q blobVersionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("db: no blobVersionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to delete all from blob_versions")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: failed to get rows affected by deleteall for blob_versions")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAllG deletes all rows in the slice.
+func (o BlobVersionSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o BlobVersionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(blobVersionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobVersionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"blob_versions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, blobVersionPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db: unable to delete all from blobVersion slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "db: failed to get rows affected by deleteall for blob_versions")
+	}
+
+	if len(blobVersionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// ReloadG refetches the object from the database using the primary keys.
+func (o *BlobVersion) ReloadG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("db: no BlobVersion provided for reload")
+	}
+
+	return o.Reload(ctx, boil.GetContextDB())
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *BlobVersion) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindBlobVersion(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAllG refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *BlobVersionSlice) ReloadAllG(ctx context.Context) error {
+	if o == nil {
+		return errors.New("db: empty BlobVersionSlice provided for reload all")
+	}
+
+	return o.ReloadAll(ctx, boil.GetContextDB())
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *BlobVersionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := BlobVersionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), blobVersionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"blob_versions\".* FROM \"blob_versions\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, blobVersionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "db: unable to reload all in BlobVersionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// BlobVersionExistsG checks if the BlobVersion row exists.
+func BlobVersionExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return BlobVersionExists(ctx, boil.GetContextDB(), iD)
+}
+
+// BlobVersionExists checks if the BlobVersion row exists.
+func BlobVersionExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"blob_versions\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "db: unable to check if blob_versions exists")
+	}
+
+	return exists, nil
+}
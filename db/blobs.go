@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -22,196 +23,140 @@ import (
 
 // Blob is an object representing the database table.
 type Blob struct {
-	ID            null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
-	FileName      string     `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
-	MimeType      string     `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
-	FileSizeBytes int64      `boil:"file_size_bytes" json:"file_size_bytes" toml:"file_size_bytes" yaml:"file_size_bytes"`
-	EXTENSION     string     `boil:"EXTENSION" json:"EXTENSION" toml:"EXTENSION" yaml:"EXTENSION"`
-	File          []byte     `boil:"file" json:"file" toml:"file" yaml:"file"`
-	Views         null.Int64 `boil:"views" json:"views,omitempty" toml:"views" yaml:"views,omitempty"`
-	Archived      bool       `boil:"archived" json:"archived" toml:"archived" yaml:"archived"`
-	ArchivedAt    null.Time  `boil:"archived_at" json:"archived_at,omitempty" toml:"archived_at" yaml:"archived_at,omitempty"`
-	UpdatedAt     time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	CreatedAt     time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	ID               null.Int64 `boil:"id" json:"id,omitempty" toml:"id" yaml:"id,omitempty"`
+	FileName         string     `boil:"file_name" json:"file_name" toml:"file_name" yaml:"file_name"`
+	MimeType         string     `boil:"mime_type" json:"mime_type" toml:"mime_type" yaml:"mime_type"`
+	FileSizeBytes    int64      `boil:"file_size_bytes" json:"file_size_bytes" toml:"file_size_bytes" yaml:"file_size_bytes"`
+	EXTENSION        string     `boil:"EXTENSION" json:"EXTENSION" toml:"EXTENSION" yaml:"EXTENSION"`
+	File             []byte     `boil:"file" json:"file" toml:"file" yaml:"file"`
+	Views            null.Int64 `boil:"views" json:"views,omitempty" toml:"views" yaml:"views,omitempty"`
+	Archived         bool       `boil:"archived" json:"archived" toml:"archived" yaml:"archived"`
+	ArchivedAt       null.Time  `boil:"archived_at" json:"archived_at,omitempty" toml:"archived_at" yaml:"archived_at,omitempty"`
+	UpdatedAt        time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	CreatedAt        time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Checksum         string     `boil:"checksum" json:"checksum" toml:"checksum" yaml:"checksum"`
+	Nonce            []byte     `boil:"nonce" json:"nonce" toml:"nonce" yaml:"nonce"`
+	Compressed       bool       `boil:"compressed" json:"compressed" toml:"compressed" yaml:"compressed"`
+	SegmentSize      int64      `boil:"segment_size" json:"segment_size" toml:"segment_size" yaml:"segment_size"`
+	OwnerID          null.Int64 `boil:"owner_id" json:"owner_id,omitempty" toml:"owner_id" yaml:"owner_id,omitempty"`
+	ExpiresAt        null.Time  `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	DeletedAt        null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	SealedFinal      bool       `boil:"sealed_final" json:"sealed_final" toml:"sealed_final" yaml:"sealed_final"`
+	ContentUpdatedAt null.Time  `boil:"content_updated_at" json:"content_updated_at,omitempty" toml:"content_updated_at" yaml:"content_updated_at,omitempty"`
 
 	R *blobR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L blobL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var BlobColumns = struct {
-	ID            string
-	FileName      string
-	MimeType      string
-	FileSizeBytes string
-	EXTENSION     string
-	File          string
-	Views         string
-	Archived      string
-	ArchivedAt    string
-	UpdatedAt     string
-	CreatedAt     string
+	ID               string
+	FileName         string
+	MimeType         string
+	FileSizeBytes    string
+	EXTENSION        string
+	File             string
+	Views            string
+	Archived         string
+	ArchivedAt       string
+	UpdatedAt        string
+	CreatedAt        string
+	Checksum         string
+	Nonce            string
+	Compressed       string
+	SegmentSize      string
+	OwnerID          string
+	ExpiresAt        string
+	DeletedAt        string
+	SealedFinal      string
+	ContentUpdatedAt string
 }{
-	ID:            "id",
-	FileName:      "file_name",
-	MimeType:      "mime_type",
-	FileSizeBytes: "file_size_bytes",
-	EXTENSION:     "EXTENSION",
-	File:          "file",
-	Views:         "views",
-	Archived:      "archived",
-	ArchivedAt:    "archived_at",
-	UpdatedAt:     "updated_at",
-	CreatedAt:     "created_at",
+	ID:               "id",
+	FileName:         "file_name",
+	MimeType:         "mime_type",
+	FileSizeBytes:    "file_size_bytes",
+	EXTENSION:        "EXTENSION",
+	File:             "file",
+	Views:            "views",
+	Archived:         "archived",
+	ArchivedAt:       "archived_at",
+	UpdatedAt:        "updated_at",
+	CreatedAt:        "created_at",
+	Checksum:         "checksum",
+	Nonce:            "nonce",
+	Compressed:       "compressed",
+	SegmentSize:      "segment_size",
+	OwnerID:          "owner_id",
+	ExpiresAt:        "expires_at",
+	DeletedAt:        "deleted_at",
+	SealedFinal:      "sealed_final",
+	ContentUpdatedAt: "content_updated_at",
 }
 
 // Generated where
 
-type whereHelpernull_Int64 struct{ field string }
-
-func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelper__byte struct{ field string }
-
-func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var BlobWhere = struct {
-	ID            whereHelpernull_Int64
-	FileName      whereHelperstring
-	MimeType      whereHelperstring
-	FileSizeBytes whereHelperint64
-	EXTENSION     whereHelperstring
-	File          whereHelper__byte
-	Views         whereHelpernull_Int64
-	Archived      whereHelperbool
-	ArchivedAt    whereHelpernull_Time
-	UpdatedAt     whereHelpertime_Time
-	CreatedAt     whereHelpertime_Time
+	ID               whereHelpernull_Int64
+	FileName         whereHelperstring
+	MimeType         whereHelperstring
+	FileSizeBytes    whereHelperint64
+	EXTENSION        whereHelperstring
+	File             whereHelper__byte
+	Views            whereHelpernull_Int64
+	Archived         whereHelperbool
+	ArchivedAt       whereHelpernull_Time
+	UpdatedAt        whereHelpertime_Time
+	CreatedAt        whereHelpertime_Time
+	Checksum         whereHelperstring
+	Nonce            whereHelper__byte
+	Compressed       whereHelperbool
+	SegmentSize      whereHelperint64
+	OwnerID          whereHelpernull_Int64
+	ExpiresAt        whereHelpernull_Time
+	DeletedAt        whereHelpernull_Time
+	SealedFinal      whereHelperbool
+	ContentUpdatedAt whereHelpernull_Time
 }{
-	ID:            whereHelpernull_Int64{field: "\"blobs\".\"id\""},
-	FileName:      whereHelperstring{field: "\"blobs\".\"file_name\""},
-	MimeType:      whereHelperstring{field: "\"blobs\".\"mime_type\""},
-	FileSizeBytes: whereHelperint64{field: "\"blobs\".\"file_size_bytes\""},
-	EXTENSION:     whereHelperstring{field: "\"blobs\".\"EXTENSION\""},
-	File:          whereHelper__byte{field: "\"blobs\".\"file\""},
-	Views:         whereHelpernull_Int64{field: "\"blobs\".\"views\""},
-	Archived:      whereHelperbool{field: "\"blobs\".\"archived\""},
-	ArchivedAt:    whereHelpernull_Time{field: "\"blobs\".\"archived_at\""},
-	UpdatedAt:     whereHelpertime_Time{field: "\"blobs\".\"updated_at\""},
-	CreatedAt:     whereHelpertime_Time{field: "\"blobs\".\"created_at\""},
+	ID:               whereHelpernull_Int64{field: "\"blobs\".\"id\""},
+	FileName:         whereHelperstring{field: "\"blobs\".\"file_name\""},
+	MimeType:         whereHelperstring{field: "\"blobs\".\"mime_type\""},
+	FileSizeBytes:    whereHelperint64{field: "\"blobs\".\"file_size_bytes\""},
+	EXTENSION:        whereHelperstring{field: "\"blobs\".\"EXTENSION\""},
+	File:             whereHelper__byte{field: "\"blobs\".\"file\""},
+	Views:            whereHelpernull_Int64{field: "\"blobs\".\"views\""},
+	Archived:         whereHelperbool{field: "\"blobs\".\"archived\""},
+	ArchivedAt:       whereHelpernull_Time{field: "\"blobs\".\"archived_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"blobs\".\"updated_at\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"blobs\".\"created_at\""},
+	Checksum:         whereHelperstring{field: "\"blobs\".\"checksum\""},
+	Nonce:            whereHelper__byte{field: "\"blobs\".\"nonce\""},
+	Compressed:       whereHelperbool{field: "\"blobs\".\"compressed\""},
+	SegmentSize:      whereHelperint64{field: "\"blobs\".\"segment_size\""},
+	OwnerID:          whereHelpernull_Int64{field: "\"blobs\".\"owner_id\""},
+	ExpiresAt:        whereHelpernull_Time{field: "\"blobs\".\"expires_at\""},
+	DeletedAt:        whereHelpernull_Time{field: "\"blobs\".\"deleted_at\""},
+	SealedFinal:      whereHelperbool{field: "\"blobs\".\"sealed_final\""},
+	ContentUpdatedAt: whereHelpernull_Time{field: "\"blobs\".\"content_updated_at\""},
 }
 
 // BlobRels is where relationship names are stored.
 var BlobRels = struct {
+	Owner         string
+	BlobVersion   string
 	DocumentsBlob string
+	Tags          string
 }{
+	Owner:         "Owner",
+	BlobVersion:   "BlobVersion",
 	DocumentsBlob: "DocumentsBlob",
+	Tags:          "Tags",
 }
 
 // blobR is where relationships are stored.
 type blobR struct {
+	Owner         *User
+	BlobVersion   *BlobVersion
 	DocumentsBlob *DocumentsBlob
+	Tags          TagSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -223,9 +168,9 @@ func (*blobR) NewStruct() *blobR {
 type blobL struct{}
 
 var (
-	blobAllColumns            = []string{"id", "file_name", "mime_type", "file_size_bytes", "EXTENSION", "file", "views", "archived", "archived_at", "updated_at", "created_at"}
-	blobColumnsWithoutDefault = []string{"file_name", "mime_type", "file_size_bytes", "EXTENSION", "file", "archived_at"}
-	blobColumnsWithDefault    = []string{"id", "views", "archived", "updated_at", "created_at"}
+	blobAllColumns            = []string{"id", "file_name", "mime_type", "file_size_bytes", "EXTENSION", "file", "views", "archived", "archived_at", "updated_at", "created_at", "checksum", "nonce", "compressed", "segment_size", "owner_id", "expires_at", "deleted_at", "sealed_final", "content_updated_at"}
+	blobColumnsWithoutDefault = []string{"file_name", "mime_type", "file_size_bytes", "EXTENSION", "file", "archived_at", "owner_id", "expires_at", "deleted_at", "content_updated_at"}
+	blobColumnsWithDefault    = []string{"id", "views", "archived", "updated_at", "created_at", "checksum", "nonce", "compressed", "segment_size", "sealed_final"}
 	blobPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -234,7 +179,7 @@ type (
 	// This should generally be used opposed to []Blob.
 	BlobSlice []*Blob
 	// BlobHook is the signature for custom Blob hook methods
-	BlobHook func(boil.Executor, *Blob) error
+	BlobHook func(context.Context, boil.ContextExecutor, *Blob) error
 
 	blobQuery struct {
 		*queries.Query
@@ -274,9 +219,13 @@ var blobAfterDeleteHooks []BlobHook
 var blobAfterUpsertHooks []BlobHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Blob) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *Blob) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -285,9 +234,13 @@ func (o *Blob) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Blob) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *Blob) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -296,9 +249,13 @@ func (o *Blob) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Blob) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *Blob) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -307,9 +264,13 @@ func (o *Blob) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Blob) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *Blob) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -318,9 +279,13 @@ func (o *Blob) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Blob) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *Blob) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -329,9 +294,13 @@ func (o *Blob) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *Blob) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *Blob) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -340,9 +309,13 @@ func (o *Blob) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Blob) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *Blob) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -351,9 +324,13 @@ func (o *Blob) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Blob) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *Blob) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -362,9 +339,13 @@ func (o *Blob) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *Blob) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *Blob) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range blobAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -397,17 +378,17 @@ func AddBlobHook(hookPoint boil.HookPoint, blobHook BlobHook) {
 }
 
 // OneG returns a single blob record from the query using the global executor.
-func (q blobQuery) OneG() (*Blob, error) {
-	return q.One(boil.GetDB())
+func (q blobQuery) OneG(ctx context.Context) (*Blob, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single blob record from the query.
-func (q blobQuery) One(exec boil.Executor) (*Blob, error) {
+func (q blobQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Blob, error) {
 	o := &Blob{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -415,7 +396,7 @@ func (q blobQuery) One(exec boil.Executor) (*Blob, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for blobs")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -423,22 +404,22 @@ func (q blobQuery) One(exec boil.Executor) (*Blob, error) {
 }
 
 // AllG returns all Blob records from the query using the global executor.
-func (q blobQuery) AllG() (BlobSlice, error) {
-	return q.All(boil.GetDB())
+func (q blobQuery) AllG(ctx context.Context) (BlobSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all Blob records from the query.
-func (q blobQuery) All(exec boil.Executor) (BlobSlice, error) {
+func (q blobQuery) All(ctx context.Context, exec boil.ContextExecutor) (BlobSlice, error) {
 	var o []*Blob
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to Blob slice")
 	}
 
 	if len(blobAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -448,18 +429,18 @@ func (q blobQuery) All(exec boil.Executor) (BlobSlice, error) {
 }
 
 // CountG returns the count of all Blob records in the query, and panics on error.
-func (q blobQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q blobQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all Blob records in the query.
-func (q blobQuery) Count(exec boil.Executor) (int64, error) {
+func (q blobQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count blobs rows")
 	}
@@ -468,19 +449,19 @@ func (q blobQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q blobQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q blobQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q blobQuery) Exists(exec boil.Executor) (bool, error) {
+func (q blobQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if blobs exists")
 	}
@@ -488,6 +469,34 @@ func (q blobQuery) Exists(exec boil.Executor) (bool, error) {
 	return count > 0, nil
 }
 
+// Owner pointed to by the foreign key.
+func (o *Blob) Owner(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.OwnerID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Users(queryMods...)
+	queries.SetFrom(query.Query, "\"users\"")
+
+	return query
+}
+
+// BlobVersion pointed to by the foreign key.
+func (o *Blob) BlobVersion(mods ...qm.QueryMod) blobVersionQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"blob_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := BlobVersions(queryMods...)
+	queries.SetFrom(query.Query, "\"blob_versions\"")
+
+	return query
+}
+
 // DocumentsBlob pointed to by the foreign key.
 func (o *Blob) DocumentsBlob(mods ...qm.QueryMod) documentsBlobQuery {
 	queryMods := []qm.QueryMod{
@@ -502,9 +511,234 @@ func (o *Blob) DocumentsBlob(mods ...qm.QueryMod) documentsBlobQuery {
 	return query
 }
 
+// Tags retrieves all the tag's Tags with an executor.
+func (o *Blob) Tags(mods ...qm.QueryMod) tagQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"blobs_tags\" on \"tags\".\"id\" = \"blobs_tags\".\"tag_id\""),
+		qm.Where("\"blobs_tags\".\"blob_id\"=?", o.ID),
+	)
+
+	query := Tags(queryMods...)
+	queries.SetFrom(query.Query, "\"tags\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"tags\".*"})
+	}
+
+	return query
+}
+
+// LoadOwner allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (blobL) LoadOwner(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+	var slice []*Blob
+	var object *Blob
+
+	if singular {
+		object = maybeBlob.(*Blob)
+	} else {
+		slice = *maybeBlob.(*[]*Blob)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobR{}
+		}
+		if !queries.IsNil(object.OwnerID) {
+			args = append(args, object.OwnerID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.OwnerID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.OwnerID) {
+				args = append(args, obj.OwnerID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`users`), qm.WhereIn(`users.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for users")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for users")
+	}
+
+	if len(blobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Owner = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.OwnerBlobs = append(foreign.R.OwnerBlobs, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.OwnerID, foreign.ID) {
+				local.R.Owner = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.OwnerBlobs = append(foreign.R.OwnerBlobs, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadBlobVersion allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (blobL) LoadBlobVersion(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+	var slice []*Blob
+	var object *Blob
+
+	if singular {
+		object = maybeBlob.(*Blob)
+	} else {
+		slice = *maybeBlob.(*[]*Blob)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`blob_versions`), qm.WhereIn(`blob_versions.blob_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load BlobVersion")
+	}
+
+	var resultSlice []*BlobVersion
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice BlobVersion")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for blob_versions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blob_versions")
+	}
+
+	if len(blobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.BlobVersion = foreign
+		if foreign.R == nil {
+			foreign.R = &blobVersionR{}
+		}
+		foreign.R.Blob = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.ID, foreign.BlobID) {
+				local.R.BlobVersion = foreign
+				if foreign.R == nil {
+					foreign.R = &blobVersionR{}
+				}
+				foreign.R.Blob = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadDocumentsBlob allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (blobL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+func (blobL) LoadDocumentsBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
 	var slice []*Blob
 	var object *Blob
 
@@ -546,7 +780,7 @@ func (blobL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeBlob interfa
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load DocumentsBlob")
 	}
@@ -565,7 +799,7 @@ func (blobL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeBlob interfa
 
 	if len(blobAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -600,24 +834,291 @@ func (blobL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeBlob interfa
 	return nil
 }
 
+// LoadTags allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (blobL) LoadTags(ctx context.Context, e boil.ContextExecutor, singular bool, maybeBlob interface{}, mods queries.Applicator) error {
+	var slice []*Blob
+	var object *Blob
+
+	if singular {
+		object = maybeBlob.(*Blob)
+	} else {
+		slice = *maybeBlob.(*[]*Blob)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &blobR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &blobR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"tags\".*, \"a\".\"blob_id\""),
+		qm.From("\"tags\""),
+		qm.InnerJoin("\"blobs_tags\" as \"a\" on \"tags\".\"id\" = \"a\".\"tag_id\""),
+		qm.WhereIn("\"a\".\"blob_id\" in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load tags")
+	}
+
+	var resultSlice []*Tag
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Tag)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.Name, &one.Archived, &one.ArchivedAt, &one.UpdatedAt, &one.CreatedAt, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for tags")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice tags")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on tags")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for tags")
+	}
+
+	if len(tagAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Tags = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tagR{}
+			}
+			foreign.R.Blobs = append(foreign.R.Blobs, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Tags = append(local.R.Tags, foreign)
+				if foreign.R == nil {
+					foreign.R = &tagR{}
+				}
+				foreign.R.Blobs = append(foreign.R.Blobs, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetOwnerG of the blob to the related item.
+// Sets o.R.Owner to related.
+// Adds o to related.R.OwnerBlobs.
+// Uses the global database handle.
+func (o *Blob) SetOwnerG(ctx context.Context, insert bool, related *User) error {
+	return o.SetOwner(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetOwner of the blob to the related item.
+// Sets o.R.Owner to related.
+// Adds o to related.R.OwnerBlobs.
+func (o *Blob) SetOwner(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"blobs\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"owner_id"}),
+		strmangle.WhereClause("\"", "\"", 0, blobPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.OwnerID, related.ID)
+	if o.R == nil {
+		o.R = &blobR{
+			Owner: related,
+		}
+	} else {
+		o.R.Owner = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			OwnerBlobs: BlobSlice{o},
+		}
+	} else {
+		related.R.OwnerBlobs = append(related.R.OwnerBlobs, o)
+	}
+
+	return nil
+}
+
+// RemoveOwnerG relationship.
+// Sets o.R.Owner to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+// Uses the global database handle.
+func (o *Blob) RemoveOwnerG(ctx context.Context, related *User) error {
+	return o.RemoveOwner(ctx, boil.GetContextDB(), related)
+}
+
+// RemoveOwner relationship.
+// Sets o.R.Owner to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Blob) RemoveOwner(ctx context.Context, exec boil.ContextExecutor, related *User) error {
+	var err error
+
+	queries.SetScanner(&o.OwnerID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("owner_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.R.Owner = nil
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.OwnerBlobs {
+		if queries.Equal(o.OwnerID, ri.OwnerID) {
+			continue
+		}
+
+		ln := len(related.R.OwnerBlobs)
+		if ln > 1 && i < ln-1 {
+			related.R.OwnerBlobs[i] = related.R.OwnerBlobs[ln-1]
+		}
+		related.R.OwnerBlobs = related.R.OwnerBlobs[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetBlobVersionG of the blob to the related item.
+// Sets o.R.BlobVersion to related.
+// Adds o to related.R.Blob.
+// Uses the global database handle.
+func (o *Blob) SetBlobVersionG(ctx context.Context, insert bool, related *BlobVersion) error {
+	return o.SetBlobVersion(ctx, boil.GetContextDB(), insert, related)
+}
+
+// SetBlobVersion of the blob to the related item.
+// Sets o.R.BlobVersion to related.
+// Adds o to related.R.Blob.
+func (o *Blob) SetBlobVersion(ctx context.Context, exec boil.ContextExecutor, insert bool, related *BlobVersion) error {
+	var err error
+
+	if insert {
+		queries.Assign(&related.BlobID, o.ID)
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"blob_versions\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, []string{"blob_id"}),
+			strmangle.WhereClause("\"", "\"", 0, blobVersionPrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, updateQuery)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		queries.Assign(&related.BlobID, o.ID)
+	}
+
+	if o.R == nil {
+		o.R = &blobR{
+			BlobVersion: related,
+		}
+	} else {
+		o.R.BlobVersion = related
+	}
+
+	if related.R == nil {
+		related.R = &blobVersionR{
+			Blob: o,
+		}
+	} else {
+		related.R.Blob = o
+	}
+	return nil
+}
+
 // SetDocumentsBlobG of the blob to the related item.
 // Sets o.R.DocumentsBlob to related.
 // Adds o to related.R.Blob.
 // Uses the global database handle.
-func (o *Blob) SetDocumentsBlobG(insert bool, related *DocumentsBlob) error {
-	return o.SetDocumentsBlob(boil.GetDB(), insert, related)
+func (o *Blob) SetDocumentsBlobG(ctx context.Context, insert bool, related *DocumentsBlob) error {
+	return o.SetDocumentsBlob(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetDocumentsBlob of the blob to the related item.
 // Sets o.R.DocumentsBlob to related.
 // Adds o to related.R.Blob.
-func (o *Blob) SetDocumentsBlob(exec boil.Executor, insert bool, related *DocumentsBlob) error {
+func (o *Blob) SetDocumentsBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *DocumentsBlob) error {
 	var err error
 
 	if insert {
 		queries.Assign(&related.BlobID, o.ID)
 
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	} else {
@@ -633,7 +1134,7 @@ func (o *Blob) SetDocumentsBlob(exec boil.Executor, insert bool, related *Docume
 			fmt.Fprintln(boil.DebugWriter, values)
 		}
 
-		if _, err = exec.Exec(updateQuery, values...); err != nil {
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 			return errors.Wrap(err, "failed to update foreign table")
 		}
 
@@ -658,6 +1159,174 @@ func (o *Blob) SetDocumentsBlob(exec boil.Executor, insert bool, related *Docume
 	return nil
 }
 
+// AddTagsG adds the given related objects to the existing relationships
+// of the blob, optionally inserting them as new records.
+// Appends related to o.R.Tags.
+// Sets related.R.Blobs appropriately.
+// Uses the global database handle.
+func (o *Blob) AddTagsG(ctx context.Context, insert bool, related ...*Tag) error {
+	return o.AddTags(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddTags adds the given related objects to the existing relationships
+// of the blob, optionally inserting them as new records.
+// Appends related to o.R.Tags.
+// Sets related.R.Blobs appropriately.
+func (o *Blob) AddTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"blobs_tags\" (\"blob_id\", \"tag_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &blobR{
+			Tags: related,
+		}
+	} else {
+		o.R.Tags = append(o.R.Tags, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tagR{
+				Blobs: BlobSlice{o},
+			}
+		} else {
+			rel.R.Blobs = append(rel.R.Blobs, o)
+		}
+	}
+	return nil
+}
+
+// SetTagsG removes all previously related items of the
+// blob replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Blobs's Tags accordingly.
+// Replaces o.R.Tags with related.
+// Sets related.R.Blobs's Tags accordingly.
+// Uses the global database handle.
+func (o *Blob) SetTagsG(ctx context.Context, insert bool, related ...*Tag) error {
+	return o.SetTags(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// SetTags removes all previously related items of the
+// blob replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Blobs's Tags accordingly.
+// Replaces o.R.Tags with related.
+// Sets related.R.Blobs's Tags accordingly.
+func (o *Blob) SetTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
+	query := "delete from \"blobs_tags\" where \"blob_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeTagsFromBlobsSlice(o, related)
+	if o.R != nil {
+		o.R.Tags = nil
+	}
+	return o.AddTags(ctx, exec, insert, related...)
+}
+
+// RemoveTagsG relationships from objects passed in.
+// Removes related items from R.Tags (uses pointer comparison, removal does not keep order)
+// Sets related.R.Blobs.
+// Uses the global database handle.
+func (o *Blob) RemoveTagsG(ctx context.Context, related ...*Tag) error {
+	return o.RemoveTags(ctx, boil.GetContextDB(), related...)
+}
+
+// RemoveTags relationships from objects passed in.
+// Removes related items from R.Tags (uses pointer comparison, removal does not keep order)
+// Sets related.R.Blobs.
+func (o *Blob) RemoveTags(ctx context.Context, exec boil.ContextExecutor, related ...*Tag) error {
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"blobs_tags\" where \"blob_id\" = ? and \"tag_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeTagsFromBlobsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Tags {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Tags)
+			if ln > 1 && i < ln-1 {
+				o.R.Tags[i] = o.R.Tags[ln-1]
+			}
+			o.R.Tags = o.R.Tags[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeTagsFromBlobsSlice(o *Blob, related []*Tag) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Blobs {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Blobs)
+			if ln > 1 && i < ln-1 {
+				rel.R.Blobs[i] = rel.R.Blobs[ln-1]
+			}
+			rel.R.Blobs = rel.R.Blobs[:ln-1]
+			break
+		}
+	}
+}
+
 // Blobs retrieves all the records using an executor.
 func Blobs(mods ...qm.QueryMod) blobQuery {
 	mods = append(mods, qm.From("\"blobs\""))
@@ -665,13 +1334,13 @@ func Blobs(mods ...qm.QueryMod) blobQuery {
 }
 
 // FindBlobG retrieves a single record by ID.
-func FindBlobG(iD null.Int64, selectCols ...string) (*Blob, error) {
-	return FindBlob(boil.GetDB(), iD, selectCols...)
+func FindBlobG(ctx context.Context, iD null.Int64, selectCols ...string) (*Blob, error) {
+	return FindBlob(ctx, boil.GetContextDB(), iD, selectCols...)
 }
 
 // FindBlob retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindBlob(exec boil.Executor, iD null.Int64, selectCols ...string) (*Blob, error) {
+func FindBlob(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Blob, error) {
 	blobObj := &Blob{}
 
 	sel := "*"
@@ -684,7 +1353,7 @@ func FindBlob(exec boil.Executor, iD null.Int64, selectCols ...string) (*Blob, e
 
 	q := queries.Raw(query, iD)
 
-	err := q.Bind(nil, exec, blobObj)
+	err := q.Bind(ctx, exec, blobObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -696,28 +1365,30 @@ func FindBlob(exec boil.Executor, iD null.Int64, selectCols ...string) (*Blob, e
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *Blob) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *Blob) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *Blob) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *Blob) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no blobs provided for insertion")
 	}
 
 	var err error
-	currTime := time.Now().In(boil.GetLocation())
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
-	}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
 	}
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -767,7 +1438,7 @@ func (o *Blob) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into blobs")
@@ -788,7 +1459,7 @@ func (o *Blob) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for blobs")
 	}
@@ -800,25 +1471,27 @@ CacheNoHooks:
 		blobInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single Blob record using the global executor.
 // See Update for more documentation.
-func (o *Blob) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *Blob) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the Blob.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *Blob) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
-	currTime := time.Now().In(boil.GetLocation())
+func (o *Blob) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	o.UpdatedAt = currTime
+		o.UpdatedAt = currTime
+	}
 
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -857,7 +1530,7 @@ func (o *Blob) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update blobs row")
 	}
@@ -873,19 +1546,19 @@ func (o *Blob) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
 		blobUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q blobQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q blobQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q blobQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q blobQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for blobs")
 	}
@@ -899,12 +1572,12 @@ func (q blobQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o BlobSlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o BlobSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o BlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o BlobSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -939,7 +1612,7 @@ func (o BlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in blob slice")
 	}
@@ -953,18 +1626,18 @@ func (o BlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 
 // DeleteG deletes a single Blob record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Blob) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *Blob) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single Blob record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Blob) Delete(exec boil.Executor) (int64, error) {
+func (o *Blob) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no Blob provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -976,7 +1649,7 @@ func (o *Blob) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from blobs")
 	}
@@ -986,7 +1659,7 @@ func (o *Blob) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for blobs")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -994,14 +1667,14 @@ func (o *Blob) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q blobQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q blobQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no blobQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from blobs")
 	}
@@ -1015,19 +1688,19 @@ func (q blobQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o BlobSlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o BlobSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o BlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o BlobSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(blobBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1047,7 +1720,7 @@ func (o BlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from blob slice")
 	}
@@ -1059,7 +1732,7 @@ func (o BlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(blobAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1069,18 +1742,18 @@ func (o BlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *Blob) ReloadG() error {
+func (o *Blob) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no Blob provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *Blob) Reload(exec boil.Executor) error {
-	ret, err := FindBlob(exec, o.ID)
+func (o *Blob) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindBlob(ctx, exec, o.ID)
 	if err != nil {
 		return err
 	}
@@ -1091,17 +1764,17 @@ func (o *Blob) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *BlobSlice) ReloadAllG() error {
+func (o *BlobSlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty BlobSlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *BlobSlice) ReloadAll(exec boil.Executor) error {
+func (o *BlobSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -1118,7 +1791,7 @@ func (o *BlobSlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in BlobSlice")
 	}
@@ -1129,12 +1802,12 @@ func (o *BlobSlice) ReloadAll(exec boil.Executor) error {
 }
 
 // BlobExistsG checks if the Blob row exists.
-func BlobExistsG(iD null.Int64) (bool, error) {
-	return BlobExists(boil.GetDB(), iD)
+func BlobExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return BlobExists(ctx, boil.GetContextDB(), iD)
 }
 
 // BlobExists checks if the Blob row exists.
-func BlobExists(exec boil.Executor, iD null.Int64) (bool, error) {
+func BlobExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"blobs\" where \"id\"=? limit 1)"
 
@@ -1143,7 +1816,7 @@ func BlobExists(exec boil.Executor, iD null.Int64) (bool, error) {
 		fmt.Fprintln(boil.DebugWriter, iD)
 	}
 
-	row := exec.QueryRow(sql, iD)
+	row := exec.QueryRowContext(ctx, sql, iD)
 
 	err := row.Scan(&exists)
 	if err != nil {
@@ -4,19 +4,33 @@
 package db
 
 var TableNames = struct {
-	Blobs          string
-	Documents      string
-	DocumentsBlobs string
-	DocumentsTags  string
-	Projects       string
-	Tags           string
-	Taxonomies     string
+	APIKeys         string
+	AuditLog        string
+	BlobVersions    string
+	Blobs           string
+	BlobsTags       string
+	Documents       string
+	DocumentsBlobs  string
+	DocumentsTags   string
+	IdempotencyKeys string
+	Projects        string
+	Sessions        string
+	Tags            string
+	Taxonomies      string
+	Users           string
 }{
-	Blobs:          "blobs",
-	Documents:      "documents",
-	DocumentsBlobs: "documents_blobs",
-	DocumentsTags:  "documents_tags",
-	Projects:       "projects",
-	Tags:           "tags",
-	Taxonomies:     "taxonomies",
+	APIKeys:         "api_keys",
+	AuditLog:        "audit_log",
+	BlobVersions:    "blob_versions",
+	Blobs:           "blobs",
+	BlobsTags:       "blobs_tags",
+	Documents:       "documents",
+	DocumentsBlobs:  "documents_blobs",
+	DocumentsTags:   "documents_tags",
+	IdempotencyKeys: "idempotency_keys",
+	Projects:        "projects",
+	Sessions:        "sessions",
+	Tags:            "tags",
+	Taxonomies:      "taxonomies",
+	Users:           "users",
 }
@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -118,7 +119,7 @@ type (
 	// This should generally be used opposed to []Document.
 	DocumentSlice []*Document
 	// DocumentHook is the signature for custom Document hook methods
-	DocumentHook func(boil.Executor, *Document) error
+	DocumentHook func(context.Context, boil.ContextExecutor, *Document) error
 
 	documentQuery struct {
 		*queries.Query
@@ -158,9 +159,13 @@ var documentAfterDeleteHooks []DocumentHook
 var documentAfterUpsertHooks []DocumentHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Document) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *Document) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -169,9 +174,13 @@ func (o *Document) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Document) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *Document) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -180,9 +189,13 @@ func (o *Document) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Document) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *Document) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -191,9 +204,13 @@ func (o *Document) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Document) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *Document) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -202,9 +219,13 @@ func (o *Document) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Document) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *Document) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -213,9 +234,13 @@ func (o *Document) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *Document) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *Document) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -224,9 +249,13 @@ func (o *Document) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Document) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *Document) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -235,9 +264,13 @@ func (o *Document) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Document) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *Document) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -246,9 +279,13 @@ func (o *Document) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *Document) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *Document) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -281,17 +318,17 @@ func AddDocumentHook(hookPoint boil.HookPoint, documentHook DocumentHook) {
 }
 
 // OneG returns a single document record from the query using the global executor.
-func (q documentQuery) OneG() (*Document, error) {
-	return q.One(boil.GetDB())
+func (q documentQuery) OneG(ctx context.Context) (*Document, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single document record from the query.
-func (q documentQuery) One(exec boil.Executor) (*Document, error) {
+func (q documentQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Document, error) {
 	o := &Document{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -299,7 +336,7 @@ func (q documentQuery) One(exec boil.Executor) (*Document, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for documents")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -307,22 +344,22 @@ func (q documentQuery) One(exec boil.Executor) (*Document, error) {
 }
 
 // AllG returns all Document records from the query using the global executor.
-func (q documentQuery) AllG() (DocumentSlice, error) {
-	return q.All(boil.GetDB())
+func (q documentQuery) AllG(ctx context.Context) (DocumentSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all Document records from the query.
-func (q documentQuery) All(exec boil.Executor) (DocumentSlice, error) {
+func (q documentQuery) All(ctx context.Context, exec boil.ContextExecutor) (DocumentSlice, error) {
 	var o []*Document
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to Document slice")
 	}
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -332,18 +369,18 @@ func (q documentQuery) All(exec boil.Executor) (DocumentSlice, error) {
 }
 
 // CountG returns the count of all Document records in the query, and panics on error.
-func (q documentQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q documentQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all Document records in the query.
-func (q documentQuery) Count(exec boil.Executor) (int64, error) {
+func (q documentQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count documents rows")
 	}
@@ -352,19 +389,19 @@ func (q documentQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q documentQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q documentQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q documentQuery) Exists(exec boil.Executor) (bool, error) {
+func (q documentQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if documents exists")
 	}
@@ -438,7 +475,7 @@ func (o *Document) Tags(mods ...qm.QueryMod) tagQuery {
 
 // LoadTaxonomy allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
-func (documentL) LoadTaxonomy(e boil.Executor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
+func (documentL) LoadTaxonomy(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
 	var slice []*Document
 	var object *Document
 
@@ -486,7 +523,7 @@ func (documentL) LoadTaxonomy(e boil.Executor, singular bool, maybeDocument inte
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load Taxonomy")
 	}
@@ -505,7 +542,7 @@ func (documentL) LoadTaxonomy(e boil.Executor, singular bool, maybeDocument inte
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -543,7 +580,7 @@ func (documentL) LoadTaxonomy(e boil.Executor, singular bool, maybeDocument inte
 
 // LoadProject allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
-func (documentL) LoadProject(e boil.Executor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
+func (documentL) LoadProject(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
 	var slice []*Document
 	var object *Document
 
@@ -591,7 +628,7 @@ func (documentL) LoadProject(e boil.Executor, singular bool, maybeDocument inter
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load Project")
 	}
@@ -610,7 +647,7 @@ func (documentL) LoadProject(e boil.Executor, singular bool, maybeDocument inter
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -648,7 +685,7 @@ func (documentL) LoadProject(e boil.Executor, singular bool, maybeDocument inter
 
 // LoadDocumentsBlob allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
-func (documentL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
+func (documentL) LoadDocumentsBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
 	var slice []*Document
 	var object *Document
 
@@ -690,7 +727,7 @@ func (documentL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeDocument
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load DocumentsBlob")
 	}
@@ -709,7 +746,7 @@ func (documentL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeDocument
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -746,7 +783,7 @@ func (documentL) LoadDocumentsBlob(e boil.Executor, singular bool, maybeDocument
 
 // LoadTags allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (documentL) LoadTags(e boil.Executor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
+func (documentL) LoadTags(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocument interface{}, mods queries.Applicator) error {
 	var slice []*Document
 	var object *Document
 
@@ -793,7 +830,7 @@ func (documentL) LoadTags(e boil.Executor, singular bool, maybeDocument interfac
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load tags")
 	}
@@ -826,7 +863,7 @@ func (documentL) LoadTags(e boil.Executor, singular bool, maybeDocument interfac
 
 	if len(tagAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -863,17 +900,17 @@ func (documentL) LoadTags(e boil.Executor, singular bool, maybeDocument interfac
 // Sets o.R.Taxonomy to related.
 // Adds o to related.R.Documents.
 // Uses the global database handle.
-func (o *Document) SetTaxonomyG(insert bool, related *Taxonomy) error {
-	return o.SetTaxonomy(boil.GetDB(), insert, related)
+func (o *Document) SetTaxonomyG(ctx context.Context, insert bool, related *Taxonomy) error {
+	return o.SetTaxonomy(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetTaxonomy of the document to the related item.
 // Sets o.R.Taxonomy to related.
 // Adds o to related.R.Documents.
-func (o *Document) SetTaxonomy(exec boil.Executor, insert bool, related *Taxonomy) error {
+func (o *Document) SetTaxonomy(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Taxonomy) error {
 	var err error
 	if insert {
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	}
@@ -890,7 +927,7 @@ func (o *Document) SetTaxonomy(exec boil.Executor, insert bool, related *Taxonom
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 		return errors.Wrap(err, "failed to update local table")
 	}
 
@@ -918,17 +955,17 @@ func (o *Document) SetTaxonomy(exec boil.Executor, insert bool, related *Taxonom
 // Sets o.R.Project to related.
 // Adds o to related.R.Documents.
 // Uses the global database handle.
-func (o *Document) SetProjectG(insert bool, related *Project) error {
-	return o.SetProject(boil.GetDB(), insert, related)
+func (o *Document) SetProjectG(ctx context.Context, insert bool, related *Project) error {
+	return o.SetProject(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetProject of the document to the related item.
 // Sets o.R.Project to related.
 // Adds o to related.R.Documents.
-func (o *Document) SetProject(exec boil.Executor, insert bool, related *Project) error {
+func (o *Document) SetProject(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Project) error {
 	var err error
 	if insert {
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	}
@@ -945,7 +982,7 @@ func (o *Document) SetProject(exec boil.Executor, insert bool, related *Project)
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 		return errors.Wrap(err, "failed to update local table")
 	}
 
@@ -973,20 +1010,20 @@ func (o *Document) SetProject(exec boil.Executor, insert bool, related *Project)
 // Sets o.R.DocumentsBlob to related.
 // Adds o to related.R.Document.
 // Uses the global database handle.
-func (o *Document) SetDocumentsBlobG(insert bool, related *DocumentsBlob) error {
-	return o.SetDocumentsBlob(boil.GetDB(), insert, related)
+func (o *Document) SetDocumentsBlobG(ctx context.Context, insert bool, related *DocumentsBlob) error {
+	return o.SetDocumentsBlob(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetDocumentsBlob of the document to the related item.
 // Sets o.R.DocumentsBlob to related.
 // Adds o to related.R.Document.
-func (o *Document) SetDocumentsBlob(exec boil.Executor, insert bool, related *DocumentsBlob) error {
+func (o *Document) SetDocumentsBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *DocumentsBlob) error {
 	var err error
 
 	if insert {
 		queries.Assign(&related.DocumentID, o.ID)
 
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	} else {
@@ -1002,7 +1039,7 @@ func (o *Document) SetDocumentsBlob(exec boil.Executor, insert bool, related *Do
 			fmt.Fprintln(boil.DebugWriter, values)
 		}
 
-		if _, err = exec.Exec(updateQuery, values...); err != nil {
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 			return errors.Wrap(err, "failed to update foreign table")
 		}
 
@@ -1032,19 +1069,19 @@ func (o *Document) SetDocumentsBlob(exec boil.Executor, insert bool, related *Do
 // Appends related to o.R.Tags.
 // Sets related.R.Documents appropriately.
 // Uses the global database handle.
-func (o *Document) AddTagsG(insert bool, related ...*Tag) error {
-	return o.AddTags(boil.GetDB(), insert, related...)
+func (o *Document) AddTagsG(ctx context.Context, insert bool, related ...*Tag) error {
+	return o.AddTags(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // AddTags adds the given related objects to the existing relationships
 // of the document, optionally inserting them as new records.
 // Appends related to o.R.Tags.
 // Sets related.R.Documents appropriately.
-func (o *Document) AddTags(exec boil.Executor, insert bool, related ...*Tag) error {
+func (o *Document) AddTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			if err = rel.Insert(exec, boil.Infer()); err != nil {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		}
@@ -1059,7 +1096,7 @@ func (o *Document) AddTags(exec boil.Executor, insert bool, related ...*Tag) err
 			fmt.Fprintln(boil.DebugWriter, values)
 		}
 
-		_, err = exec.Exec(query, values...)
+		_, err = exec.ExecContext(ctx, query, values...)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert into join table")
 		}
@@ -1091,8 +1128,8 @@ func (o *Document) AddTags(exec boil.Executor, insert bool, related ...*Tag) err
 // Replaces o.R.Tags with related.
 // Sets related.R.Documents's Tags accordingly.
 // Uses the global database handle.
-func (o *Document) SetTagsG(insert bool, related ...*Tag) error {
-	return o.SetTags(boil.GetDB(), insert, related...)
+func (o *Document) SetTagsG(ctx context.Context, insert bool, related ...*Tag) error {
+	return o.SetTags(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // SetTags removes all previously related items of the
@@ -1101,7 +1138,7 @@ func (o *Document) SetTagsG(insert bool, related ...*Tag) error {
 // Sets o.R.Documents's Tags accordingly.
 // Replaces o.R.Tags with related.
 // Sets related.R.Documents's Tags accordingly.
-func (o *Document) SetTags(exec boil.Executor, insert bool, related ...*Tag) error {
+func (o *Document) SetTags(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Tag) error {
 	query := "delete from \"documents_tags\" where \"document_id\" = ?"
 	values := []interface{}{o.ID}
 	if boil.DebugMode {
@@ -1109,7 +1146,7 @@ func (o *Document) SetTags(exec boil.Executor, insert bool, related ...*Tag) err
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	_, err := exec.Exec(query, values...)
+	_, err := exec.ExecContext(ctx, query, values...)
 	if err != nil {
 		return errors.Wrap(err, "failed to remove relationships before set")
 	}
@@ -1118,21 +1155,21 @@ func (o *Document) SetTags(exec boil.Executor, insert bool, related ...*Tag) err
 	if o.R != nil {
 		o.R.Tags = nil
 	}
-	return o.AddTags(exec, insert, related...)
+	return o.AddTags(ctx, exec, insert, related...)
 }
 
 // RemoveTagsG relationships from objects passed in.
 // Removes related items from R.Tags (uses pointer comparison, removal does not keep order)
 // Sets related.R.Documents.
 // Uses the global database handle.
-func (o *Document) RemoveTagsG(related ...*Tag) error {
-	return o.RemoveTags(boil.GetDB(), related...)
+func (o *Document) RemoveTagsG(ctx context.Context, related ...*Tag) error {
+	return o.RemoveTags(ctx, boil.GetContextDB(), related...)
 }
 
 // RemoveTags relationships from objects passed in.
 // Removes related items from R.Tags (uses pointer comparison, removal does not keep order)
 // Sets related.R.Documents.
-func (o *Document) RemoveTags(exec boil.Executor, related ...*Tag) error {
+func (o *Document) RemoveTags(ctx context.Context, exec boil.ContextExecutor, related ...*Tag) error {
 	var err error
 	query := fmt.Sprintf(
 		"delete from \"documents_tags\" where \"document_id\" = ? and \"tag_id\" in (%s)",
@@ -1148,7 +1185,7 @@ func (o *Document) RemoveTags(exec boil.Executor, related ...*Tag) error {
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	_, err = exec.Exec(query, values...)
+	_, err = exec.ExecContext(ctx, query, values...)
 	if err != nil {
 		return errors.Wrap(err, "failed to remove relationships before set")
 	}
@@ -1202,13 +1239,13 @@ func Documents(mods ...qm.QueryMod) documentQuery {
 }
 
 // FindDocumentG retrieves a single record by ID.
-func FindDocumentG(iD null.Int64, selectCols ...string) (*Document, error) {
-	return FindDocument(boil.GetDB(), iD, selectCols...)
+func FindDocumentG(ctx context.Context, iD null.Int64, selectCols ...string) (*Document, error) {
+	return FindDocument(ctx, boil.GetContextDB(), iD, selectCols...)
 }
 
 // FindDocument retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindDocument(exec boil.Executor, iD null.Int64, selectCols ...string) (*Document, error) {
+func FindDocument(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Document, error) {
 	documentObj := &Document{}
 
 	sel := "*"
@@ -1221,7 +1258,7 @@ func FindDocument(exec boil.Executor, iD null.Int64, selectCols ...string) (*Doc
 
 	q := queries.Raw(query, iD)
 
-	err := q.Bind(nil, exec, documentObj)
+	err := q.Bind(ctx, exec, documentObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -1233,28 +1270,30 @@ func FindDocument(exec boil.Executor, iD null.Int64, selectCols ...string) (*Doc
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *Document) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *Document) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *Document) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *Document) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no documents provided for insertion")
 	}
 
 	var err error
-	currTime := time.Now().In(boil.GetLocation())
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
-	}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
 	}
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -1304,7 +1343,7 @@ func (o *Document) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into documents")
@@ -1325,7 +1364,7 @@ func (o *Document) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for documents")
 	}
@@ -1337,25 +1376,27 @@ CacheNoHooks:
 		documentInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single Document record using the global executor.
 // See Update for more documentation.
-func (o *Document) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *Document) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the Document.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *Document) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
-	currTime := time.Now().In(boil.GetLocation())
+func (o *Document) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	o.UpdatedAt = currTime
+		o.UpdatedAt = currTime
+	}
 
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -1394,7 +1435,7 @@ func (o *Document) Update(exec boil.Executor, columns boil.Columns) (int64, erro
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update documents row")
 	}
@@ -1410,19 +1451,19 @@ func (o *Document) Update(exec boil.Executor, columns boil.Columns) (int64, erro
 		documentUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q documentQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q documentQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q documentQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q documentQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for documents")
 	}
@@ -1436,12 +1477,12 @@ func (q documentQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o DocumentSlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o DocumentSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o DocumentSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o DocumentSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -1476,7 +1517,7 @@ func (o DocumentSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in document slice")
 	}
@@ -1490,18 +1531,18 @@ func (o DocumentSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 
 // DeleteG deletes a single Document record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Document) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *Document) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single Document record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Document) Delete(exec boil.Executor) (int64, error) {
+func (o *Document) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no Document provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -1513,7 +1554,7 @@ func (o *Document) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from documents")
 	}
@@ -1523,7 +1564,7 @@ func (o *Document) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for documents")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -1531,14 +1572,14 @@ func (o *Document) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q documentQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q documentQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no documentQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from documents")
 	}
@@ -1552,19 +1593,19 @@ func (q documentQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o DocumentSlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o DocumentSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o DocumentSlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o DocumentSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(documentBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1584,7 +1625,7 @@ func (o DocumentSlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from document slice")
 	}
@@ -1596,7 +1637,7 @@ func (o DocumentSlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(documentAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1606,18 +1647,18 @@ func (o DocumentSlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *Document) ReloadG() error {
+func (o *Document) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no Document provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *Document) Reload(exec boil.Executor) error {
-	ret, err := FindDocument(exec, o.ID)
+func (o *Document) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDocument(ctx, exec, o.ID)
 	if err != nil {
 		return err
 	}
@@ -1628,17 +1669,17 @@ func (o *Document) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *DocumentSlice) ReloadAllG() error {
+func (o *DocumentSlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty DocumentSlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *DocumentSlice) ReloadAll(exec boil.Executor) error {
+func (o *DocumentSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -1655,7 +1696,7 @@ func (o *DocumentSlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in DocumentSlice")
 	}
@@ -1666,12 +1707,12 @@ func (o *DocumentSlice) ReloadAll(exec boil.Executor) error {
 }
 
 // DocumentExistsG checks if the Document row exists.
-func DocumentExistsG(iD null.Int64) (bool, error) {
-	return DocumentExists(boil.GetDB(), iD)
+func DocumentExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return DocumentExists(ctx, boil.GetContextDB(), iD)
 }
 
 // DocumentExists checks if the Document row exists.
-func DocumentExists(exec boil.Executor, iD null.Int64) (bool, error) {
+func DocumentExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"documents\" where \"id\"=? limit 1)"
 
@@ -1680,7 +1721,7 @@ func DocumentExists(exec boil.Executor, iD null.Int64) (bool, error) {
 		fmt.Fprintln(boil.DebugWriter, iD)
 	}
 
-	row := exec.QueryRow(sql, iD)
+	row := exec.QueryRowContext(ctx, sql, iD)
 
 	err := row.Scan(&exists)
 	if err != nil {
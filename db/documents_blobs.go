@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -86,7 +87,7 @@ type (
 	// This should generally be used opposed to []DocumentsBlob.
 	DocumentsBlobSlice []*DocumentsBlob
 	// DocumentsBlobHook is the signature for custom DocumentsBlob hook methods
-	DocumentsBlobHook func(boil.Executor, *DocumentsBlob) error
+	DocumentsBlobHook func(context.Context, boil.ContextExecutor, *DocumentsBlob) error
 
 	documentsBlobQuery struct {
 		*queries.Query
@@ -126,9 +127,13 @@ var documentsBlobAfterDeleteHooks []DocumentsBlobHook
 var documentsBlobAfterUpsertHooks []DocumentsBlobHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *DocumentsBlob) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -137,9 +142,13 @@ func (o *DocumentsBlob) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *DocumentsBlob) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -148,9 +157,13 @@ func (o *DocumentsBlob) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *DocumentsBlob) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -159,9 +172,13 @@ func (o *DocumentsBlob) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *DocumentsBlob) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -170,9 +187,13 @@ func (o *DocumentsBlob) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *DocumentsBlob) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -181,9 +202,13 @@ func (o *DocumentsBlob) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *DocumentsBlob) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -192,9 +217,13 @@ func (o *DocumentsBlob) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *DocumentsBlob) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -203,9 +232,13 @@ func (o *DocumentsBlob) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *DocumentsBlob) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -214,9 +247,13 @@ func (o *DocumentsBlob) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *DocumentsBlob) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *DocumentsBlob) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range documentsBlobAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -249,17 +286,17 @@ func AddDocumentsBlobHook(hookPoint boil.HookPoint, documentsBlobHook DocumentsB
 }
 
 // OneG returns a single documentsBlob record from the query using the global executor.
-func (q documentsBlobQuery) OneG() (*DocumentsBlob, error) {
-	return q.One(boil.GetDB())
+func (q documentsBlobQuery) OneG(ctx context.Context) (*DocumentsBlob, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single documentsBlob record from the query.
-func (q documentsBlobQuery) One(exec boil.Executor) (*DocumentsBlob, error) {
+func (q documentsBlobQuery) One(ctx context.Context, exec boil.ContextExecutor) (*DocumentsBlob, error) {
 	o := &DocumentsBlob{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -267,7 +304,7 @@ func (q documentsBlobQuery) One(exec boil.Executor) (*DocumentsBlob, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for documents_blobs")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -275,22 +312,22 @@ func (q documentsBlobQuery) One(exec boil.Executor) (*DocumentsBlob, error) {
 }
 
 // AllG returns all DocumentsBlob records from the query using the global executor.
-func (q documentsBlobQuery) AllG() (DocumentsBlobSlice, error) {
-	return q.All(boil.GetDB())
+func (q documentsBlobQuery) AllG(ctx context.Context) (DocumentsBlobSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all DocumentsBlob records from the query.
-func (q documentsBlobQuery) All(exec boil.Executor) (DocumentsBlobSlice, error) {
+func (q documentsBlobQuery) All(ctx context.Context, exec boil.ContextExecutor) (DocumentsBlobSlice, error) {
 	var o []*DocumentsBlob
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to DocumentsBlob slice")
 	}
 
 	if len(documentsBlobAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -300,18 +337,18 @@ func (q documentsBlobQuery) All(exec boil.Executor) (DocumentsBlobSlice, error)
 }
 
 // CountG returns the count of all DocumentsBlob records in the query, and panics on error.
-func (q documentsBlobQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q documentsBlobQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all DocumentsBlob records in the query.
-func (q documentsBlobQuery) Count(exec boil.Executor) (int64, error) {
+func (q documentsBlobQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count documents_blobs rows")
 	}
@@ -320,19 +357,19 @@ func (q documentsBlobQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q documentsBlobQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q documentsBlobQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q documentsBlobQuery) Exists(exec boil.Executor) (bool, error) {
+func (q documentsBlobQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if documents_blobs exists")
 	}
@@ -370,7 +407,7 @@ func (o *DocumentsBlob) Document(mods ...qm.QueryMod) documentQuery {
 
 // LoadBlob allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
-func (documentsBlobL) LoadBlob(e boil.Executor, singular bool, maybeDocumentsBlob interface{}, mods queries.Applicator) error {
+func (documentsBlobL) LoadBlob(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocumentsBlob interface{}, mods queries.Applicator) error {
 	var slice []*DocumentsBlob
 	var object *DocumentsBlob
 
@@ -418,7 +455,7 @@ func (documentsBlobL) LoadBlob(e boil.Executor, singular bool, maybeDocumentsBlo
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load Blob")
 	}
@@ -437,7 +474,7 @@ func (documentsBlobL) LoadBlob(e boil.Executor, singular bool, maybeDocumentsBlo
 
 	if len(documentsBlobAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -475,7 +512,7 @@ func (documentsBlobL) LoadBlob(e boil.Executor, singular bool, maybeDocumentsBlo
 
 // LoadDocument allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
-func (documentsBlobL) LoadDocument(e boil.Executor, singular bool, maybeDocumentsBlob interface{}, mods queries.Applicator) error {
+func (documentsBlobL) LoadDocument(ctx context.Context, e boil.ContextExecutor, singular bool, maybeDocumentsBlob interface{}, mods queries.Applicator) error {
 	var slice []*DocumentsBlob
 	var object *DocumentsBlob
 
@@ -523,7 +560,7 @@ func (documentsBlobL) LoadDocument(e boil.Executor, singular bool, maybeDocument
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load Document")
 	}
@@ -542,7 +579,7 @@ func (documentsBlobL) LoadDocument(e boil.Executor, singular bool, maybeDocument
 
 	if len(documentsBlobAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -582,17 +619,17 @@ func (documentsBlobL) LoadDocument(e boil.Executor, singular bool, maybeDocument
 // Sets o.R.Blob to related.
 // Adds o to related.R.DocumentsBlob.
 // Uses the global database handle.
-func (o *DocumentsBlob) SetBlobG(insert bool, related *Blob) error {
-	return o.SetBlob(boil.GetDB(), insert, related)
+func (o *DocumentsBlob) SetBlobG(ctx context.Context, insert bool, related *Blob) error {
+	return o.SetBlob(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetBlob of the documentsBlob to the related item.
 // Sets o.R.Blob to related.
 // Adds o to related.R.DocumentsBlob.
-func (o *DocumentsBlob) SetBlob(exec boil.Executor, insert bool, related *Blob) error {
+func (o *DocumentsBlob) SetBlob(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Blob) error {
 	var err error
 	if insert {
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	}
@@ -609,7 +646,7 @@ func (o *DocumentsBlob) SetBlob(exec boil.Executor, insert bool, related *Blob)
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 		return errors.Wrap(err, "failed to update local table")
 	}
 
@@ -637,17 +674,17 @@ func (o *DocumentsBlob) SetBlob(exec boil.Executor, insert bool, related *Blob)
 // Sets o.R.Document to related.
 // Adds o to related.R.DocumentsBlob.
 // Uses the global database handle.
-func (o *DocumentsBlob) SetDocumentG(insert bool, related *Document) error {
-	return o.SetDocument(boil.GetDB(), insert, related)
+func (o *DocumentsBlob) SetDocumentG(ctx context.Context, insert bool, related *Document) error {
+	return o.SetDocument(ctx, boil.GetContextDB(), insert, related)
 }
 
 // SetDocument of the documentsBlob to the related item.
 // Sets o.R.Document to related.
 // Adds o to related.R.DocumentsBlob.
-func (o *DocumentsBlob) SetDocument(exec boil.Executor, insert bool, related *Document) error {
+func (o *DocumentsBlob) SetDocument(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Document) error {
 	var err error
 	if insert {
-		if err = related.Insert(exec, boil.Infer()); err != nil {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
 			return errors.Wrap(err, "failed to insert into foreign table")
 		}
 	}
@@ -664,7 +701,7 @@ func (o *DocumentsBlob) SetDocument(exec boil.Executor, insert bool, related *Do
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 		return errors.Wrap(err, "failed to update local table")
 	}
 
@@ -695,13 +732,13 @@ func DocumentsBlobs(mods ...qm.QueryMod) documentsBlobQuery {
 }
 
 // FindDocumentsBlobG retrieves a single record by ID.
-func FindDocumentsBlobG(documentID int64, blobID int64, selectCols ...string) (*DocumentsBlob, error) {
-	return FindDocumentsBlob(boil.GetDB(), documentID, blobID, selectCols...)
+func FindDocumentsBlobG(ctx context.Context, documentID int64, blobID int64, selectCols ...string) (*DocumentsBlob, error) {
+	return FindDocumentsBlob(ctx, boil.GetContextDB(), documentID, blobID, selectCols...)
 }
 
 // FindDocumentsBlob retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindDocumentsBlob(exec boil.Executor, documentID int64, blobID int64, selectCols ...string) (*DocumentsBlob, error) {
+func FindDocumentsBlob(ctx context.Context, exec boil.ContextExecutor, documentID int64, blobID int64, selectCols ...string) (*DocumentsBlob, error) {
 	documentsBlobObj := &DocumentsBlob{}
 
 	sel := "*"
@@ -714,7 +751,7 @@ func FindDocumentsBlob(exec boil.Executor, documentID int64, blobID int64, selec
 
 	q := queries.Raw(query, documentID, blobID)
 
-	err := q.Bind(nil, exec, documentsBlobObj)
+	err := q.Bind(ctx, exec, documentsBlobObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -726,20 +763,20 @@ func FindDocumentsBlob(exec boil.Executor, documentID int64, blobID int64, selec
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *DocumentsBlob) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *DocumentsBlob) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *DocumentsBlob) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *DocumentsBlob) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no documents_blobs provided for insertion")
 	}
 
 	var err error
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -789,7 +826,7 @@ func (o *DocumentsBlob) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into documents_blobs")
@@ -811,7 +848,7 @@ func (o *DocumentsBlob) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for documents_blobs")
 	}
@@ -823,21 +860,21 @@ CacheNoHooks:
 		documentsBlobInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single DocumentsBlob record using the global executor.
 // See Update for more documentation.
-func (o *DocumentsBlob) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *DocumentsBlob) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the DocumentsBlob.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *DocumentsBlob) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
+func (o *DocumentsBlob) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -876,7 +913,7 @@ func (o *DocumentsBlob) Update(exec boil.Executor, columns boil.Columns) (int64,
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update documents_blobs row")
 	}
@@ -892,19 +929,19 @@ func (o *DocumentsBlob) Update(exec boil.Executor, columns boil.Columns) (int64,
 		documentsBlobUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q documentsBlobQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q documentsBlobQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q documentsBlobQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q documentsBlobQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for documents_blobs")
 	}
@@ -918,12 +955,12 @@ func (q documentsBlobQuery) UpdateAll(exec boil.Executor, cols M) (int64, error)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o DocumentsBlobSlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o DocumentsBlobSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o DocumentsBlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o DocumentsBlobSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -958,7 +995,7 @@ func (o DocumentsBlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error)
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in documentsBlob slice")
 	}
@@ -972,18 +1009,18 @@ func (o DocumentsBlobSlice) UpdateAll(exec boil.Executor, cols M) (int64, error)
 
 // DeleteG deletes a single DocumentsBlob record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *DocumentsBlob) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *DocumentsBlob) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single DocumentsBlob record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *DocumentsBlob) Delete(exec boil.Executor) (int64, error) {
+func (o *DocumentsBlob) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no DocumentsBlob provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -995,7 +1032,7 @@ func (o *DocumentsBlob) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from documents_blobs")
 	}
@@ -1005,7 +1042,7 @@ func (o *DocumentsBlob) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for documents_blobs")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -1013,14 +1050,14 @@ func (o *DocumentsBlob) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q documentsBlobQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q documentsBlobQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no documentsBlobQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from documents_blobs")
 	}
@@ -1034,19 +1071,19 @@ func (q documentsBlobQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o DocumentsBlobSlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o DocumentsBlobSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o DocumentsBlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o DocumentsBlobSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(documentsBlobBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1066,7 +1103,7 @@ func (o DocumentsBlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from documentsBlob slice")
 	}
@@ -1078,7 +1115,7 @@ func (o DocumentsBlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(documentsBlobAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1088,18 +1125,18 @@ func (o DocumentsBlobSlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *DocumentsBlob) ReloadG() error {
+func (o *DocumentsBlob) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no DocumentsBlob provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *DocumentsBlob) Reload(exec boil.Executor) error {
-	ret, err := FindDocumentsBlob(exec, o.DocumentID, o.BlobID)
+func (o *DocumentsBlob) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindDocumentsBlob(ctx, exec, o.DocumentID, o.BlobID)
 	if err != nil {
 		return err
 	}
@@ -1110,17 +1147,17 @@ func (o *DocumentsBlob) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *DocumentsBlobSlice) ReloadAllG() error {
+func (o *DocumentsBlobSlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty DocumentsBlobSlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *DocumentsBlobSlice) ReloadAll(exec boil.Executor) error {
+func (o *DocumentsBlobSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -1137,7 +1174,7 @@ func (o *DocumentsBlobSlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in DocumentsBlobSlice")
 	}
@@ -1148,12 +1185,12 @@ func (o *DocumentsBlobSlice) ReloadAll(exec boil.Executor) error {
 }
 
 // DocumentsBlobExistsG checks if the DocumentsBlob row exists.
-func DocumentsBlobExistsG(documentID int64, blobID int64) (bool, error) {
-	return DocumentsBlobExists(boil.GetDB(), documentID, blobID)
+func DocumentsBlobExistsG(ctx context.Context, documentID int64, blobID int64) (bool, error) {
+	return DocumentsBlobExists(ctx, boil.GetContextDB(), documentID, blobID)
 }
 
 // DocumentsBlobExists checks if the DocumentsBlob row exists.
-func DocumentsBlobExists(exec boil.Executor, documentID int64, blobID int64) (bool, error) {
+func DocumentsBlobExists(ctx context.Context, exec boil.ContextExecutor, documentID int64, blobID int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"documents_blobs\" where \"document_id\"=? AND \"blob_id\"=? limit 1)"
 
@@ -1162,7 +1199,7 @@ func DocumentsBlobExists(exec boil.Executor, documentID int64, blobID int64) (bo
 		fmt.Fprintln(boil.DebugWriter, documentID, blobID)
 	}
 
-	row := exec.QueryRow(sql, documentID, blobID)
+	row := exec.QueryRowContext(ctx, sql, documentID, blobID)
 
 	err := row.Scan(&exists)
 	if err != nil {
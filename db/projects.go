@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -104,7 +105,7 @@ type (
 	// This should generally be used opposed to []Project.
 	ProjectSlice []*Project
 	// ProjectHook is the signature for custom Project hook methods
-	ProjectHook func(boil.Executor, *Project) error
+	ProjectHook func(context.Context, boil.ContextExecutor, *Project) error
 
 	projectQuery struct {
 		*queries.Query
@@ -144,9 +145,13 @@ var projectAfterDeleteHooks []ProjectHook
 var projectAfterUpsertHooks []ProjectHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Project) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *Project) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -155,9 +160,13 @@ func (o *Project) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Project) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *Project) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -166,9 +175,13 @@ func (o *Project) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Project) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *Project) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -177,9 +190,13 @@ func (o *Project) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Project) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *Project) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -188,9 +205,13 @@ func (o *Project) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Project) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *Project) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -199,9 +220,13 @@ func (o *Project) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *Project) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *Project) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -210,9 +235,13 @@ func (o *Project) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Project) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *Project) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -221,9 +250,13 @@ func (o *Project) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Project) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *Project) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -232,9 +265,13 @@ func (o *Project) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *Project) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *Project) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range projectAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -267,17 +304,17 @@ func AddProjectHook(hookPoint boil.HookPoint, projectHook ProjectHook) {
 }
 
 // OneG returns a single project record from the query using the global executor.
-func (q projectQuery) OneG() (*Project, error) {
-	return q.One(boil.GetDB())
+func (q projectQuery) OneG(ctx context.Context) (*Project, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single project record from the query.
-func (q projectQuery) One(exec boil.Executor) (*Project, error) {
+func (q projectQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Project, error) {
 	o := &Project{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -285,7 +322,7 @@ func (q projectQuery) One(exec boil.Executor) (*Project, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for projects")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -293,22 +330,22 @@ func (q projectQuery) One(exec boil.Executor) (*Project, error) {
 }
 
 // AllG returns all Project records from the query using the global executor.
-func (q projectQuery) AllG() (ProjectSlice, error) {
-	return q.All(boil.GetDB())
+func (q projectQuery) AllG(ctx context.Context) (ProjectSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all Project records from the query.
-func (q projectQuery) All(exec boil.Executor) (ProjectSlice, error) {
+func (q projectQuery) All(ctx context.Context, exec boil.ContextExecutor) (ProjectSlice, error) {
 	var o []*Project
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to Project slice")
 	}
 
 	if len(projectAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -318,18 +355,18 @@ func (q projectQuery) All(exec boil.Executor) (ProjectSlice, error) {
 }
 
 // CountG returns the count of all Project records in the query, and panics on error.
-func (q projectQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q projectQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all Project records in the query.
-func (q projectQuery) Count(exec boil.Executor) (int64, error) {
+func (q projectQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count projects rows")
 	}
@@ -338,19 +375,19 @@ func (q projectQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q projectQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q projectQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q projectQuery) Exists(exec boil.Executor) (bool, error) {
+func (q projectQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if projects exists")
 	}
@@ -381,7 +418,7 @@ func (o *Project) Documents(mods ...qm.QueryMod) documentQuery {
 
 // LoadDocuments allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (projectL) LoadDocuments(e boil.Executor, singular bool, maybeProject interface{}, mods queries.Applicator) error {
+func (projectL) LoadDocuments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeProject interface{}, mods queries.Applicator) error {
 	var slice []*Project
 	var object *Project
 
@@ -423,7 +460,7 @@ func (projectL) LoadDocuments(e boil.Executor, singular bool, maybeProject inter
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load documents")
 	}
@@ -442,7 +479,7 @@ func (projectL) LoadDocuments(e boil.Executor, singular bool, maybeProject inter
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -479,20 +516,20 @@ func (projectL) LoadDocuments(e boil.Executor, singular bool, maybeProject inter
 // Appends related to o.R.Documents.
 // Sets related.R.Project appropriately.
 // Uses the global database handle.
-func (o *Project) AddDocumentsG(insert bool, related ...*Document) error {
-	return o.AddDocuments(boil.GetDB(), insert, related...)
+func (o *Project) AddDocumentsG(ctx context.Context, insert bool, related ...*Document) error {
+	return o.AddDocuments(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // AddDocuments adds the given related objects to the existing relationships
 // of the project, optionally inserting them as new records.
 // Appends related to o.R.Documents.
 // Sets related.R.Project appropriately.
-func (o *Project) AddDocuments(exec boil.Executor, insert bool, related ...*Document) error {
+func (o *Project) AddDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
 	var err error
 	for _, rel := range related {
 		if insert {
 			queries.Assign(&rel.ProjectID, o.ID)
-			if err = rel.Insert(exec, boil.Infer()); err != nil {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
@@ -508,7 +545,7 @@ func (o *Project) AddDocuments(exec boil.Executor, insert bool, related ...*Docu
 				fmt.Fprintln(boil.DebugWriter, values)
 			}
 
-			if _, err = exec.Exec(updateQuery, values...); err != nil {
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
@@ -543,13 +580,13 @@ func Projects(mods ...qm.QueryMod) projectQuery {
 }
 
 // FindProjectG retrieves a single record by ID.
-func FindProjectG(iD null.Int64, selectCols ...string) (*Project, error) {
-	return FindProject(boil.GetDB(), iD, selectCols...)
+func FindProjectG(ctx context.Context, iD null.Int64, selectCols ...string) (*Project, error) {
+	return FindProject(ctx, boil.GetContextDB(), iD, selectCols...)
 }
 
 // FindProject retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindProject(exec boil.Executor, iD null.Int64, selectCols ...string) (*Project, error) {
+func FindProject(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Project, error) {
 	projectObj := &Project{}
 
 	sel := "*"
@@ -562,7 +599,7 @@ func FindProject(exec boil.Executor, iD null.Int64, selectCols ...string) (*Proj
 
 	q := queries.Raw(query, iD)
 
-	err := q.Bind(nil, exec, projectObj)
+	err := q.Bind(ctx, exec, projectObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -574,28 +611,30 @@ func FindProject(exec boil.Executor, iD null.Int64, selectCols ...string) (*Proj
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *Project) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *Project) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *Project) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *Project) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no projects provided for insertion")
 	}
 
 	var err error
-	currTime := time.Now().In(boil.GetLocation())
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
-	}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
 	}
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -645,7 +684,7 @@ func (o *Project) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into projects")
@@ -666,7 +705,7 @@ func (o *Project) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for projects")
 	}
@@ -678,25 +717,27 @@ CacheNoHooks:
 		projectInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single Project record using the global executor.
 // See Update for more documentation.
-func (o *Project) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *Project) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the Project.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *Project) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
-	currTime := time.Now().In(boil.GetLocation())
+func (o *Project) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	o.UpdatedAt = currTime
+		o.UpdatedAt = currTime
+	}
 
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -735,7 +776,7 @@ func (o *Project) Update(exec boil.Executor, columns boil.Columns) (int64, error
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update projects row")
 	}
@@ -751,19 +792,19 @@ func (o *Project) Update(exec boil.Executor, columns boil.Columns) (int64, error
 		projectUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q projectQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q projectQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q projectQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q projectQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for projects")
 	}
@@ -777,12 +818,12 @@ func (q projectQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o ProjectSlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o ProjectSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o ProjectSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o ProjectSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -817,7 +858,7 @@ func (o ProjectSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in project slice")
 	}
@@ -831,18 +872,18 @@ func (o ProjectSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 
 // DeleteG deletes a single Project record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Project) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *Project) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single Project record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Project) Delete(exec boil.Executor) (int64, error) {
+func (o *Project) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no Project provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -854,7 +895,7 @@ func (o *Project) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from projects")
 	}
@@ -864,7 +905,7 @@ func (o *Project) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for projects")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -872,14 +913,14 @@ func (o *Project) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q projectQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q projectQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no projectQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from projects")
 	}
@@ -893,19 +934,19 @@ func (q projectQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o ProjectSlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o ProjectSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o ProjectSlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o ProjectSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(projectBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -925,7 +966,7 @@ func (o ProjectSlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from project slice")
 	}
@@ -937,7 +978,7 @@ func (o ProjectSlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(projectAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -947,18 +988,18 @@ func (o ProjectSlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *Project) ReloadG() error {
+func (o *Project) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no Project provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *Project) Reload(exec boil.Executor) error {
-	ret, err := FindProject(exec, o.ID)
+func (o *Project) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindProject(ctx, exec, o.ID)
 	if err != nil {
 		return err
 	}
@@ -969,17 +1010,17 @@ func (o *Project) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *ProjectSlice) ReloadAllG() error {
+func (o *ProjectSlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty ProjectSlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *ProjectSlice) ReloadAll(exec boil.Executor) error {
+func (o *ProjectSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -996,7 +1037,7 @@ func (o *ProjectSlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in ProjectSlice")
 	}
@@ -1007,12 +1048,12 @@ func (o *ProjectSlice) ReloadAll(exec boil.Executor) error {
 }
 
 // ProjectExistsG checks if the Project row exists.
-func ProjectExistsG(iD null.Int64) (bool, error) {
-	return ProjectExists(boil.GetDB(), iD)
+func ProjectExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return ProjectExists(ctx, boil.GetContextDB(), iD)
 }
 
 // ProjectExists checks if the Project row exists.
-func ProjectExists(exec boil.Executor, iD null.Int64) (bool, error) {
+func ProjectExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"projects\" where \"id\"=? limit 1)"
 
@@ -1021,7 +1062,7 @@ func ProjectExists(exec boil.Executor, iD null.Int64) (bool, error) {
 		fmt.Fprintln(boil.DebugWriter, iD)
 	}
 
-	row := exec.QueryRow(sql, iD)
+	row := exec.QueryRowContext(ctx, sql, iD)
 
 	err := row.Scan(&exists)
 	if err != nil {
@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -69,13 +70,16 @@ var TagWhere = struct {
 
 // TagRels is where relationship names are stored.
 var TagRels = struct {
+	Blobs     string
 	Documents string
 }{
+	Blobs:     "Blobs",
 	Documents: "Documents",
 }
 
 // tagR is where relationships are stored.
 type tagR struct {
+	Blobs     BlobSlice
 	Documents DocumentSlice
 }
 
@@ -99,7 +103,7 @@ type (
 	// This should generally be used opposed to []Tag.
 	TagSlice []*Tag
 	// TagHook is the signature for custom Tag hook methods
-	TagHook func(boil.Executor, *Tag) error
+	TagHook func(context.Context, boil.ContextExecutor, *Tag) error
 
 	tagQuery struct {
 		*queries.Query
@@ -139,9 +143,13 @@ var tagAfterDeleteHooks []TagHook
 var tagAfterUpsertHooks []TagHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Tag) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *Tag) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -150,9 +158,13 @@ func (o *Tag) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Tag) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *Tag) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -161,9 +173,13 @@ func (o *Tag) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Tag) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *Tag) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -172,9 +188,13 @@ func (o *Tag) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Tag) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *Tag) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -183,9 +203,13 @@ func (o *Tag) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Tag) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *Tag) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -194,9 +218,13 @@ func (o *Tag) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *Tag) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *Tag) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -205,9 +233,13 @@ func (o *Tag) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Tag) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *Tag) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -216,9 +248,13 @@ func (o *Tag) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Tag) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *Tag) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -227,9 +263,13 @@ func (o *Tag) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *Tag) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *Tag) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range tagAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -262,17 +302,17 @@ func AddTagHook(hookPoint boil.HookPoint, tagHook TagHook) {
 }
 
 // OneG returns a single tag record from the query using the global executor.
-func (q tagQuery) OneG() (*Tag, error) {
-	return q.One(boil.GetDB())
+func (q tagQuery) OneG(ctx context.Context) (*Tag, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single tag record from the query.
-func (q tagQuery) One(exec boil.Executor) (*Tag, error) {
+func (q tagQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Tag, error) {
 	o := &Tag{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -280,7 +320,7 @@ func (q tagQuery) One(exec boil.Executor) (*Tag, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for tags")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -288,22 +328,22 @@ func (q tagQuery) One(exec boil.Executor) (*Tag, error) {
 }
 
 // AllG returns all Tag records from the query using the global executor.
-func (q tagQuery) AllG() (TagSlice, error) {
-	return q.All(boil.GetDB())
+func (q tagQuery) AllG(ctx context.Context) (TagSlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all Tag records from the query.
-func (q tagQuery) All(exec boil.Executor) (TagSlice, error) {
+func (q tagQuery) All(ctx context.Context, exec boil.ContextExecutor) (TagSlice, error) {
 	var o []*Tag
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to Tag slice")
 	}
 
 	if len(tagAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -313,18 +353,18 @@ func (q tagQuery) All(exec boil.Executor) (TagSlice, error) {
 }
 
 // CountG returns the count of all Tag records in the query, and panics on error.
-func (q tagQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q tagQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all Tag records in the query.
-func (q tagQuery) Count(exec boil.Executor) (int64, error) {
+func (q tagQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count tags rows")
 	}
@@ -333,19 +373,19 @@ func (q tagQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q tagQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q tagQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q tagQuery) Exists(exec boil.Executor) (bool, error) {
+func (q tagQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if tags exists")
 	}
@@ -353,6 +393,28 @@ func (q tagQuery) Exists(exec boil.Executor) (bool, error) {
 	return count > 0, nil
 }
 
+// Blobs retrieves all the blob's Blobs with an executor.
+func (o *Tag) Blobs(mods ...qm.QueryMod) blobQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.InnerJoin("\"blobs_tags\" on \"blobs\".\"id\" = \"blobs_tags\".\"blob_id\""),
+		qm.Where("\"blobs_tags\".\"tag_id\"=?", o.ID),
+	)
+
+	query := Blobs(queryMods...)
+	queries.SetFrom(query.Query, "\"blobs\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"blobs\".*"})
+	}
+
+	return query
+}
+
 // Documents retrieves all the document's Documents with an executor.
 func (o *Tag) Documents(mods ...qm.QueryMod) documentQuery {
 	var queryMods []qm.QueryMod
@@ -375,9 +437,124 @@ func (o *Tag) Documents(mods ...qm.QueryMod) documentQuery {
 	return query
 }
 
+// LoadBlobs allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (tagL) LoadBlobs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTag interface{}, mods queries.Applicator) error {
+	var slice []*Tag
+	var object *Tag
+
+	if singular {
+		object = maybeTag.(*Tag)
+	} else {
+		slice = *maybeTag.(*[]*Tag)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &tagR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tagR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.Select("\"blobs\".*, \"a\".\"tag_id\""),
+		qm.From("\"blobs\""),
+		qm.InnerJoin("\"blobs_tags\" as \"a\" on \"blobs\".\"id\" = \"a\".\"blob_id\""),
+		qm.WhereIn("\"a\".\"tag_id\" in ?", args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load blobs")
+	}
+
+	var resultSlice []*Blob
+
+	var localJoinCols []int64
+	for results.Next() {
+		one := new(Blob)
+		var localJoinCol int64
+
+		err = results.Scan(&one.ID, &one.FileName, &one.MimeType, &one.FileSizeBytes, &one.EXTENSION, &one.File, &one.Views, &one.Archived, &one.ArchivedAt, &one.UpdatedAt, &one.CreatedAt, &one.Checksum, &one.Nonce, &one.Compressed, &one.SegmentSize, &one.OwnerID, &one.ExpiresAt, &one.DeletedAt, &one.SealedFinal, &one.ContentUpdatedAt, &localJoinCol)
+		if err != nil {
+			return errors.Wrap(err, "failed to scan eager loaded results for blobs")
+		}
+		if err = results.Err(); err != nil {
+			return errors.Wrap(err, "failed to plebian-bind eager loaded slice blobs")
+		}
+
+		resultSlice = append(resultSlice, one)
+		localJoinCols = append(localJoinCols, localJoinCol)
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on blobs")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for blobs")
+	}
+
+	if len(blobAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.Blobs = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &blobR{}
+			}
+			foreign.R.Tags = append(foreign.R.Tags, object)
+		}
+		return nil
+	}
+
+	for i, foreign := range resultSlice {
+		localJoinCol := localJoinCols[i]
+		for _, local := range slice {
+			if queries.Equal(local.ID, localJoinCol) {
+				local.R.Blobs = append(local.R.Blobs, foreign)
+				if foreign.R == nil {
+					foreign.R = &blobR{}
+				}
+				foreign.R.Tags = append(foreign.R.Tags, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadDocuments allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (tagL) LoadDocuments(e boil.Executor, singular bool, maybeTag interface{}, mods queries.Applicator) error {
+func (tagL) LoadDocuments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTag interface{}, mods queries.Applicator) error {
 	var slice []*Tag
 	var object *Tag
 
@@ -424,7 +601,7 @@ func (tagL) LoadDocuments(e boil.Executor, singular bool, maybeTag interface{},
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load documents")
 	}
@@ -457,7 +634,7 @@ func (tagL) LoadDocuments(e boil.Executor, singular bool, maybeTag interface{},
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -490,24 +667,192 @@ func (tagL) LoadDocuments(e boil.Executor, singular bool, maybeTag interface{},
 	return nil
 }
 
+// AddBlobsG adds the given related objects to the existing relationships
+// of the tag, optionally inserting them as new records.
+// Appends related to o.R.Blobs.
+// Sets related.R.Tags appropriately.
+// Uses the global database handle.
+func (o *Tag) AddBlobsG(ctx context.Context, insert bool, related ...*Blob) error {
+	return o.AddBlobs(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// AddBlobs adds the given related objects to the existing relationships
+// of the tag, optionally inserting them as new records.
+// Appends related to o.R.Blobs.
+// Sets related.R.Tags appropriately.
+func (o *Tag) AddBlobs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Blob) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		}
+	}
+
+	for _, rel := range related {
+		query := "insert into \"blobs_tags\" (\"tag_id\", \"blob_id\") values (?, ?)"
+		values := []interface{}{o.ID, rel.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		_, err = exec.ExecContext(ctx, query, values...)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into join table")
+		}
+	}
+	if o.R == nil {
+		o.R = &tagR{
+			Blobs: related,
+		}
+	} else {
+		o.R.Blobs = append(o.R.Blobs, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &blobR{
+				Tags: TagSlice{o},
+			}
+		} else {
+			rel.R.Tags = append(rel.R.Tags, o)
+		}
+	}
+	return nil
+}
+
+// SetBlobsG removes all previously related items of the
+// tag replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Tags's Blobs accordingly.
+// Replaces o.R.Blobs with related.
+// Sets related.R.Tags's Blobs accordingly.
+// Uses the global database handle.
+func (o *Tag) SetBlobsG(ctx context.Context, insert bool, related ...*Blob) error {
+	return o.SetBlobs(ctx, boil.GetContextDB(), insert, related...)
+}
+
+// SetBlobs removes all previously related items of the
+// tag replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Tags's Blobs accordingly.
+// Replaces o.R.Blobs with related.
+// Sets related.R.Tags's Blobs accordingly.
+func (o *Tag) SetBlobs(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Blob) error {
+	query := "delete from \"blobs_tags\" where \"tag_id\" = ?"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	removeBlobsFromTagsSlice(o, related)
+	if o.R != nil {
+		o.R.Blobs = nil
+	}
+	return o.AddBlobs(ctx, exec, insert, related...)
+}
+
+// RemoveBlobsG relationships from objects passed in.
+// Removes related items from R.Blobs (uses pointer comparison, removal does not keep order)
+// Sets related.R.Tags.
+// Uses the global database handle.
+func (o *Tag) RemoveBlobsG(ctx context.Context, related ...*Blob) error {
+	return o.RemoveBlobs(ctx, boil.GetContextDB(), related...)
+}
+
+// RemoveBlobs relationships from objects passed in.
+// Removes related items from R.Blobs (uses pointer comparison, removal does not keep order)
+// Sets related.R.Tags.
+func (o *Tag) RemoveBlobs(ctx context.Context, exec boil.ContextExecutor, related ...*Blob) error {
+	var err error
+	query := fmt.Sprintf(
+		"delete from \"blobs_tags\" where \"tag_id\" = ? and \"blob_id\" in (%s)",
+		strmangle.Placeholders(dialect.UseIndexPlaceholders, len(related), 2, 1),
+	)
+	values := []interface{}{o.ID}
+	for _, rel := range related {
+		values = append(values, rel.ID)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err = exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+	removeBlobsFromTagsSlice(o, related)
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Blobs {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Blobs)
+			if ln > 1 && i < ln-1 {
+				o.R.Blobs[i] = o.R.Blobs[ln-1]
+			}
+			o.R.Blobs = o.R.Blobs[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+func removeBlobsFromTagsSlice(o *Tag, related []*Blob) {
+	for _, rel := range related {
+		if rel.R == nil {
+			continue
+		}
+		for i, ri := range rel.R.Tags {
+			if !queries.Equal(o.ID, ri.ID) {
+				continue
+			}
+
+			ln := len(rel.R.Tags)
+			if ln > 1 && i < ln-1 {
+				rel.R.Tags[i] = rel.R.Tags[ln-1]
+			}
+			rel.R.Tags = rel.R.Tags[:ln-1]
+			break
+		}
+	}
+}
+
 // AddDocumentsG adds the given related objects to the existing relationships
 // of the tag, optionally inserting them as new records.
 // Appends related to o.R.Documents.
 // Sets related.R.Tags appropriately.
 // Uses the global database handle.
-func (o *Tag) AddDocumentsG(insert bool, related ...*Document) error {
-	return o.AddDocuments(boil.GetDB(), insert, related...)
+func (o *Tag) AddDocumentsG(ctx context.Context, insert bool, related ...*Document) error {
+	return o.AddDocuments(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // AddDocuments adds the given related objects to the existing relationships
 // of the tag, optionally inserting them as new records.
 // Appends related to o.R.Documents.
 // Sets related.R.Tags appropriately.
-func (o *Tag) AddDocuments(exec boil.Executor, insert bool, related ...*Document) error {
+func (o *Tag) AddDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
 	var err error
 	for _, rel := range related {
 		if insert {
-			if err = rel.Insert(exec, boil.Infer()); err != nil {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		}
@@ -522,7 +867,7 @@ func (o *Tag) AddDocuments(exec boil.Executor, insert bool, related ...*Document
 			fmt.Fprintln(boil.DebugWriter, values)
 		}
 
-		_, err = exec.Exec(query, values...)
+		_, err = exec.ExecContext(ctx, query, values...)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert into join table")
 		}
@@ -554,8 +899,8 @@ func (o *Tag) AddDocuments(exec boil.Executor, insert bool, related ...*Document
 // Replaces o.R.Documents with related.
 // Sets related.R.Tags's Documents accordingly.
 // Uses the global database handle.
-func (o *Tag) SetDocumentsG(insert bool, related ...*Document) error {
-	return o.SetDocuments(boil.GetDB(), insert, related...)
+func (o *Tag) SetDocumentsG(ctx context.Context, insert bool, related ...*Document) error {
+	return o.SetDocuments(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // SetDocuments removes all previously related items of the
@@ -564,7 +909,7 @@ func (o *Tag) SetDocumentsG(insert bool, related ...*Document) error {
 // Sets o.R.Tags's Documents accordingly.
 // Replaces o.R.Documents with related.
 // Sets related.R.Tags's Documents accordingly.
-func (o *Tag) SetDocuments(exec boil.Executor, insert bool, related ...*Document) error {
+func (o *Tag) SetDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
 	query := "delete from \"documents_tags\" where \"tag_id\" = ?"
 	values := []interface{}{o.ID}
 	if boil.DebugMode {
@@ -572,7 +917,7 @@ func (o *Tag) SetDocuments(exec boil.Executor, insert bool, related ...*Document
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	_, err := exec.Exec(query, values...)
+	_, err := exec.ExecContext(ctx, query, values...)
 	if err != nil {
 		return errors.Wrap(err, "failed to remove relationships before set")
 	}
@@ -581,21 +926,21 @@ func (o *Tag) SetDocuments(exec boil.Executor, insert bool, related ...*Document
 	if o.R != nil {
 		o.R.Documents = nil
 	}
-	return o.AddDocuments(exec, insert, related...)
+	return o.AddDocuments(ctx, exec, insert, related...)
 }
 
 // RemoveDocumentsG relationships from objects passed in.
 // Removes related items from R.Documents (uses pointer comparison, removal does not keep order)
 // Sets related.R.Tags.
 // Uses the global database handle.
-func (o *Tag) RemoveDocumentsG(related ...*Document) error {
-	return o.RemoveDocuments(boil.GetDB(), related...)
+func (o *Tag) RemoveDocumentsG(ctx context.Context, related ...*Document) error {
+	return o.RemoveDocuments(ctx, boil.GetContextDB(), related...)
 }
 
 // RemoveDocuments relationships from objects passed in.
 // Removes related items from R.Documents (uses pointer comparison, removal does not keep order)
 // Sets related.R.Tags.
-func (o *Tag) RemoveDocuments(exec boil.Executor, related ...*Document) error {
+func (o *Tag) RemoveDocuments(ctx context.Context, exec boil.ContextExecutor, related ...*Document) error {
 	var err error
 	query := fmt.Sprintf(
 		"delete from \"documents_tags\" where \"tag_id\" = ? and \"document_id\" in (%s)",
@@ -611,7 +956,7 @@ func (o *Tag) RemoveDocuments(exec boil.Executor, related ...*Document) error {
 		fmt.Fprintln(boil.DebugWriter, values)
 	}
 
-	_, err = exec.Exec(query, values...)
+	_, err = exec.ExecContext(ctx, query, values...)
 	if err != nil {
 		return errors.Wrap(err, "failed to remove relationships before set")
 	}
@@ -665,13 +1010,13 @@ func Tags(mods ...qm.QueryMod) tagQuery {
 }
 
 // FindTagG retrieves a single record by ID.
-func FindTagG(iD null.Int64, selectCols ...string) (*Tag, error) {
-	return FindTag(boil.GetDB(), iD, selectCols...)
+func FindTagG(ctx context.Context, iD null.Int64, selectCols ...string) (*Tag, error) {
+	return FindTag(ctx, boil.GetContextDB(), iD, selectCols...)
 }
 
 // FindTag retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindTag(exec boil.Executor, iD null.Int64, selectCols ...string) (*Tag, error) {
+func FindTag(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Tag, error) {
 	tagObj := &Tag{}
 
 	sel := "*"
@@ -684,7 +1029,7 @@ func FindTag(exec boil.Executor, iD null.Int64, selectCols ...string) (*Tag, err
 
 	q := queries.Raw(query, iD)
 
-	err := q.Bind(nil, exec, tagObj)
+	err := q.Bind(ctx, exec, tagObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -696,28 +1041,30 @@ func FindTag(exec boil.Executor, iD null.Int64, selectCols ...string) (*Tag, err
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *Tag) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *Tag) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *Tag) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *Tag) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no tags provided for insertion")
 	}
 
 	var err error
-	currTime := time.Now().In(boil.GetLocation())
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
-	}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
 	}
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -767,7 +1114,7 @@ func (o *Tag) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into tags")
@@ -788,7 +1135,7 @@ func (o *Tag) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for tags")
 	}
@@ -800,25 +1147,27 @@ CacheNoHooks:
 		tagInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single Tag record using the global executor.
 // See Update for more documentation.
-func (o *Tag) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *Tag) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the Tag.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *Tag) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
-	currTime := time.Now().In(boil.GetLocation())
+func (o *Tag) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	o.UpdatedAt = currTime
+		o.UpdatedAt = currTime
+	}
 
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -857,7 +1206,7 @@ func (o *Tag) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update tags row")
 	}
@@ -873,19 +1222,19 @@ func (o *Tag) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
 		tagUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q tagQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q tagQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q tagQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q tagQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for tags")
 	}
@@ -899,12 +1248,12 @@ func (q tagQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o TagSlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o TagSlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o TagSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o TagSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -939,7 +1288,7 @@ func (o TagSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in tag slice")
 	}
@@ -953,18 +1302,18 @@ func (o TagSlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 
 // DeleteG deletes a single Tag record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Tag) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *Tag) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single Tag record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Tag) Delete(exec boil.Executor) (int64, error) {
+func (o *Tag) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no Tag provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -976,7 +1325,7 @@ func (o *Tag) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from tags")
 	}
@@ -986,7 +1335,7 @@ func (o *Tag) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for tags")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -994,14 +1343,14 @@ func (o *Tag) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q tagQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q tagQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no tagQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from tags")
 	}
@@ -1015,19 +1364,19 @@ func (q tagQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o TagSlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o TagSlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o TagSlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o TagSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(tagBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1047,7 +1396,7 @@ func (o TagSlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from tag slice")
 	}
@@ -1059,7 +1408,7 @@ func (o TagSlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(tagAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -1069,18 +1418,18 @@ func (o TagSlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *Tag) ReloadG() error {
+func (o *Tag) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no Tag provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *Tag) Reload(exec boil.Executor) error {
-	ret, err := FindTag(exec, o.ID)
+func (o *Tag) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTag(ctx, exec, o.ID)
 	if err != nil {
 		return err
 	}
@@ -1091,17 +1440,17 @@ func (o *Tag) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *TagSlice) ReloadAllG() error {
+func (o *TagSlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty TagSlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *TagSlice) ReloadAll(exec boil.Executor) error {
+func (o *TagSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -1118,7 +1467,7 @@ func (o *TagSlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in TagSlice")
 	}
@@ -1129,12 +1478,12 @@ func (o *TagSlice) ReloadAll(exec boil.Executor) error {
 }
 
 // TagExistsG checks if the Tag row exists.
-func TagExistsG(iD null.Int64) (bool, error) {
-	return TagExists(boil.GetDB(), iD)
+func TagExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return TagExists(ctx, boil.GetContextDB(), iD)
 }
 
 // TagExists checks if the Tag row exists.
-func TagExists(exec boil.Executor, iD null.Int64) (bool, error) {
+func TagExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"tags\" where \"id\"=? limit 1)"
 
@@ -1143,7 +1492,7 @@ func TagExists(exec boil.Executor, iD null.Int64) (bool, error) {
 		fmt.Fprintln(boil.DebugWriter, iD)
 	}
 
-	row := exec.QueryRow(sql, iD)
+	row := exec.QueryRowContext(ctx, sql, iD)
 
 	err := row.Scan(&exists)
 	if err != nil {
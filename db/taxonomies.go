@@ -4,6 +4,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -99,7 +100,7 @@ type (
 	// This should generally be used opposed to []Taxonomy.
 	TaxonomySlice []*Taxonomy
 	// TaxonomyHook is the signature for custom Taxonomy hook methods
-	TaxonomyHook func(boil.Executor, *Taxonomy) error
+	TaxonomyHook func(context.Context, boil.ContextExecutor, *Taxonomy) error
 
 	taxonomyQuery struct {
 		*queries.Query
@@ -139,9 +140,13 @@ var taxonomyAfterDeleteHooks []TaxonomyHook
 var taxonomyAfterUpsertHooks []TaxonomyHook
 
 // doBeforeInsertHooks executes all "before insert" hooks.
-func (o *Taxonomy) doBeforeInsertHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyBeforeInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -150,9 +155,13 @@ func (o *Taxonomy) doBeforeInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpdateHooks executes all "before Update" hooks.
-func (o *Taxonomy) doBeforeUpdateHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyBeforeUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -161,9 +170,13 @@ func (o *Taxonomy) doBeforeUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeDeleteHooks executes all "before Delete" hooks.
-func (o *Taxonomy) doBeforeDeleteHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyBeforeDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -172,9 +185,13 @@ func (o *Taxonomy) doBeforeDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doBeforeUpsertHooks executes all "before Upsert" hooks.
-func (o *Taxonomy) doBeforeUpsertHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyBeforeUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -183,9 +200,13 @@ func (o *Taxonomy) doBeforeUpsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterInsertHooks executes all "after Insert" hooks.
-func (o *Taxonomy) doAfterInsertHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyAfterInsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -194,9 +215,13 @@ func (o *Taxonomy) doAfterInsertHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterSelectHooks executes all "after Select" hooks.
-func (o *Taxonomy) doAfterSelectHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyAfterSelectHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -205,9 +230,13 @@ func (o *Taxonomy) doAfterSelectHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpdateHooks executes all "after Update" hooks.
-func (o *Taxonomy) doAfterUpdateHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyAfterUpdateHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -216,9 +245,13 @@ func (o *Taxonomy) doAfterUpdateHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterDeleteHooks executes all "after Delete" hooks.
-func (o *Taxonomy) doAfterDeleteHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyAfterDeleteHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -227,9 +260,13 @@ func (o *Taxonomy) doAfterDeleteHooks(exec boil.Executor) (err error) {
 }
 
 // doAfterUpsertHooks executes all "after Upsert" hooks.
-func (o *Taxonomy) doAfterUpsertHooks(exec boil.Executor) (err error) {
+func (o *Taxonomy) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
 	for _, hook := range taxonomyAfterUpsertHooks {
-		if err := hook(exec, o); err != nil {
+		if err := hook(ctx, exec, o); err != nil {
 			return err
 		}
 	}
@@ -262,17 +299,17 @@ func AddTaxonomyHook(hookPoint boil.HookPoint, taxonomyHook TaxonomyHook) {
 }
 
 // OneG returns a single taxonomy record from the query using the global executor.
-func (q taxonomyQuery) OneG() (*Taxonomy, error) {
-	return q.One(boil.GetDB())
+func (q taxonomyQuery) OneG(ctx context.Context) (*Taxonomy, error) {
+	return q.One(ctx, boil.GetContextDB())
 }
 
 // One returns a single taxonomy record from the query.
-func (q taxonomyQuery) One(exec boil.Executor) (*Taxonomy, error) {
+func (q taxonomyQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Taxonomy, error) {
 	o := &Taxonomy{}
 
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Bind(nil, exec, o)
+	err := q.Bind(ctx, exec, o)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -280,7 +317,7 @@ func (q taxonomyQuery) One(exec boil.Executor) (*Taxonomy, error) {
 		return nil, errors.Wrap(err, "db: failed to execute a one query for taxonomies")
 	}
 
-	if err := o.doAfterSelectHooks(exec); err != nil {
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
 		return o, err
 	}
 
@@ -288,22 +325,22 @@ func (q taxonomyQuery) One(exec boil.Executor) (*Taxonomy, error) {
 }
 
 // AllG returns all Taxonomy records from the query using the global executor.
-func (q taxonomyQuery) AllG() (TaxonomySlice, error) {
-	return q.All(boil.GetDB())
+func (q taxonomyQuery) AllG(ctx context.Context) (TaxonomySlice, error) {
+	return q.All(ctx, boil.GetContextDB())
 }
 
 // All returns all Taxonomy records from the query.
-func (q taxonomyQuery) All(exec boil.Executor) (TaxonomySlice, error) {
+func (q taxonomyQuery) All(ctx context.Context, exec boil.ContextExecutor) (TaxonomySlice, error) {
 	var o []*Taxonomy
 
-	err := q.Bind(nil, exec, &o)
+	err := q.Bind(ctx, exec, &o)
 	if err != nil {
 		return nil, errors.Wrap(err, "db: failed to assign all query results to Taxonomy slice")
 	}
 
 	if len(taxonomyAfterSelectHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterSelectHooks(exec); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
 				return o, err
 			}
 		}
@@ -313,18 +350,18 @@ func (q taxonomyQuery) All(exec boil.Executor) (TaxonomySlice, error) {
 }
 
 // CountG returns the count of all Taxonomy records in the query, and panics on error.
-func (q taxonomyQuery) CountG() (int64, error) {
-	return q.Count(boil.GetDB())
+func (q taxonomyQuery) CountG(ctx context.Context) (int64, error) {
+	return q.Count(ctx, boil.GetContextDB())
 }
 
 // Count returns the count of all Taxonomy records in the query.
-func (q taxonomyQuery) Count(exec boil.Executor) (int64, error) {
+func (q taxonomyQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: failed to count taxonomies rows")
 	}
@@ -333,19 +370,19 @@ func (q taxonomyQuery) Count(exec boil.Executor) (int64, error) {
 }
 
 // ExistsG checks if the row exists in the table, and panics on error.
-func (q taxonomyQuery) ExistsG() (bool, error) {
-	return q.Exists(boil.GetDB())
+func (q taxonomyQuery) ExistsG(ctx context.Context) (bool, error) {
+	return q.Exists(ctx, boil.GetContextDB())
 }
 
 // Exists checks if the row exists in the table.
-func (q taxonomyQuery) Exists(exec boil.Executor) (bool, error) {
+func (q taxonomyQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
 	var count int64
 
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow(exec).Scan(&count)
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "db: failed to check if taxonomies exists")
 	}
@@ -376,7 +413,7 @@ func (o *Taxonomy) Documents(mods ...qm.QueryMod) documentQuery {
 
 // LoadDocuments allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (taxonomyL) LoadDocuments(e boil.Executor, singular bool, maybeTaxonomy interface{}, mods queries.Applicator) error {
+func (taxonomyL) LoadDocuments(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTaxonomy interface{}, mods queries.Applicator) error {
 	var slice []*Taxonomy
 	var object *Taxonomy
 
@@ -418,7 +455,7 @@ func (taxonomyL) LoadDocuments(e boil.Executor, singular bool, maybeTaxonomy int
 		mods.Apply(query)
 	}
 
-	results, err := query.Query(e)
+	results, err := query.QueryContext(ctx, e)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load documents")
 	}
@@ -437,7 +474,7 @@ func (taxonomyL) LoadDocuments(e boil.Executor, singular bool, maybeTaxonomy int
 
 	if len(documentAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
 			}
 		}
@@ -474,20 +511,20 @@ func (taxonomyL) LoadDocuments(e boil.Executor, singular bool, maybeTaxonomy int
 // Appends related to o.R.Documents.
 // Sets related.R.Taxonomy appropriately.
 // Uses the global database handle.
-func (o *Taxonomy) AddDocumentsG(insert bool, related ...*Document) error {
-	return o.AddDocuments(boil.GetDB(), insert, related...)
+func (o *Taxonomy) AddDocumentsG(ctx context.Context, insert bool, related ...*Document) error {
+	return o.AddDocuments(ctx, boil.GetContextDB(), insert, related...)
 }
 
 // AddDocuments adds the given related objects to the existing relationships
 // of the taxonomy, optionally inserting them as new records.
 // Appends related to o.R.Documents.
 // Sets related.R.Taxonomy appropriately.
-func (o *Taxonomy) AddDocuments(exec boil.Executor, insert bool, related ...*Document) error {
+func (o *Taxonomy) AddDocuments(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*Document) error {
 	var err error
 	for _, rel := range related {
 		if insert {
 			queries.Assign(&rel.TaxonomyID, o.ID)
-			if err = rel.Insert(exec, boil.Infer()); err != nil {
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
 				return errors.Wrap(err, "failed to insert into foreign table")
 			}
 		} else {
@@ -503,7 +540,7 @@ func (o *Taxonomy) AddDocuments(exec boil.Executor, insert bool, related ...*Doc
 				fmt.Fprintln(boil.DebugWriter, values)
 			}
 
-			if _, err = exec.Exec(updateQuery, values...); err != nil {
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
 				return errors.Wrap(err, "failed to update foreign table")
 			}
 
@@ -538,13 +575,13 @@ func Taxonomies(mods ...qm.QueryMod) taxonomyQuery {
 }
 
 // FindTaxonomyG retrieves a single record by ID.
-func FindTaxonomyG(iD null.Int64, selectCols ...string) (*Taxonomy, error) {
-	return FindTaxonomy(boil.GetDB(), iD, selectCols...)
+func FindTaxonomyG(ctx context.Context, iD null.Int64, selectCols ...string) (*Taxonomy, error) {
+	return FindTaxonomy(ctx, boil.GetContextDB(), iD, selectCols...)
 }
 
 // FindTaxonomy retrieves a single record by ID with an executor.
 // If selectCols is empty Find will return all columns.
-func FindTaxonomy(exec boil.Executor, iD null.Int64, selectCols ...string) (*Taxonomy, error) {
+func FindTaxonomy(ctx context.Context, exec boil.ContextExecutor, iD null.Int64, selectCols ...string) (*Taxonomy, error) {
 	taxonomyObj := &Taxonomy{}
 
 	sel := "*"
@@ -557,7 +594,7 @@ func FindTaxonomy(exec boil.Executor, iD null.Int64, selectCols ...string) (*Tax
 
 	q := queries.Raw(query, iD)
 
-	err := q.Bind(nil, exec, taxonomyObj)
+	err := q.Bind(ctx, exec, taxonomyObj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -569,28 +606,30 @@ func FindTaxonomy(exec boil.Executor, iD null.Int64, selectCols ...string) (*Tax
 }
 
 // InsertG a single record. See Insert for whitelist behavior description.
-func (o *Taxonomy) InsertG(columns boil.Columns) error {
-	return o.Insert(boil.GetDB(), columns)
+func (o *Taxonomy) InsertG(ctx context.Context, columns boil.Columns) error {
+	return o.Insert(ctx, boil.GetContextDB(), columns)
 }
 
 // Insert a single record using an executor.
 // See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
-func (o *Taxonomy) Insert(exec boil.Executor, columns boil.Columns) error {
+func (o *Taxonomy) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
 	if o == nil {
 		return errors.New("db: no taxonomies provided for insertion")
 	}
 
 	var err error
-	currTime := time.Now().In(boil.GetLocation())
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	if o.UpdatedAt.IsZero() {
-		o.UpdatedAt = currTime
-	}
-	if o.CreatedAt.IsZero() {
-		o.CreatedAt = currTime
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
 	}
 
-	if err := o.doBeforeInsertHooks(exec); err != nil {
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
 		return err
 	}
 
@@ -640,7 +679,7 @@ func (o *Taxonomy) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
-	_, err = exec.Exec(cache.query, vals...)
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
 
 	if err != nil {
 		return errors.Wrap(err, "db: unable to insert into taxonomies")
@@ -661,7 +700,7 @@ func (o *Taxonomy) Insert(exec boil.Executor, columns boil.Columns) error {
 		fmt.Fprintln(boil.DebugWriter, identifierCols...)
 	}
 
-	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to populate default values for taxonomies")
 	}
@@ -673,25 +712,27 @@ CacheNoHooks:
 		taxonomyInsertCacheMut.Unlock()
 	}
 
-	return o.doAfterInsertHooks(exec)
+	return o.doAfterInsertHooks(ctx, exec)
 }
 
 // UpdateG a single Taxonomy record using the global executor.
 // See Update for more documentation.
-func (o *Taxonomy) UpdateG(columns boil.Columns) (int64, error) {
-	return o.Update(boil.GetDB(), columns)
+func (o *Taxonomy) UpdateG(ctx context.Context, columns boil.Columns) (int64, error) {
+	return o.Update(ctx, boil.GetContextDB(), columns)
 }
 
 // Update uses an executor to update the Taxonomy.
 // See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
 // Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
-func (o *Taxonomy) Update(exec boil.Executor, columns boil.Columns) (int64, error) {
-	currTime := time.Now().In(boil.GetLocation())
+func (o *Taxonomy) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
 
-	o.UpdatedAt = currTime
+		o.UpdatedAt = currTime
+	}
 
 	var err error
-	if err = o.doBeforeUpdateHooks(exec); err != nil {
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 	key := makeCacheKey(columns, nil)
@@ -730,7 +771,7 @@ func (o *Taxonomy) Update(exec boil.Executor, columns boil.Columns) (int64, erro
 	}
 
 	var result sql.Result
-	result, err = exec.Exec(cache.query, values...)
+	result, err = exec.ExecContext(ctx, cache.query, values...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update taxonomies row")
 	}
@@ -746,19 +787,19 @@ func (o *Taxonomy) Update(exec boil.Executor, columns boil.Columns) (int64, erro
 		taxonomyUpdateCacheMut.Unlock()
 	}
 
-	return rowsAff, o.doAfterUpdateHooks(exec)
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (q taxonomyQuery) UpdateAllG(cols M) (int64, error) {
-	return q.UpdateAll(boil.GetDB(), cols)
+func (q taxonomyQuery) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return q.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values.
-func (q taxonomyQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (q taxonomyQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	queries.SetUpdate(q.Query, cols)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all for taxonomies")
 	}
@@ -772,12 +813,12 @@ func (q taxonomyQuery) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 }
 
 // UpdateAllG updates all rows with the specified column values.
-func (o TaxonomySlice) UpdateAllG(cols M) (int64, error) {
-	return o.UpdateAll(boil.GetDB(), cols)
+func (o TaxonomySlice) UpdateAllG(ctx context.Context, cols M) (int64, error) {
+	return o.UpdateAll(ctx, boil.GetContextDB(), cols)
 }
 
 // UpdateAll updates all rows with the specified column values, using an executor.
-func (o TaxonomySlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
+func (o TaxonomySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
 	ln := int64(len(o))
 	if ln == 0 {
 		return 0, nil
@@ -812,7 +853,7 @@ func (o TaxonomySlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to update all in taxonomy slice")
 	}
@@ -826,18 +867,18 @@ func (o TaxonomySlice) UpdateAll(exec boil.Executor, cols M) (int64, error) {
 
 // DeleteG deletes a single Taxonomy record.
 // DeleteG will match against the primary key column to find the record to delete.
-func (o *Taxonomy) DeleteG() (int64, error) {
-	return o.Delete(boil.GetDB())
+func (o *Taxonomy) DeleteG(ctx context.Context) (int64, error) {
+	return o.Delete(ctx, boil.GetContextDB())
 }
 
 // Delete deletes a single Taxonomy record with an executor.
 // Delete will match against the primary key column to find the record to delete.
-func (o *Taxonomy) Delete(exec boil.Executor) (int64, error) {
+func (o *Taxonomy) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if o == nil {
 		return 0, errors.New("db: no Taxonomy provided for delete")
 	}
 
-	if err := o.doBeforeDeleteHooks(exec); err != nil {
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -849,7 +890,7 @@ func (o *Taxonomy) Delete(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args...)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete from taxonomies")
 	}
@@ -859,7 +900,7 @@ func (o *Taxonomy) Delete(exec boil.Executor) (int64, error) {
 		return 0, errors.Wrap(err, "db: failed to get rows affected by delete for taxonomies")
 	}
 
-	if err := o.doAfterDeleteHooks(exec); err != nil {
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
 		return 0, err
 	}
 
@@ -867,14 +908,14 @@ func (o *Taxonomy) Delete(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAll deletes all matching rows.
-func (q taxonomyQuery) DeleteAll(exec boil.Executor) (int64, error) {
+func (q taxonomyQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if q.Query == nil {
 		return 0, errors.New("db: no taxonomyQuery provided for delete all")
 	}
 
 	queries.SetDelete(q.Query)
 
-	result, err := q.Query.Exec(exec)
+	result, err := q.Query.ExecContext(ctx, exec)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from taxonomies")
 	}
@@ -888,19 +929,19 @@ func (q taxonomyQuery) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // DeleteAllG deletes all rows in the slice.
-func (o TaxonomySlice) DeleteAllG() (int64, error) {
-	return o.DeleteAll(boil.GetDB())
+func (o TaxonomySlice) DeleteAllG(ctx context.Context) (int64, error) {
+	return o.DeleteAll(ctx, boil.GetContextDB())
 }
 
 // DeleteAll deletes all rows in the slice, using an executor.
-func (o TaxonomySlice) DeleteAll(exec boil.Executor) (int64, error) {
+func (o TaxonomySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
 	if len(o) == 0 {
 		return 0, nil
 	}
 
 	if len(taxonomyBeforeDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doBeforeDeleteHooks(exec); err != nil {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -920,7 +961,7 @@ func (o TaxonomySlice) DeleteAll(exec boil.Executor) (int64, error) {
 		fmt.Fprintln(boil.DebugWriter, args)
 	}
 
-	result, err := exec.Exec(sql, args...)
+	result, err := exec.ExecContext(ctx, sql, args...)
 	if err != nil {
 		return 0, errors.Wrap(err, "db: unable to delete all from taxonomy slice")
 	}
@@ -932,7 +973,7 @@ func (o TaxonomySlice) DeleteAll(exec boil.Executor) (int64, error) {
 
 	if len(taxonomyAfterDeleteHooks) != 0 {
 		for _, obj := range o {
-			if err := obj.doAfterDeleteHooks(exec); err != nil {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
 				return 0, err
 			}
 		}
@@ -942,18 +983,18 @@ func (o TaxonomySlice) DeleteAll(exec boil.Executor) (int64, error) {
 }
 
 // ReloadG refetches the object from the database using the primary keys.
-func (o *Taxonomy) ReloadG() error {
+func (o *Taxonomy) ReloadG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: no Taxonomy provided for reload")
 	}
 
-	return o.Reload(boil.GetDB())
+	return o.Reload(ctx, boil.GetContextDB())
 }
 
 // Reload refetches the object from the database
 // using the primary keys with an executor.
-func (o *Taxonomy) Reload(exec boil.Executor) error {
-	ret, err := FindTaxonomy(exec, o.ID)
+func (o *Taxonomy) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTaxonomy(ctx, exec, o.ID)
 	if err != nil {
 		return err
 	}
@@ -964,17 +1005,17 @@ func (o *Taxonomy) Reload(exec boil.Executor) error {
 
 // ReloadAllG refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *TaxonomySlice) ReloadAllG() error {
+func (o *TaxonomySlice) ReloadAllG(ctx context.Context) error {
 	if o == nil {
 		return errors.New("db: empty TaxonomySlice provided for reload all")
 	}
 
-	return o.ReloadAll(boil.GetDB())
+	return o.ReloadAll(ctx, boil.GetContextDB())
 }
 
 // ReloadAll refetches every row with matching primary key column values
 // and overwrites the original object slice with the newly updated slice.
-func (o *TaxonomySlice) ReloadAll(exec boil.Executor) error {
+func (o *TaxonomySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -991,7 +1032,7 @@ func (o *TaxonomySlice) ReloadAll(exec boil.Executor) error {
 
 	q := queries.Raw(sql, args...)
 
-	err := q.Bind(nil, exec, &slice)
+	err := q.Bind(ctx, exec, &slice)
 	if err != nil {
 		return errors.Wrap(err, "db: unable to reload all in TaxonomySlice")
 	}
@@ -1002,12 +1043,12 @@ func (o *TaxonomySlice) ReloadAll(exec boil.Executor) error {
 }
 
 // TaxonomyExistsG checks if the Taxonomy row exists.
-func TaxonomyExistsG(iD null.Int64) (bool, error) {
-	return TaxonomyExists(boil.GetDB(), iD)
+func TaxonomyExistsG(ctx context.Context, iD null.Int64) (bool, error) {
+	return TaxonomyExists(ctx, boil.GetContextDB(), iD)
 }
 
 // TaxonomyExists checks if the Taxonomy row exists.
-func TaxonomyExists(exec boil.Executor, iD null.Int64) (bool, error) {
+func TaxonomyExists(ctx context.Context, exec boil.ContextExecutor, iD null.Int64) (bool, error) {
 	var exists bool
 	sql := "select exists(select 1 from \"taxonomies\" where \"id\"=? limit 1)"
 
@@ -1016,7 +1057,7 @@ func TaxonomyExists(exec boil.Executor, iD null.Int64) (bool, error) {
 		fmt.Fprintln(boil.DebugWriter, iD)
 	}
 
-	row := exec.QueryRow(sql, iD)
+	row := exec.QueryRowContext(ctx, sql, iD)
 
 	err := row.Scan(&exists)
 	if err != nil {
@@ -3,34 +3,51 @@ package doco
 import (
 	"bytes"
 	"context"
-	"doco/db"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/go-chi/cors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"net/http"
 	"text/template"
+	"time"
 
 	"github.com/caddyserver/caddy"
 	// http driver for caddy
 	_ "github.com/caddyserver/caddy/caddyhttp"
+	"github.com/caddyserver/caddy/caddytls"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
-var sessionManager *scs.SessionManager
-
 // ErrNotImplemented is used to stub empty funcs
 var ErrNotImplemented = errors.New("not implemented")
 
-// ErrUnableToPopulate occurs because of SQLite's ID creation order
+// ErrRouteNotFound is returned for paths no route matches
+var ErrRouteNotFound = errors.New("route not found")
+
+// ErrMethodNotAllowed is returned when the path exists but not for the request method
+var ErrMethodNotAllowed = errors.New("method not allowed")
+
+// ErrAPIUnavailable is served by the load balancer when the API can't be reached
+var ErrAPIUnavailable = errors.New("api unavailable")
+
+// ErrUnableToPopulate occurs because of SQLite's ID creation order. sqlboiler's
+// sqlite3 driver has no RETURNING, so after an INSERT it reads back every column
+// with a DEFAULT that the model left zero (id, archived, created_at, updated_at
+// and friends) by primary key. The model's ID is still NULL at that point, so the
+// read finds nothing even though the row was written. Use insertG rather than
+// matching on this.
 var ErrUnableToPopulate = "db: unable to populate default values"
 
 // SecureHandlerFunc is a custom http.HandlerFunc that returns a status code and error
@@ -43,6 +60,10 @@ type HandlerFunc func(w http.ResponseWriter, r *http.Request) (interface{}, int,
 type ErrorResponse struct {
 	Err     string `json:"err"`
 	Message string `json:"message"`
+	// Fields maps each invalid input field to what is wrong with it
+	Fields map[string]string `json:"fields,omitempty"`
+	// RequestID finds the request in the logs when someone reports the error
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // Err constructor
@@ -57,6 +78,28 @@ func Err(err error, message ...string) *ErrorResponse {
 	return e
 }
 
+// ErrValidation is the err of every ValidationError response
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError reports bad input field by field, withError sends it as a 422
+type ValidationError struct {
+	Fields map[string]string
+}
+
+// ValidationErr constructor, fields maps each bad field to a message
+func ValidationErr(fields map[string]string) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	problems := []string{}
+	for field, message := range e.Fields {
+		problems = append(problems, field+": "+message)
+	}
+	sort.Strings(problems)
+	return strings.Join(problems, "; ")
+}
+
 // Unwrap the inner error
 func (e *ErrorResponse) Unwrap() error {
 	return errors.New(e.Err)
@@ -74,28 +117,91 @@ func (e *ErrorResponse) JSON() string {
 	}
 	return string(b)
 }
-func withError(next HandlerFunc) http.HandlerFunc {
+
+// errInternal is all a client learns about a 5xx, the detail only goes to the log
+var errInternal = errors.New("internal server error")
+
+// publicServerErrors are 5xx causes that are safe to show to clients
+var publicServerErrors = []error{ErrChecksumMismatch, ErrNotReady, ErrBatchFailed, ErrBackupUnsupported, ErrVacuumUnsupported, ErrTooManyDownloads}
+
+// errorFor builds the client facing error, 4xx keep their detail while 5xx are
+// reduced to a generic message unless caused by one of publicServerErrors
+func errorFor(err error, code int) *ErrorResponse {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		e := Err(ErrValidation, verr.Error())
+		e.Fields = verr.Fields
+		return e
+	}
+	if code < http.StatusInternalServerError {
+		return Err(err)
+	}
+	for _, public := range publicServerErrors {
+		if errors.Is(err, public) {
+			return Err(public)
+		}
+	}
+	return Err(errInternal)
+}
+
+// writeError logs the full error and writes the client facing one
+func (c *API) writeError(w http.ResponseWriter, r *http.Request, err error, code int) {
+	if code >= http.StatusInternalServerError {
+		c.log.Errorw("request failed", "path", r.URL.Path, "status", code, "request_id", middleware.GetReqID(r.Context()), "err", err)
+	} else {
+		c.log.Debugw("request rejected", "path", r.URL.Path, "status", code, "request_id", middleware.GetReqID(r.Context()), "err", err)
+	}
+	writeErrorResponse(w, r, errorFor(err, code), code)
+}
+
+// writeErrorResponse is http.Error with a content type that matches the JSON body
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, e *ErrorResponse, code int) {
+	e.RequestID = middleware.GetReqID(r.Context())
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	fmt.Fprintln(w, e.JSON())
+}
+
+// marshal encodes a response body, indented when PrettyJSON is set or the request has ?pretty
+func (c *API) marshal(r *http.Request, v interface{}) ([]byte, error) {
+	if _, pretty := r.URL.Query()["pretty"]; pretty || c.prettyJSON {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return json.Marshal(v)
+}
+
+func (c *API) withError(next HandlerFunc) http.HandlerFunc {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		result, code, err := next(w, r)
+		var verr *ValidationError
+		switch {
+		case errors.As(err, &verr):
+			code = http.StatusUnprocessableEntity
+		case err != nil && code == 0:
+			code = http.StatusInternalServerError
+		case err == nil && result == nil:
+			code = http.StatusNoContent
+		case code == 0:
+			code = http.StatusOK
+		}
+		c.metrics.requestsTotal.WithLabelValues(strconv.Itoa(code)).Inc()
 		if err != nil {
-			fmt.Println(err)
-			http.Error(w, Err(err).JSON(), code)
+			c.writeError(w, r, err, code)
 			return
 		}
 		if result == nil {
-			if err == nil {
-				err = errors.New("no response")
-			}
-			fmt.Println(err)
-			http.Error(w, Err(err, "no response").JSON(), code)
+			w.WriteHeader(code)
 			return
 		}
-		err = json.NewEncoder(w).Encode(result)
+		b, err := c.marshal(r, result)
 		if err != nil {
-			fmt.Println(err)
-			http.Error(w, Err(err).JSON(), code)
+			c.writeError(w, r, err, http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(code)
+		w.Write(append(b, '\n'))
 		return
 	}
 	return fn
@@ -103,75 +209,419 @@ func withError(next HandlerFunc) http.HandlerFunc {
 
 const caddyfileTemplate = `
 {{ .caddyAddr}} {
+	{{- if .tlsCert }}
+	tls {{ .tlsCert }} {{ .tlsKey }}
+	{{- else if .tlsEmail }}
+	tls {{ .tlsEmail }}
+	{{- else }}
 	tls off
-    proxy /api/ localhost{{ .apiAddr }} {
+	{{- end }}
+    proxy {{ .apiPrefix }}/ localhost{{ .apiAddr }} {
 		transparent
 		websocket
 		timeout 10m
+		{{- if .healthCheckInterval }}
+		health_check {{ .apiPrefix }}/health
+		health_check_interval {{ .healthCheckInterval }}
+		{{- end }}
+    }
+    errors {
+		502 {{ .errorPage }}
+		503 {{ .errorPage }}
+		504 {{ .errorPage }}
     }
     root {{ .rootPath }}
     rewrite { 
-        if {path} not_match ^/api
+        if {path} not_match ^{{ .apiPrefix }}(/|$)
         to {path} /
     }
 }
 `
 
+// DefaultAPIPrefix is where the API is mounted unless configured otherwise
+const DefaultAPIPrefix = "/api"
+
+// ServerConfig for the API server
+type ServerConfig struct {
+	Addr string
+	// APIPrefix is the path the routes are mounted under, DefaultAPIPrefix when empty.
+	// RunLoadBalancer must be given the same one.
+	APIPrefix      string
+	JWTSecret      string
+	MasterKey      []byte
+	AllowedOrigins []string
+	MaxBlobBytes   int64
+	Store          BlobStore
+	// RequestTimeout bounds JSON requests, downloads, archives, streams, backups,
+	// exports and imports are left to WriteTimeout
+	RequestTimeout time.Duration
+	// RateLimit is the sustained requests per second allowed per client IP, zero disables limiting
+	RateLimit      float64
+	RateLimitBurst int
+	PrettyJSON     bool
+	// CompressResponses gzips JSON responses for clients that accept it
+	CompressResponses bool
+	// SessionStore keeps browser sessions in "memory" or the "db"
+	SessionStore    string
+	SessionLifetime time.Duration
+	// SecureCookies restricts the session cookie to HTTPS
+	SecureCookies bool
+	// StepInterval is how often the maintenance tasks run, defaultStepInterval when zero
+	StepInterval time.Duration
+	// MultipartMemory is how much of an upload is held in memory before the rest
+	// spills to temp files, defaultMultipartMemory when zero
+	MultipartMemory int64
+	// BlobVersioning lets owners upload over a blob's name, keeping the old contents as numbered versions
+	BlobVersioning bool
+	// AutoMigrate applies pending migrations on startup, otherwise requests get 503
+	// until someone migrates the database
+	AutoMigrate bool
+	// TrashRetention is how long deleted blobs can be restored before they are purged,
+	// zero deletes them straight away
+	TrashRetention time.Duration
+	// WebhookURL is posted every blob event, signed with WebhookSecret, empty disables webhooks
+	WebhookURL    string
+	WebhookSecret string
+	// MaxDownloads is how many blobs may stream at once, zero is unlimited. Downloads
+	// past it wait up to DownloadQueueWait for a slot, then get 503.
+	MaxDownloads      int
+	DownloadQueueWait time.Duration
+	// ReadTimeout, WriteTimeout and IdleTimeout bound each connection, zero disables one.
+	// WriteTimeout covers a whole response, so it must outlast the largest download.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout is how long in flight requests get to finish once ctx is done,
+	// defaultShutdownTimeout when zero
+	ShutdownTimeout time.Duration
+}
+
+// defaultShutdownTimeout lets most transfers finish without holding up a deploy for long
+const defaultShutdownTimeout = 30 * time.Second
+
+// readHeaderTimeout drops clients that trickle in headers, whatever the other timeouts
+const readHeaderTimeout = 10 * time.Second
+
+// compressionLevel trades CPU for size on compressed JSON responses, chi's default
+const compressionLevel = 5
+
 // RunServer the service
-func RunServer(ctx context.Context, conn *sqlx.DB, serverAddr string, jwtsecret string, log *zap.SugaredLogger) error {
-	log.Infow("start api", "svc-addr", serverAddr)
-	c := &API{log}
+func RunServer(ctx context.Context, conn *sqlx.DB, serverConfig ServerConfig, log *zap.SugaredLogger) error {
+	log.Infow("start api", "svc-addr", serverConfig.Addr)
+	if serverConfig.AutoMigrate {
+		err := autoMigrate(conn, log)
+		if err != nil {
+			return err
+		}
+	}
+	r, err := newRouter(ctx, conn, serverConfig, log)
+	if err != nil {
+		return err
+	}
+	// sessions are saved by the routes that change them, LoadAndSave buffers
+	// whole responses so it can't wrap blob downloads
+	srv := &http.Server{
+		Addr:              serverConfig.Addr,
+		Handler:           r,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       serverConfig.ReadTimeout,
+		WriteTimeout:      serverConfig.WriteTimeout,
+		IdleTimeout:       serverConfig.IdleTimeout,
+	}
+	shutdownTimeout := serverConfig.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	// stop accepting connections and let in flight requests finish. Event streams
+	// and websockets end with ctx themselves, Shutdown doesn't wait on them.
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := srv.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Warnw("shutdown timed out, closing remaining connections", "timeout", shutdownTimeout, "err", err)
+			srv.Close()
+		}
+	}()
+	err = srv.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		<-stopped
+		return ctx.Err()
+	}
+	return err
+}
+
+// newRouter builds the API without binding a port, so tests can serve it with
+// httptest. Background work stops with ctx. Keep openAPIDocument in step with
+// the routes here.
+func newRouter(ctx context.Context, conn *sqlx.DB, serverConfig ServerConfig, log *zap.SugaredLogger) (http.Handler, error) {
+	apiPrefix := serverConfig.APIPrefix
+	if apiPrefix == "" {
+		apiPrefix = DefaultAPIPrefix
+	}
+	// tokens and share links are signed with it, an empty one would let anyone forge both
+	if serverConfig.JWTSecret == "" {
+		return nil, ErrJWTSecretRequired
+	}
+	sessions, err := newSessionManager(serverConfig.SessionStore, conn, apiPrefix, serverConfig.SessionLifetime, serverConfig.SecureCookies)
+	if err != nil {
+		return nil, err
+	}
+	c := &API{
+		log:       log,
+		conn:      conn,
+		jwtSecret: []byte(serverConfig.JWTSecret),
+		shareKey:  deriveShareKey([]byte(serverConfig.JWTSecret)),
+		masterKey: serverConfig.MasterKey,
+		store:     serverConfig.Store,
+		sessions:  sessions,
+		metrics:   newMetrics(),
+		events:    newEventHub(),
+		schema:    newSchemaGate(),
+		apiPrefix: apiPrefix,
+
+		maxBlobBytes:    serverConfig.MaxBlobBytes,
+		multipartMemory: serverConfig.MultipartMemory,
+		allowedOrigins:  serverConfig.AllowedOrigins,
+		prettyJSON:      serverConfig.PrettyJSON,
+		blobVersioning:  serverConfig.BlobVersioning,
+		trashRetention:  serverConfig.TrashRetention,
+	}
+	if c.multipartMemory <= 0 {
+		c.multipartMemory = defaultMultipartMemory
+	}
+	if serverConfig.MaxDownloads > 0 {
+		c.downloads = newDownloadLimiter(serverConfig.MaxDownloads, serverConfig.DownloadQueueWait)
+	}
+	if serverConfig.WebhookURL != "" {
+		c.webhooks = newWebhookSender(serverConfig.WebhookURL, serverConfig.WebhookSecret, log)
+		go c.webhooks.run(ctx)
+	}
+	tasks := c.maintenanceTasks()
+	if store, ok := sessions.Store.(*dbSessionStore); ok {
+		tasks = append(tasks, maintenanceTask{"delete expired sessions", store.deleteExpired})
+	}
+	if c.trashRetention > 0 {
+		tasks = append(tasks, maintenanceTask{"purge trashed blobs", func(ctx context.Context) error {
+			_, err := c.purgeTrashedBlobs(ctx, time.Now().Add(-c.trashRetention))
+			return err
+		}})
+	}
+	stepInterval := serverConfig.StepInterval
+	if stepInterval <= 0 {
+		stepInterval = defaultStepInterval
+	}
+	// maintenance would only fail against an old schema
+	go func() {
+		c.waitForSchema(ctx)
+		c.runScheduler(ctx, stepInterval, tasks)
+	}()
+
+	// browsers refuse credentialed responses to a wildcard origin
+	allowCredentials := true
+	for _, origin := range serverConfig.AllowedOrigins {
+		if origin == "*" {
+			log.Warnw("wildcard CORS origin, credentials disabled", "allowed-origins", serverConfig.AllowedOrigins)
+			allowCredentials = false
+		}
+	}
 
 	cors := cors.New(cors.Options{
-		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
+		AllowedOrigins:   serverConfig.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match", apiKeyHeader, idempotencyKeyHeader, expiresInHeader, blobSizeHeader, checksumHeader},
+		ExposedHeaders:   []string{"Link", "ETag", "Retry-After", maxBlobBytesHeader, checksumHeader, idempotencyReplayedHeader},
+		AllowCredentials: allowCredentials,
 		MaxAge:           300,
 	})
 
 	r := chi.NewRouter()
 	r.Use(cors.Handler)
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Route("/api", func(r chi.Router) {
+	r.Use(realIP)
+	r.Use(c.requestLogger)
+	r.Use(c.instrument)
+	r.Use(c.recoverer)
+	r.Use(c.requireSchema(apiPrefix+"/health", apiPrefix+"/ready", apiPrefix+"/version", apiPrefix+"/metrics", apiPrefix+"/openapi.json"))
+	if serverConfig.RateLimit > 0 {
+		c.limiter = newRateLimiter(serverConfig.RateLimit, serverConfig.RateLimitBurst)
+		r.Use(c.rateLimit)
+	}
+	// set before the routes so every subrouter inherits them
+	r.NotFound(c.notFoundHandler())
+	r.MethodNotAllowed(c.methodNotAllowedHandler())
+	// blob contents stay out of compress, they are gzipped at rest where it pays
+	// and compressing on the fly would break their ranges and validators
+	compress := func(next http.Handler) http.Handler { return next }
+	if serverConfig.CompressResponses {
+		compress = middleware.Compress(compressionLevel, "application/json")
+	}
+	// only JSON routes are cut off, transfers and streams run as long as they need
+	timeout := func(next http.Handler) http.Handler { return next }
+	if serverConfig.RequestTimeout > 0 {
+		timeout = middleware.Timeout(serverConfig.RequestTimeout)
+	}
+	r.Route(apiPrefix, func(r chi.Router) {
 		// Authenticated routes
 		r.Group(func(r chi.Router) {
+			r.Use(c.authMiddleware)
 			r.Get("/blobs/{blob_id}", c.blobHandler())
+			r.Head("/blobs/{blob_id}", c.blobHeadHandler())
+			r.Post("/blobs/archive", c.blobArchiveHandler())
+			r.Get("/events", c.eventsHandler(ctx))
+			r.Get("/ws", c.websocketHandler(ctx))
+			r.With(c.adminOnly).Get("/admin/backup", c.backupHandler())
+			r.With(c.adminOnly).Get("/admin/export", c.exportHandler())
+			r.With(c.adminOnly, compress).Post("/admin/import", c.withError(c.importHandler()))
+
+			r.Group(func(r chi.Router) {
+				r.Use(compress)
+				r.Use(timeout)
+				r.Get("/blobs", c.withError(c.blobListHandler()))
+				r.Get("/blobs/exists", c.withError(c.blobExistsHandler()))
+				r.Post("/blobs", c.withError(c.idempotent(c.blobUploadHandler())))
+				r.Post("/blobs/batch", c.withError(c.blobBatchUploadHandler()))
+				r.Patch("/blobs/{blob_id}", c.withError(c.blobRenameHandler()))
+				r.Get("/blobs/{blob_id}/versions", c.withError(c.blobVersionsHandler()))
+				r.Post("/blobs/{blob_id}/share", c.withError(c.blobShareHandler()))
+				r.Put("/blobs/{blob_id}/tags/{tag}", c.withError(c.blobTagAddHandler()))
+				r.Delete("/blobs/{blob_id}/tags/{tag}", c.withError(c.blobTagRemoveHandler()))
+				r.Delete("/blobs/{blob_id}", c.withError(HandlerFunc(c.blobDeleteHandler())))
+				r.Post("/blobs/{blob_id}/restore", c.withError(c.blobRestoreHandler()))
+				r.With(c.adminOnly).Delete("/blobs", c.withError(c.blobBulkDeleteHandler()))
+				r.With(c.adminOnly).Get("/admin/migrations", c.withError(c.migrationStatusHandler()))
+				r.With(c.adminOnly).Post("/admin/vacuum", c.withError(c.vacuumHandler()))
+				r.With(c.adminOnly).Get("/admin/audit", c.withError(c.auditLogHandler()))
+				r.With(c.adminOnly).Post("/admin/keys", c.withError(c.apiKeyCreateHandler()))
+			})
 		})
 
 		// Public routes
 		r.Group(func(r chi.Router) {
-			r.Get("/metrics", promhttp.Handler().ServeHTTP)
+			r.Get("/shared", c.sharedBlobHandler())
+			r.Get("/metrics", c.metrics.handler().ServeHTTP)
+			r.Options("/blobs", c.blobOptionsHandler())
+
+			r.Group(func(r chi.Router) {
+				r.Use(compress)
+				r.Use(timeout)
+				r.Post("/login", c.withError(c.loginHandler()))
+				r.With(sessions.LoadAndSave).Post("/session/login", c.withError(c.sessionLoginHandler()))
+				r.With(sessions.LoadAndSave).Post("/session/logout", c.withError(c.sessionLogoutHandler()))
+				r.Get("/health", c.withError(c.healthHandler()))
+				r.Get("/ready", c.withError(c.readyHandler()))
+				r.Get("/version", c.withError(c.versionHandler()))
+				r.Get("/openapi.json", c.openAPIHandler())
+			})
 		})
 
 	})
 
-	return http.ListenAndServe(serverAddr, sessionManager.LoadAndSave(r))
+	return r, nil
 }
 
 type API struct {
-	log *zap.SugaredLogger
+	log       *zap.SugaredLogger
+	conn      *sqlx.DB
+	jwtSecret []byte
+	shareKey  []byte
+	masterKey []byte
+	store     BlobStore
+	sessions  *scs.SessionManager
+	metrics   *metrics
+	events    *eventHub
+	webhooks  *webhookSender
+	schema    *schemaGate
+	apiPrefix string
+
+	maxBlobBytes    int64
+	multipartMemory int64
+	allowedOrigins  []string
+	limiter         *rateLimiter
+	downloads       *downloadLimiter
+	prettyJSON      bool
+	blobVersioning  bool
+	trashRetention  time.Duration
+	// vacuuming is set while a vacuum runs, so requests don't queue up behind it
+	vacuuming int32
 }
 
-// RunLoadBalancer starts Caddy
-func RunLoadBalancer(ctx context.Context, conn *sqlx.DB, loadBalancerAddr, serverAddr, rootPath string, log *zap.SugaredLogger) error {
-	log.Infow("start load balancer", "lb-addr", loadBalancerAddr, "svc-addr", serverAddr, "web", rootPath)
+// TLSConfig for the load balancer, either a certificate pair or an ACME email.
+// The zero value serves plain HTTP.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	Email    string
+}
+
+// writeGatewayErrorPage writes the JSON error body Caddy serves when the API is
+// down. Caddy sends error pages as text/html whatever they hold, clients should
+// parse the body rather than trust the content type.
+func writeGatewayErrorPage() (string, error) {
+	f, err := ioutil.TempFile("", "doco-gateway-error-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, Err(ErrAPIUnavailable, "the API is unavailable, try again shortly").JSON())
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// RunLoadBalancer starts Caddy, a zero healthCheckInterval disables upstream health checks.
+// Proxy failures are answered with gatewayErrorPage, or a JSON error when it is empty.
+func RunLoadBalancer(ctx context.Context, conn *sqlx.DB, loadBalancerAddr, serverAddr, apiPrefix, rootPath, gatewayErrorPage string, healthCheckInterval time.Duration, tlsConfig TLSConfig, log *zap.SugaredLogger) error {
+	log.Infow("start load balancer", "lb-addr", loadBalancerAddr, "svc-addr", serverAddr, "api-prefix", apiPrefix, "web", rootPath)
+	// caddy's own error for a missing root is obscure, backend only deployments hit it first
+	info, err := os.Stat(rootPath)
+	if err != nil {
+		return fmt.Errorf("web root not found: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("web root not found: %s is not a directory", rootPath)
+	}
+	if gatewayErrorPage == "" {
+		gatewayErrorPage, err = writeGatewayErrorPage()
+		if err != nil {
+			return fmt.Errorf("gateway error page: %w", err)
+		}
+		defer os.Remove(gatewayErrorPage)
+	}
+	// caddy resolves relative error pages against the web root
+	gatewayErrorPage, err = filepath.Abs(gatewayErrorPage)
+	if err != nil {
+		return fmt.Errorf("gateway error page: %w", err)
+	}
 	caddy.AppName = "Doco"
-	caddy.AppVersion = "0.0.1"
+	caddy.AppVersion = BuildVersion
 	caddy.Quiet = true
+	if tlsConfig.CertFile == "" && tlsConfig.Email != "" {
+		// accept the Let's Encrypt subscriber agreement without prompting
+		caddytls.Agreed = true
+	}
 	t := template.Must(template.New("CaddyFile").Parse(caddyfileTemplate))
 	data := map[string]string{
 		"caddyAddr": loadBalancerAddr,
 		"apiAddr":   serverAddr,
+		"apiPrefix": apiPrefix,
 		"rootPath":  rootPath,
+		"errorPage": gatewayErrorPage,
+		"tlsCert":   tlsConfig.CertFile,
+		"tlsKey":    tlsConfig.KeyFile,
+		"tlsEmail":  tlsConfig.Email,
+	}
+	if healthCheckInterval > 0 {
+		data["healthCheckInterval"] = healthCheckInterval.String()
 	}
 
 	result := &bytes.Buffer{}
-	err := t.Execute(result, data)
+	err = t.Execute(result, data)
 	if err != nil {
 		return err
 	}
@@ -185,41 +635,73 @@ func RunLoadBalancer(ctx context.Context, conn *sqlx.DB, loadBalancerAddr, serve
 	if err != nil {
 		return err
 	}
+
+	// tear caddy down with the rest of the run group
+	stopped := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		log.Infow("stop load balancer", "reason", ctx.Err())
+		stopped <- instance.Stop()
+	}()
 	instance.Wait()
+	if ctx.Err() != nil {
+		return <-stopped
+	}
 	return nil
 }
-func (c *API) checkHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+
+// notFoundHandler answers unrouted paths with the usual JSON error instead of chi's plain text
+func (c *API) notFoundHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		c.metrics.requestsTotal.WithLabelValues(strconv.Itoa(http.StatusNotFound)).Inc()
+		c.writeError(w, r, ErrRouteNotFound, http.StatusNotFound)
+	}
+	return fn
+}
+
+// methodNotAllowedHandler is notFoundHandler for a known path with the wrong method
+func (c *API) methodNotAllowedHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		c.metrics.requestsTotal.WithLabelValues(strconv.Itoa(http.StatusMethodNotAllowed)).Inc()
+		c.writeError(w, r, ErrMethodNotAllowed, http.StatusMethodNotAllowed)
+	}
+	return fn
+}
+
+// ErrNotReady is returned by the readiness probe when a dependency is unavailable
+var ErrNotReady = errors.New("not ready")
+
+// healthHandler is the liveness probe, it only shows the process is serving
+func (c *API) healthHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
 		type Response struct {
+			Status string `json:"status"`
 		}
 
-		return &Response{}, 200, nil
+		return &Response{Status: "ok"}, 200, nil
 	}
 	return fn
 
 }
 
-func (c *API) blobHandler() func(w http.ResponseWriter, r *http.Request) {
-	fn := func(w http.ResponseWriter, r *http.Request) {
-		blobFilename := chi.URLParam(r, "blob_id")
-		blob, err := db.Blobs(db.BlobWhere.FileName.EQ(blobFilename)).OneG()
-		if err != nil {
-			http.Error(w, Err(err).JSON(), http.StatusBadRequest)
-			return
+// readyHandler is the readiness probe, it fails while the database is unreachable
+// or its schema is behind this build
+func (c *API) readyHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Response struct {
+			Status string `json:"status"`
 		}
 
-		// tell the browser the returned content should be downloaded/inline
-		if blob.MimeType != "" && blob.MimeType != "unknown" {
-			w.Header().Add("Content-Type", blob.MimeType)
+		err := c.conn.PingContext(r.Context())
+		if err != nil {
+			c.log.Warnw("readiness check failed", "err", err)
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("%w: %s", ErrNotReady, err)
 		}
-		w.Header().Add("Content-Disposition", fmt.Sprintf("%s;filename=%s", "attachment", blob.FileName))
-		rdr := bytes.NewReader(blob.File)
-		http.ServeContent(w, r, blob.FileName, time.Now(), rdr)
-		return
+		err = c.schema.check()
+		if err != nil {
+			return nil, http.StatusServiceUnavailable, fmt.Errorf("%w: %s", ErrNotReady, err)
+		}
+		return &Response{Status: "ok"}, 200, nil
 	}
 	return fn
 }
-
-func (c *API) metricsHandler(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
-	return nil, 200, ErrNotImplemented
-}
@@ -0,0 +1,409 @@
+package doco
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"doco/db"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// checksumHeader carries the hex SHA-256 of the blob's plaintext
+const checksumHeader = "X-Checksum-Sha256"
+
+// blobCacheControl lets clients keep a blob forever, without versioning a blob's
+// contents never change under its name. Blobs need a token, so shared caches must
+// not store them.
+const blobCacheControl = "private, max-age=31536000, immutable"
+
+// blobColumnsWithoutFile select everything needed to serve a blob except its contents
+var blobColumnsWithoutFile = []string{
+	db.BlobColumns.ID,
+	db.BlobColumns.FileName,
+	db.BlobColumns.MimeType,
+	db.BlobColumns.FileSizeBytes,
+	db.BlobColumns.EXTENSION,
+	db.BlobColumns.Views,
+	db.BlobColumns.Archived,
+	db.BlobColumns.ArchivedAt,
+	db.BlobColumns.UpdatedAt,
+	db.BlobColumns.CreatedAt,
+	db.BlobColumns.ContentUpdatedAt,
+	db.BlobColumns.Checksum,
+	db.BlobColumns.Nonce,
+	db.BlobColumns.Compressed,
+	db.BlobColumns.SegmentSize,
+	db.BlobColumns.SealedFinal,
+	db.BlobColumns.OwnerID,
+	db.BlobColumns.ExpiresAt,
+	db.BlobColumns.DeletedAt,
+}
+
+func (c *API) blobHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		err := checkServeParams(r)
+		if err != nil {
+			c.writeError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		blobFilename := chi.URLParam(r, "blob_id")
+		blob, err := db.Blobs(
+			qm.Select(blobColumnsWithoutFile...),
+			db.BlobWhere.FileName.EQ(blobFilename),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) {
+			c.writeError(w, r, ErrBlobNotFound, http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		if blobExpired(blob, time.Now()) {
+			c.writeError(w, r, ErrBlobNotFound, http.StatusNotFound)
+			return
+		}
+		if !canAccessBlob(r, blob) {
+			c.writeError(w, r, ErrForbidden, http.StatusForbidden)
+			return
+		}
+		c.serveBlob(w, r, blob, AuditDownload)
+	}
+	return fn
+}
+
+// serveBlob writes a blob's contents, or those of the ?version= asked for. Small
+// and legacy blobs are checked against their checksum in memory, larger segmented
+// blobs stream and rely on per segment authentication. Each holds a download slot
+// while it is written, and is audited as action once it has one.
+func (c *API) serveBlob(w http.ResponseWriter, r *http.Request, blob *db.Blob, action string) {
+	release, ok := c.acquireDownload(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+	c.audit(r, action, blob.FileName)
+	blob, id, err := c.blobVersion(r, blob)
+	if errors.Is(err, ErrBlobVersionNotFound) {
+		c.writeError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	if blob.SegmentSize == 0 || blob.FileSizeBytes <= segmentSize {
+		c.serveBlobFromMemory(w, r, blob, id)
+		return
+	}
+	c.streamBlob(w, r, blob, id)
+}
+
+// blobHeadHandler describes a blob without decrypting or sending it
+func (c *API) blobHeadHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if err := checkServeParams(r); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		blobFilename := chi.URLParam(r, "blob_id")
+		blob, err := db.Blobs(
+			qm.Select(blobColumnsWithoutFile...),
+			db.BlobWhere.FileName.EQ(blobFilename),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.log.Errorw("blob head", "file_name", blobFilename, "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if blobExpired(blob, time.Now()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !canAccessBlob(r, blob) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		blob, _, err = c.blobVersion(r, blob)
+		if errors.Is(err, ErrBlobVersionNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.log.Errorw("blob head", "file_name", blobFilename, "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		c.setBlobHeaders(w, r, blob, nil)
+		if blob.Checksum != "" {
+			w.Header().Set("ETag", blobETag(blob.Checksum))
+		}
+		if blob.Compressed {
+			w.Header().Add("Vary", "Accept-Encoding")
+		}
+		w.Header().Set("Content-Length", strconv.FormatInt(blob.FileSizeBytes, 10))
+		w.Header().Set("Last-Modified", blobModTime(blob).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}
+	return fn
+}
+
+// ErrContentTypeNotAllowed is returned for a ?content_type= outside overridableContentTypes
+var ErrContentTypeNotAllowed = errors.New("content type not allowed")
+
+// overridableContentTypes may be forced with ?content_type= and shown inline.
+// Types a browser would run script from, like text/html, are left out.
+var overridableContentTypes = map[string]bool{
+	"application/json":          true,
+	"application/octet-stream":  true,
+	"application/pdf":           true,
+	"image/gif":                 true,
+	"image/jpeg":                true,
+	"image/png":                 true,
+	"image/webp":                true,
+	"text/csv":                  true,
+	"text/plain; charset=utf-8": true,
+}
+
+// contentTypeOverride reads ?content_type=, which replaces the stored mime type in the
+// response only. The row is left alone, PATCH it to fix the type for good.
+func contentTypeOverride(r *http.Request) (string, error) {
+	contentType := r.URL.Query().Get("content_type")
+	if contentType != "" && !overridableContentTypes[contentType] {
+		return "", fmt.Errorf("%w: %q", ErrContentTypeNotAllowed, contentType)
+	}
+	return contentType, nil
+}
+
+// ErrInvalidDisposition is returned for a ?disposition= other than inline or attachment
+var ErrInvalidDisposition = errors.New("disposition must be inline or attachment")
+
+// dispositionParam reads ?disposition=, blobs download as attachments unless inline is asked for
+func dispositionParam(r *http.Request) (string, error) {
+	switch disposition := r.URL.Query().Get("disposition"); disposition {
+	case "", "attachment":
+		return "attachment", nil
+	case "inline":
+		return disposition, nil
+	}
+	return "", ErrInvalidDisposition
+}
+
+// checkServeParams rejects bad query params before a blob is looked up
+func checkServeParams(r *http.Request) error {
+	_, err := contentTypeOverride(r)
+	if err != nil {
+		return err
+	}
+	_, err = dispositionParam(r)
+	if err != nil {
+		return err
+	}
+	_, err = versionParam(r)
+	return err
+}
+
+// quotedStringEscaper escapes a value for an HTTP quoted-string
+var quotedStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// contentDisposition builds the header value per RFC 6266. The quoted filename is an
+// ASCII fallback, names with anything else also get a UTF-8 filename* which
+// browsers prefer.
+func contentDisposition(disposition, fileName string) string {
+	ascii := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '_'
+		}
+		return r
+	}, fileName)
+	value := disposition + `; filename="` + quotedStringEscaper.Replace(ascii) + `"`
+	if ascii != fileName {
+		value += "; filename*=UTF-8''" + encodeExtValue(fileName)
+	}
+	return value
+}
+
+// encodeExtValue percent encodes every byte outside RFC 5987's attr-char
+func encodeExtValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'a' <= ch && ch <= 'z', 'A' <= ch && ch <= 'Z', '0' <= ch && ch <= '9',
+			strings.IndexByte("!#$&+-.^_`|~", ch) >= 0:
+			b.WriteByte(ch)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[ch>>4])
+			b.WriteByte(hex[ch&0x0f])
+		}
+	}
+	return b.String()
+}
+
+func (c *API) setBlobHeaders(w http.ResponseWriter, r *http.Request, blob *db.Blob, plaintext []byte) {
+	contentType, _ := contentTypeOverride(r)
+	if contentType == "" {
+		contentType = detectMimeType(blob.MimeType, blob.FileName, plaintext)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// only types that can't run script are shown inline, anything else still downloads
+	disposition, _ := dispositionParam(r)
+	if !overridableContentTypes[contentType] {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", contentDisposition(disposition, blob.FileName))
+	if blob.Checksum != "" {
+		w.Header().Set(checksumHeader, blob.Checksum)
+	}
+	if c.blobVersioning && r.URL.Query().Get("version") == "" {
+		w.Header().Set("Cache-Control", latestBlobCacheControl)
+	} else {
+		w.Header().Set("Cache-Control", blobCacheControl)
+	}
+}
+
+func (c *API) serveBlobFromMemory(w http.ResponseWriter, r *http.Request, blob *db.Blob, id string) {
+	body, err := openBlob(r.Context(), c.store, id, c.masterKey, blob)
+	if err != nil {
+		c.writeError(w, r, fmt.Errorf("open %s: %w", blob.FileName, err), http.StatusInternalServerError)
+		return
+	}
+	plaintext := body
+	if blob.Compressed {
+		plaintext, err = gunzipBytes(body)
+		if err != nil {
+			c.writeError(w, r, fmt.Errorf("decompress %s: %w", blob.FileName, err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// rows written before checksums were recorded have nothing to verify against
+	checksum := blobChecksum(plaintext)
+	if blob.Checksum != "" && blob.Checksum != checksum {
+		err = fmt.Errorf("%w: %s expected %s got %s", ErrChecksumMismatch, blob.FileName, blob.Checksum, checksum)
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.setBlobHeaders(w, r, blob, plaintext)
+	etag := blobETag(checksum)
+	if blob.Compressed {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			// the gzip representation needs its own validator
+			w.Header().Set("Content-Encoding", "gzip")
+			etag = blobETag(checksum + "-gzip")
+		} else {
+			body = plaintext
+		}
+	}
+	// ServeContent handles Range, If-Range and If-None-Match once ETag and a stable modtime are set
+	w.Header().Set("ETag", etag)
+	rdr := bytes.NewReader(body)
+	cw := &countingWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, blob.FileName, blobModTime(blob), rdr)
+	c.metrics.blobDownloadsTotal.Inc()
+	c.metrics.blobBytesServedTotal.Add(float64(cw.n))
+}
+
+// streamBlob decrypts the blob segment by segment straight from the store,
+// so memory use is bounded by the segment size rather than the blob size
+func (c *API) streamBlob(w http.ResponseWriter, r *http.Request, blob *db.Blob, id string) {
+	rc, err := c.store.Get(r.Context(), id)
+	if err != nil {
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+	src, size, err := readerAt(rc)
+	if err != nil {
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	segments, err := openSegments(c.masterKey, blob, src, size)
+	if err != nil {
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	// stop decrypting as soon as the client goes away or the request times out
+	stored := &contextReadSeeker{ctx: r.Context(), ReadSeeker: segments}
+
+	c.setBlobHeaders(w, r, blob, nil)
+	etag := blobETag(blob.Checksum)
+	if blob.Compressed {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r) {
+			c.streamInflated(w, r, blob, stored, etag)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		etag = blobETag(blob.Checksum + "-gzip")
+	}
+	w.Header().Set("ETag", etag)
+	cw := &countingWriter{ResponseWriter: w}
+	http.ServeContent(cw, r, blob.FileName, blobModTime(blob), stored)
+	c.metrics.blobDownloadsTotal.Inc()
+	c.metrics.blobBytesServedTotal.Add(float64(cw.n))
+}
+
+// streamInflated gunzips on the fly for clients without gzip support. The
+// inflated stream can't seek, so ranges aren't offered on this path.
+func (c *API) streamInflated(w http.ResponseWriter, r *http.Request, blob *db.Blob, stored io.Reader, etag string) {
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	zr, err := gzip.NewReader(stored)
+	if err != nil {
+		c.writeError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	defer zr.Close()
+
+	w.Header().Set("Accept-Ranges", "none")
+	w.Header().Set("Content-Length", strconv.FormatInt(blob.FileSizeBytes, 10))
+	w.Header().Set("Last-Modified", blobModTime(blob).UTC().Format(http.TimeFormat))
+	cw := &countingWriter{ResponseWriter: w}
+	_, err = io.Copy(cw, zr)
+	if err != nil {
+		c.log.Errorw("blob stream", "file_name", blob.FileName, "err", err)
+	}
+	c.metrics.blobDownloadsTotal.Inc()
+	c.metrics.blobBytesServedTotal.Add(float64(cw.n))
+}
+
+// contextReadSeeker fails reads once its context is done, so a stalled or
+// cancelled download releases the connection instead of running to the end
+type contextReadSeeker struct {
+	ctx context.Context
+	io.ReadSeeker
+}
+
+func (c *contextReadSeeker) Read(p []byte) (int, error) {
+	err := c.ctx.Err()
+	if err != nil {
+		return 0, err
+	}
+	return c.ReadSeeker.Read(p)
+}
@@ -0,0 +1,27 @@
+package doco
+
+import "testing"
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		name        string
+		disposition string
+		fileName    string
+		want        string
+	}{
+		{"plain", "attachment", "report.pdf", `attachment; filename="report.pdf"`},
+		{"inline", "inline", "cat.png", `inline; filename="cat.png"`},
+		{"quote and backslash escaped", "attachment", `a"b\c.txt`, `attachment; filename="a\"b\\c.txt"`},
+		{"unicode gets filename*", "attachment", "résumé.pdf", `attachment; filename="r_sum_.pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9.pdf`},
+		{"control characters replaced", "attachment", "a\r\nb.txt", `attachment; filename="a__b.txt"; filename*=UTF-8''a%0D%0Ab.txt`},
+		{"ascii punctuation kept", "attachment", "a b;c.txt", `attachment; filename="a b;c.txt"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := contentDisposition(tt.disposition, tt.fileName)
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package doco
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrTooManyDownloads is returned when every download slot stayed busy for the whole queue wait
+var ErrTooManyDownloads = errors.New("too many downloads in progress, try again shortly")
+
+// downloadLimiter caps how many blobs stream at once, so a burst of downloads
+// queues instead of saturating the database and disk
+type downloadLimiter struct {
+	slots chan struct{}
+	wait  time.Duration
+}
+
+func newDownloadLimiter(max int, wait time.Duration) *downloadLimiter {
+	return &downloadLimiter{slots: make(chan struct{}, max), wait: wait}
+}
+
+// acquire waits up to the queue wait for a slot, returning the func that frees it
+func (l *downloadLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() { <-l.slots }
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if l.wait <= 0 {
+		return nil, ErrTooManyDownloads
+	}
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, ErrTooManyDownloads
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// acquireDownload takes a download slot, answering 503 with Retry-After and
+// returning false when none frees up in time. Without a limit it always succeeds.
+func (c *API) acquireDownload(w http.ResponseWriter, r *http.Request) (func(), bool) {
+	if c.downloads == nil {
+		return func() {}, true
+	}
+	release, err := c.downloads.acquire(r.Context())
+	if err != nil {
+		retryAfter := math.Max(1, math.Ceil(c.downloads.wait.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter)))
+		c.metrics.blobDownloadsRejectedTotal.Inc()
+		c.writeError(w, r, err, http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return release, true
+}
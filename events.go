@@ -0,0 +1,172 @@
+package doco
+
+import (
+	"context"
+	"doco/db"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+// ErrStreamingUnsupported is returned when the response can't be flushed as events happen
+var ErrStreamingUnsupported = errors.New("streaming unsupported")
+
+// Blob lifecycle event types
+const (
+	EventBlobCreated  = "blob.created"
+	EventBlobDeleted  = "blob.deleted"
+	EventBlobRestored = "blob.restored"
+)
+
+const (
+	// eventBuffer is how far a subscriber may fall behind before it is dropped
+	eventBuffer = 64
+	// eventKeepalive is how often an idle stream gets a comment, so proxies don't time it out
+	eventKeepalive = 30 * time.Second
+	// eventRetry is how long EventSource clients wait before reconnecting a closed stream
+	eventRetry = 3 * time.Second
+)
+
+// Event is a blob lifecycle notification pushed to subscribers
+type Event struct {
+	Type     string `json:"type"`
+	FileName string `json:"file_name"`
+	// Version is set when an upload became a new version of an existing blob
+	Version int64     `json:"version,omitempty"`
+	At      time.Time `json:"at"`
+
+	ownerID null.Int64
+}
+
+// visibleTo reports whether claims may see e, admins see every blob's events
+func (e *Event) visibleTo(claims *Claims) bool {
+	return claims.Admin || e.ownerID.Valid && e.ownerID.Int64 == claims.UserID
+}
+
+// eventHub fans events out to subscribers in this process
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan *Event]bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: map[chan *Event]bool{}}
+}
+
+// subscribe returns a channel of every event published from now on and a func
+// to stop. The channel is closed if the subscriber falls too far behind.
+func (h *eventHub) subscribe() (<-chan *Event, func()) {
+	ch := make(chan *Event, eventBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = true
+	h.mu.Unlock()
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.subscribers[ch] {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publish never blocks the handler publishing. A subscriber with a full buffer is
+// dropped rather than silently missing events, its client reconnects and resyncs.
+func (h *eventHub) publish(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publishBlob tells subscribers, and the webhook if one is set, about a change to blob
+func (c *API) publishBlob(eventType string, blob *db.Blob, version int64) {
+	e := &Event{
+		Type:     eventType,
+		FileName: blob.FileName,
+		Version:  version,
+		At:       time.Now().UTC(),
+		ownerID:  blob.OwnerID,
+	}
+	c.events.publish(e)
+	if c.webhooks != nil {
+		c.webhooks.send(e)
+	}
+}
+
+// eventsHandler streams blob events as server-sent events, the caller's own
+// blobs' or every blob's for admins. It runs until the client goes away, the
+// request times out or ctx is done, EventSource then reconnects by itself.
+func (c *API) eventsHandler(ctx context.Context) func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			c.writeError(w, r, ErrStreamingUnsupported, http.StatusInternalServerError)
+			return
+		}
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			c.writeError(w, r, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+		events, unsubscribe := c.events.subscribe()
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-store")
+		// stop nginx style proxies from buffering the stream
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		_, err := fmt.Fprintf(w, "retry: %d\n\n", eventRetry.Milliseconds())
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+
+		keepalive := time.NewTicker(eventKeepalive)
+		defer keepalive.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ctx.Done():
+				return
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				if !e.visibleTo(claims) {
+					continue
+				}
+				b, err := json.Marshal(e)
+				if err != nil {
+					c.log.Errorw("event", "type", e.Type, "err", err)
+					continue
+				}
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, b)
+				if err != nil {
+					return
+				}
+			case <-keepalive.C:
+				_, err = fmt.Fprint(w, ": keepalive\n\n")
+				if err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+	return fn
+}
@@ -0,0 +1,73 @@
+package doco
+
+import (
+	"context"
+	"doco/db"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// expiresInHeader sets an upload's lifetime in seconds, the expires_in form field does the same
+const expiresInHeader = "X-Expires-In"
+
+// maxBlobLifetime bounds X-Expires-In, longer lived blobs should just not expire
+const maxBlobLifetime = 365 * 24 * time.Hour
+
+// blobExpiresAt reads an upload's optional lifetime, blobs without one are kept until deleted
+func blobExpiresAt(r *http.Request, now time.Time) (null.Time, error) {
+	v := r.Header.Get(expiresInHeader)
+	if v == "" {
+		v = r.FormValue("expires_in")
+	}
+	if v == "" {
+		return null.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seconds <= 0 || seconds > int64(maxBlobLifetime/time.Second) {
+		message := fmt.Sprintf("must be between 1 and %d seconds", int64(maxBlobLifetime/time.Second))
+		return null.Time{}, ValidationErr(map[string]string{"expires_in": message})
+	}
+	return null.TimeFrom(now.Add(time.Duration(seconds) * time.Second).UTC()), nil
+}
+
+// blobExpired reports whether a blob is past its expiry. It is served as missing
+// until the scheduler gets to it.
+func blobExpired(blob *db.Blob, now time.Time) bool {
+	return blob.ExpiresAt.Valid && !blob.ExpiresAt.Time.After(now)
+}
+
+// notExpired filters out blobs past their expiry, qualified for queries joining tags
+func notExpired(now time.Time) qm.QueryMod {
+	column := db.TableNames.Blobs + "." + db.BlobColumns.ExpiresAt
+	return qm.Where("("+column+" IS NULL OR "+column+" > ?)", now.UTC())
+}
+
+// deleteExpiredBlobs removes blobs past their expiry and their stored contents,
+// narrowed by filters, returning how many went
+func (c *API) deleteExpiredBlobs(ctx context.Context, now time.Time, filters ...qm.QueryMod) (int, error) {
+	blobs, err := db.Blobs(append([]qm.QueryMod{
+		qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName, db.BlobColumns.OwnerID),
+		db.BlobWhere.ExpiresAt.LTE(null.TimeFrom(now.UTC())),
+	}, filters...)...).AllG(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("expired blobs: %w", err)
+	}
+	for i, blob := range blobs {
+		err = c.deleteBlobContents(ctx, blob)
+		if err != nil {
+			return i, fmt.Errorf("expire %s: %w", blob.FileName, err)
+		}
+		_, err = blob.DeleteG(ctx)
+		if err != nil {
+			return i, fmt.Errorf("expire %s: %w", blob.FileName, err)
+		}
+		c.publishBlob(EventBlobDeleted, blob, 0)
+		c.log.Infow("blob expired", "file_name", blob.FileName)
+	}
+	return len(blobs), nil
+}
@@ -0,0 +1,131 @@
+package doco
+
+import (
+	"doco/db"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// exportBatchSize is how many blobs are read per query while exporting
+const exportBatchSize = 500
+
+// BlobRecord is one blob's metadata as exported and imported, one per line.
+// Contents aren't included, they are copied with the blob store. The owner
+// and tags are named rather than numbered so records carry between instances.
+type BlobRecord struct {
+	ID            int64      `json:"id"`
+	FileName      string     `json:"file_name"`
+	MimeType      string     `json:"mime_type"`
+	FileSizeBytes int64      `json:"file_size_bytes"`
+	Extension     string     `json:"extension"`
+	Checksum      string     `json:"checksum"`
+	Nonce         []byte     `json:"nonce"`
+	Compressed    bool       `json:"compressed"`
+	SegmentSize   int64      `json:"segment_size"`
+	SealedFinal   bool       `json:"sealed_final"`
+	Views         null.Int64 `json:"views"`
+	Archived      bool       `json:"archived"`
+	ArchivedAt    null.Time  `json:"archived_at"`
+	Owner         string     `json:"owner,omitempty"`
+	Tags          []string   `json:"tags"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	// ContentUpdatedAt is set once versioning replaced the blob's first contents
+	ContentUpdatedAt null.Time `json:"content_updated_at"`
+	ExpiresAt        null.Time `json:"expires_at"`
+	DeletedAt        null.Time `json:"deleted_at"`
+}
+
+// newBlobRecord describes blob, its owner and tags must have been loaded
+func newBlobRecord(blob *db.Blob) *BlobRecord {
+	record := &BlobRecord{
+		ID:               blob.ID.Int64,
+		FileName:         blob.FileName,
+		MimeType:         blob.MimeType,
+		FileSizeBytes:    blob.FileSizeBytes,
+		Extension:        blob.EXTENSION,
+		Checksum:         blob.Checksum,
+		Nonce:            blob.Nonce,
+		Compressed:       blob.Compressed,
+		SegmentSize:      blob.SegmentSize,
+		SealedFinal:      blob.SealedFinal,
+		Views:            blob.Views,
+		Archived:         blob.Archived,
+		ArchivedAt:       blob.ArchivedAt,
+		Tags:             []string{},
+		CreatedAt:        blob.CreatedAt,
+		UpdatedAt:        blob.UpdatedAt,
+		ContentUpdatedAt: blob.ContentUpdatedAt,
+		ExpiresAt:        blob.ExpiresAt,
+		DeletedAt:        blob.DeletedAt,
+	}
+	if blob.R != nil {
+		if blob.R.Owner != nil {
+			record.Owner = blob.R.Owner.Username
+		}
+		for _, tag := range blob.R.Tags {
+			record.Tags = append(record.Tags, tag.Name)
+		}
+	}
+	return record
+}
+
+// exportHandler streams every blob's metadata as newline delimited JSON, in ID
+// order. Blobs are read a batch at a time, so neither side holds the whole set.
+// Once the first line is sent the status can't change, a later failure cuts the
+// export short and is only logged.
+func (c *API) exportHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		flusher, _ := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+
+		exported := 0
+		after := int64(0)
+		for {
+			blobs, err := db.Blobs(
+				qm.Select(blobColumnsWithoutFile...),
+				db.BlobWhere.ID.GT(null.Int64From(after)),
+				qm.OrderBy(db.BlobColumns.ID),
+				qm.Limit(exportBatchSize),
+				qm.Load(db.BlobRels.Owner),
+				qm.Load(db.BlobRels.Tags),
+			).AllG(r.Context())
+			if err != nil {
+				if after == 0 {
+					c.writeError(w, r, err, http.StatusInternalServerError)
+					return
+				}
+				c.log.Errorw("blob export cut short", "exported", exported, "request_id", middleware.GetReqID(r.Context()), "err", err)
+				return
+			}
+			if after == 0 {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.Header().Set("Content-Disposition", contentDisposition("attachment", "doco-"+time.Now().UTC().Format("20060102T150405Z")+".ndjson"))
+				w.Header().Set("Cache-Control", "no-store")
+			}
+			for _, blob := range blobs {
+				err = enc.Encode(newBlobRecord(blob))
+				if err != nil {
+					c.log.Errorw("blob export cut short", "exported", exported, "request_id", middleware.GetReqID(r.Context()), "err", err)
+					return
+				}
+				exported++
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			if len(blobs) < exportBatchSize {
+				break
+			}
+			after = blobs[len(blobs)-1].ID.Int64
+		}
+		c.audit(r, AuditExport, "")
+		c.log.Infow("blobs exported", "blobs", exported)
+	}
+	return fn
+}
@@ -1,4 +1,4 @@
 package doco
 
-//go:generate ./bin/go-bindata -prefix migrations/ -pkg bindata -nocompress -o ./bindata/bindata.go migrations
+//go:generate ./bin/go-bindata -prefix migrations/ -pkg bindata -nocompress -o ./bindata/bindata.go migrations/...
 //go:generate ./bin/sqlboiler ./bin/sqlboiler-sqlite3 --wipe
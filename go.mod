@@ -7,13 +7,16 @@ require (
 	github.com/alexedwards/scs/v2 v2.2.0
 	github.com/bxcodec/faker/v3 v3.2.0
 	github.com/caddyserver/caddy v1.0.4
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/friendsofgo/errors v0.9.2 // indirect
 	github.com/go-chi/chi v4.0.3+incompatible
 	github.com/go-chi/cors v1.0.0
 	github.com/gofrs/uuid v3.2.0+incompatible
+	github.com/gorilla/websocket v1.4.0
 	github.com/golang-migrate/migrate/v4 v4.8.0
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/kelseyhightower/envconfig v1.4.0
+	github.com/lib/pq v1.0.0
 	github.com/mattn/go-sqlite3 v2.0.2+incompatible
 	github.com/oklog/run v1.1.0
 	github.com/pkg/errors v0.9.1
@@ -23,4 +26,5 @@ require (
 	github.com/volatiletech/null v8.0.0+incompatible
 	github.com/volatiletech/sqlboiler v3.6.1+incompatible
 	go.uber.org/zap v1.13.0
+	golang.org/x/crypto v0.0.0-20190911031432-227b76d455e7
 )
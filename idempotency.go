@@ -0,0 +1,212 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// ErrIdempotencyInProgress is returned when a retry arrives before the original request finished
+var ErrIdempotencyInProgress = errors.New("a request with this idempotency key is in progress")
+
+// ErrIdempotencyKeyReused is returned when a key comes back with a different request than it was first used for
+var ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotencyReplayedHeader marks a response replayed from an earlier request
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyTTL         = 24 * time.Hour
+	maxIdempotencyKeyLength   = 255
+)
+
+// idempotent lets clients retry next safely. The first request with an Idempotency-Key
+// reserves it, and once it succeeds its response is replayed to repeats for a day.
+// A repeat must be the same request, its method, URL and body are fingerprinted
+// and a different one gets 422. Failures release the key, so the retry runs again.
+// Keys are scoped to the caller.
+func (c *API) idempotent(next HandlerFunc) HandlerFunc {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			return next(w, r)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			message := fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength)
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{idempotencyKeyHeader: message})
+		}
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			return nil, http.StatusUnauthorized, ErrUnauthorized
+		}
+
+		fingerprint := newRequestFingerprint(r)
+		reserved, err := c.reserveIdempotencyKey(r.Context(), claims.UserID, key, time.Now())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !reserved {
+			code, response, requestHash, err := c.idempotentResponse(r.Context(), claims.UserID, key)
+			if errors.Is(err, ErrIdempotencyInProgress) {
+				return nil, http.StatusConflict, err
+			}
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			// keys saved before requests were fingerprinted have nothing to compare
+			if requestHash != nil {
+				_, err = io.Copy(fingerprint, io.LimitReader(r.Body, c.maxBlobBytes+multipartOverhead))
+				if err != nil {
+					return nil, http.StatusBadRequest, err
+				}
+				if !bytes.Equal(fingerprint.Sum(), requestHash) {
+					return nil, http.StatusUnprocessableEntity, ErrIdempotencyKeyReused
+				}
+			}
+			w.Header().Set(idempotencyReplayedHeader, "true")
+			if response == nil {
+				return nil, code, nil
+			}
+			return json.RawMessage(response), code, nil
+		}
+
+		// fingerprint the body as next reads it, then whatever it left unread
+		body := r.Body
+		r.Body = ioutil.NopCloser(io.TeeReader(body, fingerprint))
+		result, code, err := next(w, r)
+		if err != nil {
+			c.releaseIdempotencyKey(claims.UserID, key)
+			return result, code, err
+		}
+		io.Copy(fingerprint, io.LimitReader(body, multipartOverhead))
+		if code == 0 {
+			code = http.StatusOK
+		}
+		var response []byte
+		if result != nil {
+			response, err = json.Marshal(result)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+		// like release, saving must not be cancelled with the request
+		_, err = c.conn.Exec(c.conn.Rebind(`UPDATE idempotency_keys SET status_code = ?, response = ?, request_hash = ? WHERE user_id = ? AND key = ?`),
+			code, response, fingerprint.Sum(), claims.UserID, key)
+		if err != nil {
+			// the work is done, so answer anyway. A retry will see the key in progress until it expires.
+			c.log.Errorw("idempotency key not saved", "key", key, "err", err)
+		}
+		return result, code, nil
+	}
+	return fn
+}
+
+// reserveIdempotencyKey claims a key for a new request, reporting false when it was already used
+func (c *API) reserveIdempotencyKey(ctx context.Context, userID int64, key string, now time.Time) (bool, error) {
+	_, err := c.conn.ExecContext(ctx, c.conn.Rebind(`DELETE FROM idempotency_keys WHERE user_id = ? AND key = ? AND expires_at <= ?`), userID, key, now.UTC())
+	if err != nil {
+		return false, fmt.Errorf("idempotency key: %w", err)
+	}
+	result, err := c.conn.ExecContext(ctx, c.conn.Rebind(`INSERT INTO idempotency_keys (user_id, key, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT (user_id, key) DO NOTHING`), userID, key, now.Add(idempotencyKeyTTL).UTC())
+	if err != nil {
+		return false, fmt.Errorf("idempotency key: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("idempotency key: %w", err)
+	}
+	return n == 1, nil
+}
+
+// idempotentResponse returns the saved status code, body and request fingerprint for a used key
+func (c *API) idempotentResponse(ctx context.Context, userID int64, key string) (int, []byte, []byte, error) {
+	var code sql.NullInt64
+	var response, requestHash []byte
+	err := c.conn.QueryRowContext(ctx, c.conn.Rebind(`SELECT status_code, response, request_hash FROM idempotency_keys WHERE user_id = ? AND key = ?`), userID, key).
+		Scan(&code, &response, &requestHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		// released by a failed request between our reserve and this read
+		return 0, nil, nil, ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("idempotency key: %w", err)
+	}
+	if !code.Valid {
+		return 0, nil, nil, ErrIdempotencyInProgress
+	}
+	return int(code.Int64), response, requestHash, nil
+}
+
+// requestFingerprint hashes a request's method, URL, media type and body. Clients
+// pick a new random multipart boundary for each attempt, so it is left out of
+// the body wherever it appears.
+type requestFingerprint struct {
+	h        hash.Hash
+	boundary []byte
+	// pending could be the start of a boundary split across writes
+	pending []byte
+}
+
+func newRequestFingerprint(r *http.Request) *requestFingerprint {
+	f := &requestFingerprint{h: sha256.New()}
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	f.boundary = []byte(params["boundary"])
+	fmt.Fprintf(f.h, "%s\n%s\n%s\n", r.Method, r.URL.RequestURI(), mediaType)
+	return f
+}
+
+func (f *requestFingerprint) Write(p []byte) (int, error) {
+	if len(f.boundary) == 0 {
+		return f.h.Write(p)
+	}
+	f.pending = append(f.pending, p...)
+	for {
+		i := bytes.Index(f.pending, f.boundary)
+		if i < 0 {
+			break
+		}
+		f.h.Write(f.pending[:i])
+		f.pending = f.pending[i+len(f.boundary):]
+	}
+	if keep := len(f.boundary) - 1; len(f.pending) > keep {
+		f.h.Write(f.pending[:len(f.pending)-keep])
+		f.pending = append([]byte{}, f.pending[len(f.pending)-keep:]...)
+	}
+	return len(p), nil
+}
+
+// Sum returns the fingerprint of everything written
+func (f *requestFingerprint) Sum() []byte {
+	f.h.Write(f.pending)
+	f.pending = nil
+	return f.h.Sum(nil)
+}
+
+// releaseIdempotencyKey forgets a key whose request failed. It runs even if the
+// request was cancelled, otherwise the key would stay in progress until it expires.
+func (c *API) releaseIdempotencyKey(userID int64, key string) {
+	_, err := c.conn.Exec(c.conn.Rebind(`DELETE FROM idempotency_keys WHERE user_id = ? AND key = ?`), userID, key)
+	if err != nil {
+		c.log.Errorw("idempotency key not released", "key", key, "err", err)
+	}
+}
+
+// deleteExpiredIdempotencyKeys forgets keys past their TTL, a maintenance task
+func (c *API) deleteExpiredIdempotencyKeys(ctx context.Context) error {
+	_, err := c.conn.ExecContext(ctx, c.conn.Rebind(`DELETE FROM idempotency_keys WHERE expires_at <= ?`), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("idempotency keys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package doco
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// multipartUpload builds an upload body with a boundary of its own
+func multipartUpload(t *testing.T, fileName string, contents []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	part, err := mw.CreateFormFile("file", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(contents)
+	mw.Close()
+	return body, mw.FormDataContentType()
+}
+
+func fingerprint(t *testing.T, method, url string, body []byte, contentType string, chunk int) []byte {
+	t.Helper()
+	r := httptest.NewRequest(method, url, nil)
+	r.Header.Set("Content-Type", contentType)
+	f := newRequestFingerprint(r)
+	for len(body) > 0 {
+		n := chunk
+		if n > len(body) {
+			n = len(body)
+		}
+		f.Write(body[:n])
+		body = body[n:]
+	}
+	return f.Sum()
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	first, firstType := multipartUpload(t, "a.txt", []byte("hello"))
+	retry, retryType := multipartUpload(t, "a.txt", []byte("hello"))
+	other, otherType := multipartUpload(t, "a.txt", []byte("hellO"))
+	if firstType == retryType {
+		t.Fatal("expected each body to get its own boundary")
+	}
+	want := fingerprint(t, http.MethodPost, "/api/blobs", first.Bytes(), firstType, 1<<20)
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		body        []byte
+		contentType string
+		chunk       int
+		same        bool
+	}{
+		{"same body", http.MethodPost, "/api/blobs", first.Bytes(), firstType, 1 << 20, true},
+		{"split across writes", http.MethodPost, "/api/blobs", first.Bytes(), firstType, 3, true},
+		{"retry with a new boundary", http.MethodPost, "/api/blobs", retry.Bytes(), retryType, 7, true},
+		{"different contents", http.MethodPost, "/api/blobs", other.Bytes(), otherType, 1 << 20, false},
+		{"different url", http.MethodPost, "/api/blobs?x=1", first.Bytes(), firstType, 1 << 20, false},
+		{"different method", http.MethodPut, "/api/blobs", first.Bytes(), firstType, 1 << 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fingerprint(t, tt.method, tt.url, tt.body, tt.contentType, tt.chunk)
+			if bytes.Equal(got, want) != tt.same {
+				t.Fatalf("fingerprints equal = %v, want %v", !tt.same, tt.same)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package doco
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"doco/db"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrImportUnknownOwner is returned for a record owned by a username this instance doesn't have
+var ErrImportUnknownOwner = errors.New("owner not found, create users before importing their blobs")
+
+// ErrImportIDTaken is returned when a record's ID already belongs to a blob with another name
+var ErrImportIDTaken = errors.New("blob id already taken by another blob")
+
+const (
+	// importBatchSize is how many records share a transaction while importing
+	importBatchSize = 500
+	// maxImportLine bounds one record, real ones are well under a kilobyte
+	maxImportLine = 1 << 20
+)
+
+// Ways to handle an imported record whose file name is already taken
+const (
+	importFail    = "fail"
+	importSkip    = "skip"
+	importReplace = "replace"
+)
+
+// importLine is a record and where it was in the body, for error messages
+type importLine struct {
+	line   int
+	record *BlobRecord
+}
+
+// importer carries what a running import has learned across batches
+type importer struct {
+	onConflict string
+	owners     map[string]null.Int64
+	tags       map[string]*db.Tag
+	// stale are the store keys of replaced blobs' contents, deleted as their batch commits
+	stale []string
+
+	Imported int `json:"imported"`
+	Replaced int `json:"replaced"`
+	Skipped  int `json:"skipped"`
+}
+
+// importHandler loads newline delimited BlobRecords, as exported, in transactions
+// of importBatchSize. Records keep their IDs, so contents copied from the old
+// blob store line up. ?on_conflict= decides what happens to a record whose file
+// name is taken: fail (default) stops the import, skip keeps the existing blob,
+// replace deletes it, with its versions and contents, and imports the record in
+// its place. A failure stops at that line, batches before it stay imported.
+func (c *API) importHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		onConflict := r.URL.Query().Get("on_conflict")
+		switch onConflict {
+		case "":
+			onConflict = importFail
+		case importFail, importSkip, importReplace:
+		default:
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{"on_conflict": "must be fail, skip or replace"})
+		}
+		imp := &importer{
+			onConflict: onConflict,
+			owners:     map[string]null.Int64{},
+			tags:       map[string]*db.Tag{},
+		}
+		// exported timestamps are kept rather than reset to now
+		ctx := boil.SkipTimestamps(r.Context())
+
+		batch := []*importLine{}
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(make([]byte, 64<<10), maxImportLine)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			record := &BlobRecord{}
+			err := json.Unmarshal(text, record)
+			if err != nil {
+				return nil, http.StatusBadRequest, fmt.Errorf("line %d: %w", line, err)
+			}
+			if record.FileName == "" {
+				return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{fmt.Sprintf("line %d", line): "file_name is required"})
+			}
+			err = validFileName(record.FileName)
+			if err != nil {
+				return nil, http.StatusBadRequest, fmt.Errorf("line %d: %w", line, err)
+			}
+			batch = append(batch, &importLine{line, record})
+			if len(batch) < importBatchSize {
+				continue
+			}
+			code, err := c.importBatch(ctx, imp, batch)
+			if err != nil {
+				return nil, code, err
+			}
+			batch = batch[:0]
+		}
+		err := scanner.Err()
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("line %d: %w", line+1, err)
+		}
+		code, err := c.importBatch(ctx, imp, batch)
+		if err != nil {
+			return nil, code, err
+		}
+
+		if c.conn.DriverName() == DriverPostgres {
+			// explicit IDs don't advance the sequence, later inserts would collide with them
+			_, err = c.conn.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('blobs', 'id'), COALESCE((SELECT MAX(id) FROM blobs), 0) + 1, false)`)
+			if err != nil {
+				return nil, http.StatusInternalServerError, fmt.Errorf("import: %w", err)
+			}
+		}
+		c.audit(r, AuditImport, "")
+		c.log.Infow("blobs imported", "imported", imp.Imported, "replaced", imp.Replaced, "skipped", imp.Skipped)
+		return imp, http.StatusOK, nil
+	}
+	return fn
+}
+
+// importBatch imports lines in one transaction, none of them if any fails. The
+// import stops there, so what it cached from the rolled back batch isn't reused.
+func (c *API) importBatch(ctx context.Context, imp *importer, batch []*importLine) (int, error) {
+	if len(batch) == 0 {
+		return http.StatusOK, nil
+	}
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	committed := imp.Imported + imp.Replaced
+	for _, l := range batch {
+		code, err := imp.importRecord(ctx, tx, l.record)
+		if err != nil {
+			tx.Rollback()
+			return code, fmt.Errorf("line %d %s: %w, %d records imported before its batch", l.line, l.record.FileName, err, committed)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("import: %w, %d records imported before this batch", err, committed)
+	}
+	// the rows are gone, contents left behind are only logged and show up as orphans
+	for _, key := range imp.stale {
+		err = c.store.Delete(ctx, key)
+		if err != nil {
+			c.log.Warnw("replaced blob contents not deleted", "key", key, "err", err)
+		}
+	}
+	imp.stale = imp.stale[:0]
+	return http.StatusOK, nil
+}
+
+// importRecord inserts or, depending on onConflict, replaces or skips one record
+func (imp *importer) importRecord(ctx context.Context, tx boil.ContextExecutor, record *BlobRecord) (int, error) {
+	ownerID, err := imp.owner(ctx, tx, record.Owner)
+	if err != nil {
+		return http.StatusUnprocessableEntity, err
+	}
+	blob := &db.Blob{
+		FileName:         record.FileName,
+		MimeType:         record.MimeType,
+		FileSizeBytes:    record.FileSizeBytes,
+		EXTENSION:        record.Extension,
+		File:             []byte{},
+		Views:            record.Views,
+		Archived:         record.Archived,
+		ArchivedAt:       record.ArchivedAt,
+		UpdatedAt:        record.UpdatedAt,
+		CreatedAt:        record.CreatedAt,
+		ContentUpdatedAt: record.ContentUpdatedAt,
+		Checksum:         record.Checksum,
+		Nonce:            record.Nonce,
+		Compressed:       record.Compressed,
+		SegmentSize:      record.SegmentSize,
+		SealedFinal:      record.SealedFinal,
+		OwnerID:          ownerID,
+		ExpiresAt:        record.ExpiresAt,
+		DeletedAt:        record.DeletedAt,
+	}
+	if blob.Nonce == nil {
+		blob.Nonce = []byte{}
+	}
+
+	existing, err := db.Blobs(qm.Select(db.BlobColumns.ID), db.BlobWhere.FileName.EQ(record.FileName)).One(ctx, tx)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		existing = nil
+	case err != nil:
+		return http.StatusInternalServerError, err
+	case imp.onConflict == importSkip:
+		imp.Skipped++
+		return http.StatusOK, nil
+	case imp.onConflict == importReplace:
+		// the record's nonce, checksum and sizes describe the contents stored under
+		// its own ID, so the existing row goes rather than being updated in place
+		err = imp.remove(ctx, tx, existing, record.ID)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+	default:
+		return http.StatusConflict, ErrBlobExists
+	}
+
+	if record.ID > 0 {
+		taken, err := db.Blobs(db.BlobWhere.ID.EQ(null.Int64From(record.ID))).Exists(ctx, tx)
+		if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if taken {
+			return http.StatusConflict, ErrImportIDTaken
+		}
+		blob.ID = null.Int64From(record.ID)
+	}
+	err = insert(ctx, tx, blob, func() error {
+		inserted, err := db.Blobs(qm.Select(db.BlobColumns.ID), db.BlobWhere.FileName.EQ(blob.FileName)).One(ctx, tx)
+		if err != nil {
+			return err
+		}
+		blob.ID = inserted.ID
+		return nil
+	})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if existing != nil {
+		imp.Replaced++
+	} else {
+		imp.Imported++
+	}
+
+	tags := []*db.Tag{}
+	for _, name := range record.Tags {
+		tag, err := imp.tag(ctx, tx, name)
+		if err != nil {
+			return http.StatusUnprocessableEntity, err
+		}
+		tags = append(tags, tag)
+	}
+	err = blob.SetTags(ctx, tx, false, tags...)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusOK, nil
+}
+
+// remove deletes a blob being replaced by a record with ID id. Its kept versions
+// go with it, their contents and its own, unless the record reuses its ID, are
+// queued for deletion once the batch commits.
+func (imp *importer) remove(ctx context.Context, tx boil.ContextExecutor, existing *db.Blob, id int64) error {
+	versions, err := db.BlobVersions(
+		qm.Select(db.BlobVersionColumns.ID),
+		db.BlobVersionWhere.BlobID.EQ(existing.ID.Int64),
+	).All(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("blob versions: %w", err)
+	}
+	for _, v := range versions {
+		imp.stale = append(imp.stale, blobVersionKey(v))
+	}
+	if existing.ID.Int64 != id {
+		imp.stale = append(imp.stale, blobKey(existing))
+	}
+	_, err = existing.Delete(ctx, tx)
+	return err
+}
+
+// owner looks up a record's owner by username, users aren't imported so they must exist
+func (imp *importer) owner(ctx context.Context, tx boil.ContextExecutor, username string) (null.Int64, error) {
+	if username == "" {
+		return null.Int64{}, nil
+	}
+	if id, ok := imp.owners[username]; ok {
+		return id, nil
+	}
+	user, err := db.Users(qm.Select(db.UserColumns.ID), db.UserWhere.Username.EQ(username)).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return null.Int64{}, fmt.Errorf("%w: %s", ErrImportUnknownOwner, username)
+	}
+	if err != nil {
+		return null.Int64{}, err
+	}
+	imp.owners[username] = user.ID
+	return user.ID, nil
+}
+
+// tag finds or creates a tag by name
+func (imp *importer) tag(ctx context.Context, tx boil.ContextExecutor, name string) (*db.Tag, error) {
+	if tag, ok := imp.tags[name]; ok {
+		return tag, nil
+	}
+	err := validTag(name)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := db.Tags(qm.Select(db.TagColumns.ID, db.TagColumns.Name), db.TagWhere.Name.EQ(name)).One(ctx, tx)
+	if errors.Is(err, sql.ErrNoRows) {
+		tag = &db.Tag{Name: name}
+		err = insert(ctx, tx, tag, func() error {
+			inserted, err := db.Tags(qm.Select(db.TagColumns.ID), db.TagWhere.Name.EQ(name)).One(ctx, tx)
+			if err != nil {
+				return err
+			}
+			tag.ID = inserted.ID
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	imp.tags[name] = tag
+	return tag, nil
+}
@@ -1,27 +1,39 @@
 package doco
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-// NewLogToStdOut creates a new file logger
-func NewLogToStdOut(tag, version string, prod bool) *zap.SugaredLogger {
+// ParseLogLevel accepts debug, info, warn or error
+func ParseLogLevel(level string) (zapcore.Level, error) {
+	var l zapcore.Level
+	err := l.UnmarshalText([]byte(level))
+	if err != nil {
+		return l, fmt.Errorf("log level: %w", err)
+	}
+	return l, nil
+}
 
-	if prod {
-		config := zap.NewProductionConfig()
-		l, err := config.Build()
-		if err != nil {
-			panic("can't initialize zap logger: " + err.Error())
-		}
-		return l.Sugar().With("version", version).With("tag", tag)
+// NewLogToStdOut creates a new stdout logger at the given level, as JSON or coloured console output.
+// Every line carries the build version and commit.
+func NewLogToStdOut(tag, level string, json bool) *zap.SugaredLogger {
+	lvl, err := ParseLogLevel(level)
+	if err != nil {
+		panic("can't initialize zap logger: " + err.Error())
 	}
 
 	config := zap.NewDevelopmentConfig()
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	if json {
+		config = zap.NewProductionConfig()
+	}
+	config.Level = zap.NewAtomicLevelAt(lvl)
 	l, err := config.Build()
 	if err != nil {
 		panic("can't initialize zap logger: " + err.Error())
 	}
-	return l.Sugar().With("version", version).With("tag", tag)
+	return l.Sugar().With("version", BuildVersion, "commit", BuildCommit).With("tag", tag)
 }
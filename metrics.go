@@ -0,0 +1,104 @@
+package doco
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics holds the collectors for one server. Each router registers its own
+// on a fresh registry, so a second server in the same process can't panic on a
+// duplicate registration.
+type metrics struct {
+	registry *prometheus.Registry
+
+	blobDownloadsTotal         prometheus.Counter
+	blobDownloadsRejectedTotal prometheus.Counter
+	blobBytesServedTotal       prometheus.Counter
+	blobUploadsTotal           prometheus.Counter
+	requestsTotal              *prometheus.CounterVec
+	requestDuration            *prometheus.HistogramVec
+
+	// gauges refreshed by the scheduler
+	blobsStored       prometheus.Gauge
+	blobBytesStored   prometheus.Gauge
+	orphanedBlobFiles prometheus.Gauge
+}
+
+// newMetrics registers the collectors, along with the Go runtime and process ones
+// the default registry would have provided
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		blobDownloadsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "doco_blob_downloads_total",
+			Help: "Number of blob downloads served.",
+		}),
+		blobDownloadsRejectedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "doco_blob_downloads_rejected_total",
+			Help: "Number of downloads turned away with every download slot busy.",
+		}),
+		blobBytesServedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "doco_blob_bytes_served_total",
+			Help: "Number of blob bytes written to clients.",
+		}),
+		blobUploadsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "doco_blob_uploads_total",
+			Help: "Number of blobs uploaded.",
+		}),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "doco_http_requests_total",
+			Help: "Number of API requests by status code.",
+		}, []string{"code"}),
+		// blob transfers run long, so the buckets reach past the prometheus defaults
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "doco_http_request_duration_seconds",
+			Help:    "API request latency by route pattern and method.",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"route", "method"}),
+		blobsStored: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "doco_blobs_stored",
+			Help: "Number of blobs stored, as of the last maintenance step.",
+		}),
+		blobBytesStored: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "doco_blob_bytes_stored",
+			Help: "Plaintext bytes of all stored blobs, as of the last maintenance step.",
+		}),
+		orphanedBlobFiles: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "doco_orphaned_blob_files",
+			Help: "Stored contents with no blob row, as of the last maintenance step.",
+		}),
+	}
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.blobDownloadsTotal,
+		m.blobDownloadsRejectedTotal,
+		m.blobBytesServedTotal,
+		m.blobUploadsTotal,
+		m.requestsTotal,
+		m.requestDuration,
+		m.blobsStored,
+		m.blobBytesStored,
+		m.orphanedBlobFiles,
+	)
+	return m
+}
+
+// handler serves this server's collectors in the Prometheus text format
+func (m *metrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// countingWriter tallies the body bytes written through it
+type countingWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,96 @@
+package doco
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// realIP sets RemoteAddr to the client address the load balancer forwarded. Only a
+// loopback peer is trusted, that is Caddy, and only the last X-Forwarded-For entry,
+// the one Caddy appended. Earlier entries and X-Real-IP can come from the client.
+func realIP(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		peer := net.ParseIP(clientIP(r))
+		forwarded := r.Header["X-Forwarded-For"]
+		if peer != nil && peer.IsLoopback() && len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			if ip := net.ParseIP(strings.TrimSpace(hops[len(hops)-1])); ip != nil {
+				r.RemoteAddr = ip.String()
+			}
+		}
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// requestLogger logs each request through the API's zap logger
+func (c *API) requestLogger(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+		defer func() {
+			c.log.Infow("request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", ww.Status(),
+				"latency", time.Since(start),
+				"request_id", middleware.GetReqID(r.Context()),
+				"bytes", ww.BytesWritten(),
+				"remote_addr", r.RemoteAddr,
+			)
+		}()
+		next.ServeHTTP(ww, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// instrument observes request latency by route. It reads the pattern after the
+// request is routed, so labels stay bounded whatever paths clients send.
+func (c *API) instrument(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		route := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+		c.metrics.requestDuration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+	}
+	return http.HandlerFunc(fn)
+}
+
+// recoverer turns a panic into a 500 and logs it with its stack through zap,
+// where chi's Recoverer would print to stderr in its own format
+func (c *API) recoverer(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rvr := recover()
+			if rvr == nil {
+				return
+			}
+			// the server's own signal to abort a response, let it through
+			if rvr == http.ErrAbortHandler {
+				panic(rvr)
+			}
+			c.log.Errorw("panic",
+				"panic", fmt.Sprint(rvr),
+				"stack", string(debug.Stack()),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_id", middleware.GetReqID(r.Context()),
+			)
+			c.metrics.requestsTotal.WithLabelValues(strconv.Itoa(http.StatusInternalServerError)).Inc()
+			writeErrorResponse(w, r, Err(errInternal), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,43 @@
+package doco
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRealIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{"direct client", "203.0.113.9:5000", nil, "203.0.113.9:5000"},
+		{"direct client spoofing", "203.0.113.9:5000", []string{"10.0.0.1"}, "203.0.113.9:5000"},
+		{"through caddy", "127.0.0.1:5000", []string{"198.51.100.7"}, "198.51.100.7"},
+		{"through caddy over ipv6", "[::1]:5000", []string{"198.51.100.7"}, "198.51.100.7"},
+		{"client prepends a hop", "127.0.0.1:5000", []string{"10.0.0.1, 198.51.100.7"}, "198.51.100.7"},
+		{"client sends its own header", "127.0.0.1:5000", []string{"10.0.0.1", "198.51.100.7"}, "198.51.100.7"},
+		{"garbage hop", "127.0.0.1:5000", []string{"not-an-ip"}, "127.0.0.1:5000"},
+		{"loopback without header", "127.0.0.1:5000", nil, "127.0.0.1:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := realIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("X-Real-IP", "10.9.9.9")
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN checksum VARCHAR NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN nonce BLOB NOT NULL DEFAULT X'';
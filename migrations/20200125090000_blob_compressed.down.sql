@@ -0,0 +1,23 @@
+CREATE TABLE blobs_backup (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X''
+);
+
+INSERT INTO blobs_backup
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce
+FROM blobs;
+
+DROP TABLE blobs;
+ALTER TABLE blobs_backup RENAME TO blobs;
@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN compressed BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP TRIGGER blobs_created_at_immutable;
@@ -0,0 +1,6 @@
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
@@ -0,0 +1,2 @@
+DROP TABLE blobs_tags;
+DROP INDEX tags_name;
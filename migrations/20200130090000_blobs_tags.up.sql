@@ -0,0 +1,7 @@
+CREATE UNIQUE INDEX tags_name ON tags (name);
+
+CREATE TABLE blobs_tags (
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (blob_id, tag_id)
+);
@@ -0,0 +1,60 @@
+-- migrations run in a transaction, where foreign keys can't be switched off.
+-- Defer the checks instead: dropping a table leaves rows referencing it
+-- dangling until the rebuilt table is refilled.
+PRAGMA defer_foreign_keys = ON;
+
+-- blobs_tags cascades from blobs, keep its rows aside
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+
+CREATE TABLE users_backup AS
+SELECT id, username, password_hash, archived, archived_at, updated_at, created_at
+FROM users;
+
+DROP TABLE users;
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR UNIQUE NOT NULL,
+    password_hash VARCHAR NOT NULL,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO users SELECT * FROM users_backup;
+DROP TABLE users_backup;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN admin BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE blobs ADD COLUMN owner_id INTEGER REFERENCES users(id);
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
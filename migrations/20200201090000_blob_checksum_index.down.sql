@@ -0,0 +1 @@
+DROP INDEX blobs_checksum;
@@ -0,0 +1 @@
+CREATE INDEX blobs_checksum ON blobs (checksum);
@@ -0,0 +1,7 @@
+CREATE TABLE sessions (
+    token VARCHAR PRIMARY KEY,
+    data BLOB NOT NULL,
+    expiry DATETIME NOT NULL
+);
+
+CREATE INDEX sessions_expiry ON sessions (expiry);
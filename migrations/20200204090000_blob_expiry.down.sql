@@ -0,0 +1,42 @@
+-- SQLite can't drop a column, rebuild blobs without it. See 20200131090000_blob_owner.down.sql.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id)
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
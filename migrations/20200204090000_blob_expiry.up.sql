@@ -0,0 +1,3 @@
+ALTER TABLE blobs ADD COLUMN expires_at DATETIME;
+
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
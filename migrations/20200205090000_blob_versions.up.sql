@@ -0,0 +1,15 @@
+CREATE TABLE blob_versions (
+    id INTEGER PRIMARY KEY,
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BLOB NOT NULL DEFAULT X'',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
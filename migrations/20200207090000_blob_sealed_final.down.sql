@@ -0,0 +1,70 @@
+-- Blobs stored since can't be opened without this flag, roll back only before storing any.
+-- SQLite can't drop a column, rebuild blobs and blob_versions without it as in
+-- 20200206090000_blob_trash.down.sql. Dropping blobs cascades to blobs_tags and
+-- blob_versions, so both are kept aside.
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE blobs_tags_backup AS SELECT * FROM blobs_tags;
+CREATE TABLE blob_versions_backup AS
+SELECT id, blob_id, version, mime_type, file_size_bytes, extension, file, nonce, compressed, segment_size, checksum, created_at
+FROM blob_versions;
+CREATE TABLE blobs_backup AS
+SELECT id, file_name, mime_type, file_size_bytes, EXTENSION, file, views, archived, archived_at, updated_at, created_at, checksum, nonce, compressed, segment_size, owner_id, expires_at, deleted_at
+FROM blobs;
+
+DROP TABLE blobs;
+CREATE TABLE blobs (
+    id INTEGER PRIMARY KEY,
+    file_name VARCHAR UNIQUE NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    EXTENSION VARCHAR NOT NULL,
+    file BLOB NOT NULL,
+    views INTEGER DEFAULT 0,
+
+    archived BOOLEAN NOT NULL DEFAULT 0,
+    archived_at DATETIME,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    owner_id INTEGER REFERENCES users(id),
+    expires_at DATETIME,
+    deleted_at DATETIME
+);
+INSERT INTO blobs SELECT * FROM blobs_backup;
+DROP TABLE blobs_backup;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+WHEN NEW.created_at IS NOT OLD.created_at
+BEGIN
+    SELECT RAISE(ABORT, 'blobs.created_at is immutable');
+END;
+CREATE INDEX blobs_owner_id ON blobs (owner_id);
+CREATE INDEX blobs_checksum ON blobs (checksum);
+CREATE INDEX blobs_expires_at ON blobs (expires_at);
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
+
+INSERT OR IGNORE INTO blobs_tags SELECT * FROM blobs_tags_backup;
+DROP TABLE blobs_tags_backup;
+DROP TABLE blob_versions;
+CREATE TABLE blob_versions (
+    id INTEGER PRIMARY KEY,
+    blob_id INTEGER NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes INT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BLOB NOT NULL DEFAULT X'',
+    nonce BLOB NOT NULL DEFAULT X'',
+    compressed BOOLEAN NOT NULL DEFAULT 0,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
+INSERT OR IGNORE INTO blob_versions SELECT * FROM blob_versions_backup;
+DROP TABLE blob_versions_backup;
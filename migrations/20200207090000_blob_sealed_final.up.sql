@@ -0,0 +1,4 @@
+-- Blobs sealed with a distinct AAD on their last segment, so truncation is detected.
+-- Rows stored before keep 0 and are checked against their size instead.
+ALTER TABLE blobs ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE blob_versions ADD COLUMN sealed_final BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- When a blob's current contents were stored, set once versioning replaces them.
+-- created_at is immutable, so the blob's first contents leave this NULL and use it instead.
+ALTER TABLE blobs ADD COLUMN content_updated_at DATETIME;
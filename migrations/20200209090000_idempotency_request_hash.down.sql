@@ -0,0 +1,20 @@
+-- SQLite can't drop a column, rebuild the table without it. Nothing references it.
+CREATE TABLE idempotency_keys_backup AS
+SELECT user_id, key, status_code, response, expires_at, created_at
+FROM idempotency_keys;
+
+DROP TABLE idempotency_keys;
+CREATE TABLE idempotency_keys (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    key VARCHAR NOT NULL,
+    -- NULL until the first request with the key completes
+    status_code INTEGER,
+    response BLOB,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+INSERT INTO idempotency_keys SELECT * FROM idempotency_keys_backup;
+DROP TABLE idempotency_keys_backup;
+
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
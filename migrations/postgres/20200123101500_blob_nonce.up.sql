@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN nonce BYTEA NOT NULL DEFAULT '\x';
@@ -0,0 +1 @@
+ALTER TABLE blobs ADD COLUMN segment_size INTEGER NOT NULL DEFAULT 0;
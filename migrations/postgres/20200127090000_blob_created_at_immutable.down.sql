@@ -0,0 +1,2 @@
+DROP TRIGGER blobs_created_at_immutable ON blobs;
+DROP FUNCTION blobs_created_at_immutable();
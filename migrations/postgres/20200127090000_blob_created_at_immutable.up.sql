@@ -0,0 +1,11 @@
+CREATE FUNCTION blobs_created_at_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'blobs.created_at is immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER blobs_created_at_immutable
+BEFORE UPDATE OF created_at ON blobs
+FOR EACH ROW
+WHEN (NEW.created_at IS DISTINCT FROM OLD.created_at)
+EXECUTE PROCEDURE blobs_created_at_immutable();
@@ -0,0 +1,10 @@
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id),
+    username VARCHAR NOT NULL,
+    action VARCHAR NOT NULL,
+    blob_file_name VARCHAR NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at ON audit_log (created_at);
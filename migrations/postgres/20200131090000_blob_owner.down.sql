@@ -0,0 +1,3 @@
+DROP INDEX blobs_owner_id;
+ALTER TABLE blobs DROP COLUMN owner_id;
+ALTER TABLE users DROP COLUMN admin;
@@ -0,0 +1,7 @@
+CREATE TABLE sessions (
+    token VARCHAR PRIMARY KEY,
+    data BYTEA NOT NULL,
+    expiry TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX sessions_expiry ON sessions (expiry);
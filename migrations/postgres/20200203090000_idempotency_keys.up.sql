@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_keys (
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    key VARCHAR NOT NULL,
+    -- NULL until the first request with the key completes
+    status_code INTEGER,
+    response BYTEA,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at ON idempotency_keys (expires_at);
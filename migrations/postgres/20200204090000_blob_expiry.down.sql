@@ -0,0 +1,2 @@
+DROP INDEX blobs_expires_at;
+ALTER TABLE blobs DROP COLUMN expires_at;
@@ -0,0 +1,15 @@
+CREATE TABLE blob_versions (
+    id BIGSERIAL PRIMARY KEY,
+    blob_id BIGINT NOT NULL REFERENCES blobs(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    file_size_bytes BIGINT NOT NULL,
+    extension VARCHAR NOT NULL,
+    file BYTEA NOT NULL DEFAULT '\x',
+    nonce BYTEA NOT NULL DEFAULT '\x',
+    compressed BOOLEAN NOT NULL DEFAULT FALSE,
+    segment_size INTEGER NOT NULL DEFAULT 0,
+    checksum VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (blob_id, version)
+);
@@ -0,0 +1,2 @@
+DROP INDEX blobs_deleted_at;
+ALTER TABLE blobs DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE blobs ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX blobs_deleted_at ON blobs (deleted_at);
@@ -0,0 +1,3 @@
+-- Blobs stored since can't be opened without this flag, roll back only before storing any.
+ALTER TABLE blob_versions DROP COLUMN sealed_final;
+ALTER TABLE blobs DROP COLUMN sealed_final;
@@ -0,0 +1,3 @@
+-- Fingerprint of the request that used the key, a repeat must match it to get the
+-- response replayed. NULL for keys saved before, those replay as they did.
+ALTER TABLE idempotency_keys ADD COLUMN request_hash BYTEA;
@@ -0,0 +1,753 @@
+package doco
+
+import (
+	"net/http"
+	"strings"
+)
+
+// openAPIHandler serves openAPIDocument. Update the document alongside any route change in newRouter.
+func (c *API) openAPIHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		// the document is written for the default prefix
+		doc := strings.Replace(openAPIDocument, `"servers": [{"url": "`+DefaultAPIPrefix+`"}]`, `"servers": [{"url": "`+c.apiPrefix+`"}]`, 1)
+		w.Write([]byte(doc))
+	}
+	return fn
+}
+
+// openAPIDocument is the hand maintained OpenAPI 3 description of the API
+const openAPIDocument = `{
+  "openapi": "3.0.2",
+  "info": {
+    "title": "doco",
+    "version": "0.0.1",
+    "description": "Encrypted blob storage. Every path is served under the API prefix, /api unless configured otherwise. Blobs belong to the user who uploaded them, only they and admins can reach them."
+  },
+  "servers": [{"url": "/api"}],
+  "security": [{"bearerAuth": []}, {"apiKey": []}, {"sessionCookie": []}],
+  "paths": {
+    "/login": {
+      "post": {
+        "summary": "Exchange a username and password for a JWT",
+        "security": [],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/LoginRequest"}}}
+        },
+        "responses": {
+          "200": {"description": "Logged in", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/LoginResponse"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/session/login": {
+      "post": {
+        "summary": "Log in with a username and password, setting a session cookie",
+        "security": [],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/LoginRequest"}}}
+        },
+        "responses": {
+          "200": {"description": "Logged in, the doco_session cookie is set", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Session"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/session/logout": {
+      "post": {
+        "summary": "End the caller's session",
+        "security": [],
+        "responses": {
+          "204": {"description": "Logged out"}
+        }
+      }
+    },
+    "/health": {
+      "get": {
+        "summary": "Liveness probe",
+        "security": [],
+        "responses": {
+          "200": {"description": "Serving", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Status"}}}}
+        }
+      }
+    },
+    "/ready": {
+      "get": {
+        "summary": "Readiness probe, fails while the database is unreachable or its schema is behind this build",
+        "description": "Until the schema is migrated every other route but /health, /version, /metrics and /openapi.json also answers 503. Set AutoMigrate to migrate on startup instead.",
+        "security": [],
+        "responses": {
+          "200": {"description": "Ready", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Status"}}}},
+          "503": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/version": {
+      "get": {
+        "summary": "Build info of the running server",
+        "security": [],
+        "responses": {
+          "200": {"description": "Build info", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BuildInfo"}}}}
+        }
+      }
+    },
+    "/blobs": {
+      "get": {
+        "summary": "List metadata of the caller's blobs, every blob for admins, newest first",
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 0, "maximum": 200, "default": 50}},
+          {"name": "offset", "in": "query", "schema": {"type": "integer", "minimum": 0, "default": 0}},
+          {"name": "tag", "in": "query", "description": "Only blobs carrying every given tag", "schema": {"type": "array", "items": {"type": "string"}}, "style": "form", "explode": true}
+        ],
+        "responses": {
+          "200": {"description": "A page of blobs", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobList"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "post": {
+        "summary": "Upload a blob",
+        "description": "Send an Idempotency-Key to retry safely. A repeat within a day gets the first response again, marked with Idempotent-Replayed, and a repeat while the first is still running gets 409. Reusing a key for a different request, by method, URL or body, gets 422. With BlobVersioning on, uploading over one of your own blob's names keeps its old contents as a version instead of conflicting.",
+        "parameters": [
+          {"name": "Idempotency-Key", "in": "header", "schema": {"type": "string", "maxLength": 255}},
+          {"name": "X-Expires-In", "in": "header", "description": "Seconds until the blob expires. Expired blobs are served as missing and deleted every StepMinutes.", "schema": {"type": "integer", "minimum": 1, "maximum": 31536000}},
+          {"name": "X-Blob-Size", "in": "header", "description": "The file's size in bytes, the upload is rejected with 400 if fewer or more arrive", "schema": {"type": "integer", "minimum": 0}},
+          {"name": "X-Checksum-Sha256", "in": "header", "description": "Hex SHA-256 of the file, the upload is rejected with 400 if the contents don't match", "schema": {"type": "string"}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"multipart/form-data": {"schema": {"$ref": "#/components/schemas/UploadRequest"}}}
+        },
+        "responses": {
+          "200": {"description": "The caller already stored this content, the existing blob is returned and nothing new is stored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/UploadResponse"}}}},
+          "201": {"description": "Stored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/UploadResponse"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Delete the named blobs, or those carrying every tag, in one transaction. Admins only.",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobSelection"}}}
+        },
+        "responses": {
+          "200": {"description": "Deleted, named blobs that didn't exist are listed as failed", "content": {"application/json": {"schema": {"type": "object", "properties": {
+            "deleted": {"type": "integer"},
+            "failed": {"type": "array", "items": {"type": "object", "properties": {"file_name": {"type": "string"}, "error": {"type": "string"}}}}
+          }}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "options": {
+        "summary": "Discover the upload size limit",
+        "security": [],
+        "responses": {
+          "204": {"description": "Limit in the X-Max-Blob-Bytes header", "headers": {"X-Max-Blob-Bytes": {"schema": {"type": "integer"}}}}
+        }
+      }
+    },
+    "/blobs/exists": {
+      "get": {
+        "summary": "Look up one of the caller's blobs by checksum, so sync clients can skip uploads",
+        "parameters": [
+          {"name": "checksum", "in": "query", "required": true, "description": "Hex SHA-256 of the contents", "schema": {"type": "string", "pattern": "^[0-9a-fA-F]{64}$"}}
+        ],
+        "responses": {
+          "200": {"description": "Whether a blob has this content", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobExists"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/blobs/batch": {
+      "post": {
+        "summary": "Upload several blobs, all or nothing",
+        "description": "Each file is held to the same rules as a single upload. Contents the caller already stored are reported as deduplicated, and with BlobVersioning on a file can replace one of the caller's blobs as a new version. Each part may carry its own X-Blob-Size and X-Checksum-Sha256 headers.",
+        "parameters": [
+          {"name": "X-Expires-In", "in": "header", "description": "Seconds until every blob of the batch expires", "schema": {"type": "integer", "minimum": 1, "maximum": 31536000}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"multipart/form-data": {"schema": {"type": "object", "properties": {"file": {"type": "array", "items": {"type": "string", "format": "binary"}}}, "required": ["file"]}}}
+        },
+        "responses": {
+          "201": {"description": "Every file stored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BatchResponse"}}}},
+          "422": {"description": "Nothing stored, failed files carry an error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BatchResponse"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/blobs/archive": {
+      "post": {
+        "summary": "Download the named blobs, or those carrying every tag, as one zip",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobSelection"}}}
+        },
+        "responses": {
+          "200": {"description": "Zip archive streamed as it is built", "content": {"application/zip": {"schema": {"type": "string", "format": "binary"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/TooManyDownloads"}
+        }
+      }
+    },
+    "/events": {
+      "get": {
+        "summary": "Stream blob.created and blob.deleted events for the caller's blobs, every blob's for admins",
+        "description": "Server-sent events, each data line is an Event. The stream ends when the request times out or the client falls too far behind, EventSource reconnects by itself.",
+        "responses": {
+          "200": {"description": "Event stream", "content": {"text/event-stream": {"schema": {"$ref": "#/components/schemas/Event"}}}},
+          "401": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/ws": {
+      "get": {
+        "summary": "WebSocket pushing the same events as /events, one JSON Event per text message",
+        "description": "The server pings every 54 seconds and drops clients that don't answer within a minute. Messages from the client are ignored. The socket closes when the request times out, 1013 means the client fell behind, reconnect in either case.",
+        "responses": {
+          "101": {"description": "Switching to the WebSocket protocol"},
+          "400": {"description": "Not a WebSocket handshake"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"description": "Origin not allowed"}
+        }
+      }
+    },
+    "/blobs/{blob_id}": {
+      "parameters": [{"$ref": "#/components/parameters/BlobID"}],
+      "get": {
+        "summary": "Download a blob, supports Range and conditional requests",
+        "parameters": [{"$ref": "#/components/parameters/ContentType"}, {"$ref": "#/components/parameters/Disposition"}, {"$ref": "#/components/parameters/Version"}],
+        "responses": {
+          "200": {"description": "Blob contents", "content": {"*/*": {"schema": {"type": "string", "format": "binary"}}}},
+          "206": {"description": "Partial contents"},
+          "304": {"description": "Not modified"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "500": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/TooManyDownloads"}
+        }
+      },
+      "head": {
+        "summary": "Blob metadata as headers, without the contents",
+        "parameters": [{"$ref": "#/components/parameters/Version"}],
+        "responses": {
+          "200": {
+            "description": "Blob exists",
+            "headers": {
+              "Content-Length": {"schema": {"type": "integer"}},
+              "Last-Modified": {"schema": {"type": "string"}},
+              "ETag": {"schema": {"type": "string"}},
+              "X-Checksum-Sha256": {"schema": {"type": "string"}}
+            }
+          },
+          "403": {"description": "Not the blob's owner"},
+          "404": {"description": "No such blob"}
+        }
+      },
+      "patch": {
+        "summary": "Rename a blob",
+        "parameters": [{"name": "If-Match", "in": "header", "description": "Only rename if the blob still has this ETag", "schema": {"type": "string"}}],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/RenameRequest"}}}
+        },
+        "responses": {
+          "200": {"description": "Renamed", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobMetadata"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "412": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Delete a blob",
+        "description": "With TrashDays set the blob is moved to the trash instead. It is served as missing but keeps its name, and can be restored until it is purged TrashDays later.",
+        "responses": {
+          "204": {"description": "Deleted"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/blobs/{blob_id}/restore": {
+      "parameters": [{"$ref": "#/components/parameters/BlobID"}],
+      "post": {
+        "summary": "Take a deleted blob back out of the trash",
+        "responses": {
+          "200": {"description": "Restored", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/BlobMetadata"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/blobs/{blob_id}/versions": {
+      "parameters": [{"$ref": "#/components/parameters/BlobID"}],
+      "get": {
+        "summary": "List a blob's versions, newest first. Without BlobVersioning there is only ever the latest.",
+        "responses": {
+          "200": {"description": "Versions", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/BlobVersion"}}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/blobs/{blob_id}/share": {
+      "parameters": [{"$ref": "#/components/parameters/BlobID"}],
+      "post": {
+        "summary": "Mint a signed, expiring download link for someone without an account",
+        "requestBody": {
+          "required": false,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ShareRequest"}}}
+        },
+        "responses": {
+          "201": {"description": "Link minted", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ShareResponse"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/shared": {
+      "get": {
+        "summary": "Download a blob through a share link",
+        "security": [],
+        "parameters": [
+          {"name": "token", "in": "query", "required": true, "schema": {"type": "string"}},
+          {"$ref": "#/components/parameters/ContentType"},
+          {"$ref": "#/components/parameters/Disposition"},
+          {"$ref": "#/components/parameters/Version"}
+        ],
+        "responses": {
+          "200": {"description": "Blob contents", "content": {"*/*": {"schema": {"type": "string", "format": "binary"}}}},
+          "206": {"description": "Partial contents"},
+          "304": {"description": "Not modified"},
+          "403": {"description": "Tampered or expired token", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}},
+          "404": {"$ref": "#/components/responses/Error"},
+          "503": {"$ref": "#/components/responses/TooManyDownloads"}
+        }
+      }
+    },
+    "/blobs/{blob_id}/tags/{tag}": {
+      "parameters": [
+        {"$ref": "#/components/parameters/BlobID"},
+        {"name": "tag", "in": "path", "required": true, "schema": {"type": "string", "maxLength": 64}}
+      ],
+      "put": {
+        "summary": "Tag a blob",
+        "responses": {
+          "204": {"description": "Tagged"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      },
+      "delete": {
+        "summary": "Untag a blob",
+        "responses": {
+          "204": {"description": "Untagged"},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/migrations": {
+      "get": {
+        "summary": "Schema migration status, admins only",
+        "responses": {
+          "200": {"description": "Status", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/MigrationStatus"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/audit": {
+      "get": {
+        "summary": "Page through the blob audit log, newest first, admins only",
+        "parameters": [
+          {"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 0, "maximum": 200, "default": 50}},
+          {"name": "offset", "in": "query", "schema": {"type": "integer", "minimum": 0, "default": 0}},
+          {"name": "action", "in": "query", "schema": {"type": "string", "enum": ["download", "upload", "delete", "rename", "share", "shared_download", "backup"]}},
+          {"name": "from", "in": "query", "description": "Inclusive lower bound", "schema": {"type": "string", "format": "date-time"}},
+          {"name": "to", "in": "query", "description": "Exclusive upper bound", "schema": {"type": "string", "format": "date-time"}}
+        ],
+        "responses": {
+          "200": {"description": "A page of entries", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/AuditLog"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/backup": {
+      "get": {
+        "summary": "Download a consistent snapshot of a SQLite database, admins only",
+        "responses": {
+          "200": {"description": "SQLite database file", "content": {"application/vnd.sqlite3": {"schema": {"type": "string", "format": "binary"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "501": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/export": {
+      "get": {
+        "summary": "Stream every blob's metadata as newline delimited JSON, one BlobRecord per line, admins only",
+        "description": "Contents aren't included, copy the blob store alongside. Records are in ID order.",
+        "responses": {
+          "200": {"description": "Blob records", "content": {"application/x-ndjson": {"schema": {"$ref": "#/components/schemas/BlobRecord"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/import": {
+      "post": {
+        "summary": "Load newline delimited BlobRecords as exported, admins only",
+        "description": "Records keep their IDs so contents copied from the old blob store line up, and are committed 500 at a time. Owners are matched by username and must exist. A failure stops the import at that line, earlier batches stay imported.",
+        "parameters": [
+          {"name": "on_conflict", "in": "query", "description": "What to do with a record whose file name is taken: stop the import, keep the existing blob, or delete it and its contents and import the record in its place", "schema": {"type": "string", "enum": ["fail", "skip", "replace"], "default": "fail"}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/x-ndjson": {"schema": {"$ref": "#/components/schemas/BlobRecord"}}}
+        },
+        "responses": {
+          "200": {"description": "Imported", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ImportResult"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/vacuum": {
+      "post": {
+        "summary": "Compact a SQLite database to reclaim the space deletes left behind, admins only",
+        "description": "Readers carry on while it runs, writers wait and may time out on a large database. Sizes cover the database file and its write-ahead log.",
+        "responses": {
+          "200": {"description": "Vacuumed", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/VacuumResult"}}}},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "409": {"$ref": "#/components/responses/Error"},
+          "501": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/admin/keys": {
+      "post": {
+        "summary": "Mint an API key owned by the caller, admins only. The key is only returned once.",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}}}
+        },
+        "responses": {
+          "201": {"description": "Minted", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/APIKey"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "401": {"$ref": "#/components/responses/Error"},
+          "403": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "security": [],
+        "responses": {"200": {"description": "OpenAPI 3 document"}}
+      }
+    },
+    "/metrics": {
+      "get": {
+        "summary": "Prometheus metrics",
+        "security": [],
+        "responses": {"200": {"description": "Prometheus text format", "content": {"text/plain": {}}}}
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "bearerAuth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+      "apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+      "sessionCookie": {"type": "apiKey", "in": "cookie", "name": "doco_session"}
+    },
+    "parameters": {
+      "BlobID": {"name": "blob_id", "in": "path", "required": true, "description": "The blob's file name", "schema": {"type": "string"}},
+      "Disposition": {"name": "disposition", "in": "query", "description": "inline only applies to the content_type values, anything else still downloads", "schema": {"type": "string", "enum": ["attachment", "inline"], "default": "attachment"}},
+      "Version": {"name": "version", "in": "query", "description": "Serve this version of the blob, the latest when absent", "schema": {"type": "integer", "minimum": 1}},
+      "ContentType": {"name": "content_type", "in": "query", "description": "Serve with this type instead of the stored one, the blob itself is unchanged", "schema": {"type": "string", "enum": ["application/json", "application/octet-stream", "application/pdf", "image/gif", "image/jpeg", "image/png", "image/webp", "text/csv", "text/plain; charset=utf-8"]}}
+    },
+    "responses": {
+      "Error": {"description": "Error", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}},
+      "TooManyDownloads": {
+        "description": "Every download slot stayed busy for the queue wait, retry after the Retry-After seconds",
+        "headers": {"Retry-After": {"schema": {"type": "integer"}}},
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ErrorResponse"}}}
+      }
+    },
+    "schemas": {
+      "ErrorResponse": {
+        "type": "object",
+        "properties": {
+          "err": {"type": "string"},
+          "message": {"type": "string"},
+          "fields": {"type": "object", "description": "Per field messages of a 422", "additionalProperties": {"type": "string"}},
+          "request_id": {"type": "string", "description": "Quote it when reporting the error"}
+        }
+      },
+      "Status": {
+        "type": "object",
+        "properties": {"status": {"type": "string"}}
+      },
+      "BuildInfo": {
+        "type": "object",
+        "properties": {
+          "version": {"type": "string"},
+          "commit": {"type": "string"},
+          "build_date": {"type": "string"},
+          "go_version": {"type": "string"}
+        }
+      },
+      "LoginRequest": {
+        "type": "object",
+        "properties": {"username": {"type": "string"}, "password": {"type": "string"}},
+        "required": ["username", "password"]
+      },
+      "LoginResponse": {
+        "type": "object",
+        "properties": {"token": {"type": "string"}, "expires_at": {"type": "string", "format": "date-time"}}
+      },
+      "Session": {
+        "type": "object",
+        "properties": {"username": {"type": "string"}, "admin": {"type": "boolean"}}
+      },
+      "BlobSelection": {
+        "type": "object",
+        "properties": {
+          "file_names": {"type": "array", "items": {"type": "string"}},
+          "tags": {"type": "array", "items": {"type": "string"}, "description": "Every tag must match, not allowed with file_names"}
+        }
+      },
+      "BlobMetadata": {
+        "type": "object",
+        "properties": {
+          "file_name": {"type": "string"},
+          "mime_type": {"type": "string"},
+          "file_size_bytes": {"type": "integer", "format": "int64"},
+          "checksum": {"type": "string", "description": "Hex SHA-256 of the contents"},
+          "created_at": {"type": "string", "format": "date-time"},
+          "expires_at": {"type": "string", "format": "date-time", "description": "Absent for blobs kept until deleted"}
+        }
+      },
+      "BlobExists": {
+        "type": "object",
+        "properties": {
+          "exists": {"type": "boolean"},
+          "file_name": {"type": "string", "description": "A blob with the content, absent when none exists"}
+        }
+      },
+      "BlobList": {
+        "type": "object",
+        "properties": {
+          "total": {"type": "integer", "format": "int64"},
+          "limit": {"type": "integer"},
+          "offset": {"type": "integer"},
+          "blobs": {"type": "array", "items": {"$ref": "#/components/schemas/BlobMetadata"}}
+        }
+      },
+      "UploadRequest": {
+        "type": "object",
+        "properties": {
+          "file": {"type": "string", "format": "binary"},
+          "file_name": {"allOf": [{"$ref": "#/components/schemas/FileName"}], "description": "Defaults to the part's file name"},
+          "mime_type": {"type": "string", "description": "Defaults to the part's content type, then sniffing"},
+          "expires_in": {"type": "integer", "description": "Seconds until the blob is deleted, the same as X-Expires-In"},
+          "file_size_bytes": {"type": "integer", "description": "The same as X-Blob-Size"},
+          "checksum": {"type": "string", "description": "The same as X-Checksum-Sha256"}
+        },
+        "required": ["file"]
+      },
+      "UploadResponse": {
+        "type": "object",
+        "properties": {
+          "file_name": {"type": "string"},
+          "mime_type": {"type": "string"},
+          "file_size_bytes": {"type": "integer", "format": "int64"},
+          "checksum": {"type": "string"},
+          "expires_at": {"type": "string", "format": "date-time"},
+          "deduplicated": {"type": "boolean", "description": "The content was already stored, file_name is the existing blob's and may differ from the upload's"},
+          "version": {"type": "integer", "format": "int64", "description": "Set when the upload became a new version of an existing blob"}
+        }
+      },
+      "Event": {
+        "type": "object",
+        "properties": {
+          "type": {"type": "string", "enum": ["blob.created", "blob.deleted", "blob.restored"]},
+          "file_name": {"type": "string"},
+          "version": {"type": "integer", "format": "int64", "description": "Set when an upload became a new version of an existing blob"},
+          "at": {"type": "string", "format": "date-time"}
+        }
+      },
+      "BlobRecord": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer", "format": "int64"},
+          "file_name": {"type": "string"},
+          "mime_type": {"type": "string"},
+          "file_size_bytes": {"type": "integer", "format": "int64"},
+          "extension": {"type": "string"},
+          "checksum": {"type": "string"},
+          "nonce": {"type": "string", "format": "byte", "description": "Needed to decrypt the stored contents"},
+          "compressed": {"type": "boolean"},
+          "segment_size": {"type": "integer", "format": "int64"},
+          "sealed_final": {"type": "boolean", "description": "Whether the last segment is sealed apart, so a truncated copy fails to decrypt"},
+          "views": {"type": "integer", "nullable": true},
+          "archived": {"type": "boolean"},
+          "archived_at": {"type": "string", "format": "date-time", "nullable": true},
+          "owner": {"type": "string", "description": "Owner's username, absent for blobs without one"},
+          "tags": {"type": "array", "items": {"type": "string"}},
+          "created_at": {"type": "string", "format": "date-time"},
+          "updated_at": {"type": "string", "format": "date-time"},
+          "content_updated_at": {"type": "string", "format": "date-time", "nullable": true, "description": "When versioning last replaced the contents, Last-Modified uses it over created_at"},
+          "expires_at": {"type": "string", "format": "date-time", "nullable": true},
+          "deleted_at": {"type": "string", "format": "date-time", "nullable": true, "description": "Set on blobs in the trash"}
+        }
+      },
+      "VacuumResult": {
+        "type": "object",
+        "properties": {
+          "bytes_before": {"type": "integer", "format": "int64"},
+          "bytes_after": {"type": "integer", "format": "int64"},
+          "bytes_reclaimed": {"type": "integer", "format": "int64"}
+        }
+      },
+      "ImportResult": {
+        "type": "object",
+        "properties": {
+          "imported": {"type": "integer"},
+          "replaced": {"type": "integer"},
+          "skipped": {"type": "integer"}
+        }
+      },
+      "BlobVersion": {
+        "type": "object",
+        "properties": {
+          "version": {"type": "integer", "format": "int64"},
+          "mime_type": {"type": "string"},
+          "file_size_bytes": {"type": "integer", "format": "int64"},
+          "checksum": {"type": "string"},
+          "created_at": {"type": "string", "format": "date-time"},
+          "latest": {"type": "boolean"}
+        }
+      },
+      "BatchResult": {
+        "type": "object",
+        "properties": {
+          "file_name": {"type": "string"},
+          "mime_type": {"type": "string"},
+          "file_size_bytes": {"type": "integer", "format": "int64"},
+          "checksum": {"type": "string"},
+          "deduplicated": {"type": "boolean", "description": "The caller already stored these contents under file_name, nothing new was stored"},
+          "version": {"type": "integer", "format": "int64", "description": "Set when the file replaced an existing blob's contents"},
+          "error": {"type": "string"}
+        }
+      },
+      "BatchResponse": {
+        "type": "object",
+        "properties": {"results": {"type": "array", "items": {"$ref": "#/components/schemas/BatchResult"}}}
+      },
+      "FileName": {
+        "type": "string",
+        "description": "Up to 255 bytes of UTF-8 without control characters, / or \\, and not . or .. Anything else is rejected with 400.",
+        "minLength": 1,
+        "maxLength": 255
+      },
+      "RenameRequest": {
+        "type": "object",
+        "properties": {"new_filename": {"$ref": "#/components/schemas/FileName"}},
+        "required": ["new_filename"]
+      },
+      "ShareRequest": {
+        "type": "object",
+        "properties": {"expires_in_seconds": {"type": "integer", "format": "int64", "minimum": 1, "maximum": 604800, "default": 86400}}
+      },
+      "ShareResponse": {
+        "type": "object",
+        "properties": {"url": {"type": "string"}, "expires_at": {"type": "string", "format": "date-time"}}
+      },
+      "MigrationStatus": {
+        "type": "object",
+        "properties": {
+          "version": {"type": "integer"},
+          "dirty": {"type": "boolean"},
+          "latest": {"type": "integer"},
+          "pending": {"type": "boolean"}
+        }
+      },
+      "AuditEntry": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer", "format": "int64"},
+          "user_id": {"type": "integer", "format": "int64"},
+          "username": {"type": "string"},
+          "action": {"type": "string"},
+          "blob_file_name": {"type": "string"},
+          "created_at": {"type": "string", "format": "date-time"}
+        }
+      },
+      "AuditLog": {
+        "type": "object",
+        "properties": {
+          "total": {"type": "integer", "format": "int64"},
+          "limit": {"type": "integer"},
+          "offset": {"type": "integer"},
+          "entries": {"type": "array", "items": {"$ref": "#/components/schemas/AuditEntry"}}
+        }
+      },
+      "APIKey": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer", "format": "int64"},
+          "name": {"type": "string"},
+          "key": {"type": "string"},
+          "created_at": {"type": "string", "format": "date-time"}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,56 @@
+package doco
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	start := time.Unix(1580000000, 0)
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		// requests are made at these offsets from start, all from one client
+		at   []time.Duration
+		want []bool
+	}{
+		{"burst allowed", 1, 3, []time.Duration{0, 0, 0}, []bool{true, true, true}},
+		{"over burst", 1, 2, []time.Duration{0, 0, 0}, []bool{true, true, false}},
+		{"refills at rate", 2, 1, []time.Duration{0, 0, 500 * time.Millisecond}, []bool{true, false, true}},
+		{"refill capped at burst", 1, 2, []time.Duration{0, 0, time.Hour, time.Hour, time.Hour}, []bool{true, true, true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.rate, tt.burst)
+			for i, at := range tt.at {
+				ok, retryAfter := l.allow("198.51.100.7", start.Add(at))
+				if ok != tt.want[i] {
+					t.Fatalf("request %d: got %v, want %v", i, ok, tt.want[i])
+				}
+				if !ok && retryAfter <= 0 {
+					t.Fatalf("request %d: rejected without a retry after", i)
+				}
+			}
+		})
+	}
+
+	t.Run("clients have their own buckets", func(t *testing.T) {
+		l := newRateLimiter(1, 1)
+		if ok, _ := l.allow("198.51.100.7", start); !ok {
+			t.Fatal("first client rejected")
+		}
+		if ok, _ := l.allow("198.51.100.8", start); !ok {
+			t.Fatal("second client rejected by the first's bucket")
+		}
+	})
+
+	t.Run("full buckets are swept", func(t *testing.T) {
+		l := newRateLimiter(1, 1)
+		l.allow("198.51.100.7", start)
+		l.allow("198.51.100.8", start.Add(2*time.Minute))
+		if _, ok := l.buckets["198.51.100.7"]; ok {
+			t.Fatal("refilled bucket kept")
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package doco
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxJSONBodyBytes bounds JSON request bodies, uploads have their own limit
+const maxJSONBodyBytes = 1 << 20
+
+// ErrInvalidBody is returned by decodeJSON for any body that can't be used
+var ErrInvalidBody = errors.New("invalid request body")
+
+// decodeJSON strictly decodes a single JSON object from the request body into v.
+// Errors wrap ErrInvalidBody and are meant to be returned with http.StatusBadRequest.
+func decodeJSON(r *http.Request, v interface{}) error {
+	body := http.MaxBytesReader(nil, r.Body, maxJSONBodyBytes)
+	dec := json.NewDecoder(body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	switch {
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("%w: empty body", ErrInvalidBody)
+	case isBodyTooLarge(err):
+		return fmt.Errorf("%w: larger than %d bytes", ErrInvalidBody, maxJSONBodyBytes)
+	case err != nil:
+		return fmt.Errorf("%w: %s", ErrInvalidBody, err)
+	}
+	if dec.More() {
+		return fmt.Errorf("%w: unexpected data after JSON object", ErrInvalidBody)
+	}
+	return nil
+}
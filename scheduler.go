@@ -0,0 +1,107 @@
+package doco
+
+import (
+	"context"
+	"doco/db"
+	"fmt"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// defaultStepInterval is how often maintenance runs when StepInterval isn't set, StepMinutes' default
+const defaultStepInterval = 5 * time.Minute
+
+// maintenanceTask is a job the scheduler runs every step
+type maintenanceTask struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// maintenanceTasks are run every step whatever the config, newRouter adds the ones that depend on it
+func (c *API) maintenanceTasks() []maintenanceTask {
+	return []maintenanceTask{
+		{"delete expired blobs", func(ctx context.Context) error {
+			_, err := c.deleteExpiredBlobs(ctx, time.Now())
+			return err
+		}},
+		{"delete expired idempotency keys", c.deleteExpiredIdempotencyKeys},
+		{"detect orphaned blob files", c.detectOrphanedBlobFiles},
+		{"snapshot blob metrics", c.snapshotBlobMetrics},
+	}
+}
+
+// runScheduler runs the tasks in turn every interval until ctx is done. A failed
+// task is logged and the rest still run. A step that overruns delays the next
+// rather than overlapping it.
+func (c *API) runScheduler(ctx context.Context, interval time.Duration, tasks []maintenanceTask) {
+	c.log.Infow("start scheduler", "interval", interval, "tasks", len(tasks))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, task := range tasks {
+				if ctx.Err() != nil {
+					return
+				}
+				start := time.Now()
+				err := task.run(ctx)
+				if err != nil {
+					c.log.Errorw("maintenance task failed", "task", task.name, "err", err)
+					continue
+				}
+				c.log.Debugw("maintenance task done", "task", task.name, "duration", time.Since(start))
+			}
+		}
+	}
+}
+
+// detectOrphanedBlobFiles counts stored contents left without a blob row, for
+// example by a crash part way through an upload. They are reported for someone
+// to look at rather than removed.
+func (c *API) detectOrphanedBlobFiles(ctx context.Context) error {
+	lister, ok := c.store.(keyLister)
+	if !ok {
+		// the db store keeps contents in the row itself, they can't be orphaned
+		return nil
+	}
+	keys, err := lister.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	known, err := storedContentKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("orphans: %w", err)
+	}
+	orphans := 0
+	for _, key := range keys {
+		if isContentKey(key) && !known[key] {
+			orphans++
+		}
+	}
+	c.metrics.orphanedBlobFiles.Set(float64(orphans))
+	if orphans > 0 {
+		c.log.Warnw("orphaned blob files", "count", orphans)
+	}
+	return nil
+}
+
+// snapshotBlobMetrics refreshes the stored blob gauges. Trashed and expired blobs
+// are served as missing, so they aren't counted.
+func (c *API) snapshotBlobMetrics(ctx context.Context) error {
+	var count, bytes int64
+	err := db.Blobs(
+		qm.Select("COUNT(*)", "COALESCE(SUM("+db.BlobColumns.FileSizeBytes+"), 0)"),
+		notTrashed(),
+		notExpired(time.Now()),
+	).QueryRowContext(ctx, c.conn).Scan(&count, &bytes)
+	if err != nil {
+		return fmt.Errorf("blob metrics: %w", err)
+	}
+	c.metrics.blobsStored.Set(float64(count))
+	c.metrics.blobBytesStored.Set(float64(bytes))
+	return nil
+}
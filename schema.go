@@ -0,0 +1,129 @@
+package doco
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// ErrSchemaUnchecked is reported until the first schema check has run
+var ErrSchemaUnchecked = errors.New("database schema not checked yet")
+
+// schemaCheckInterval is how often a server waiting on migrations looks again
+const schemaCheckInterval = 5 * time.Second
+
+// schemaGate holds requests back until the database schema matches this build
+type schemaGate struct {
+	mu  sync.RWMutex
+	err error
+}
+
+func newSchemaGate() *schemaGate {
+	return &schemaGate{err: ErrSchemaUnchecked}
+}
+
+func (g *schemaGate) set(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.err = err
+}
+
+// check is nil once the schema is current, otherwise why it isn't
+func (g *schemaGate) check() error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.err
+}
+
+// checkSchema compares the database's migration version with the newest one built
+// in. A database ahead of this build is accepted, migrations only add to the schema.
+// The version is read directly, a migrate instance holds a postgres connection until
+// closed and closing it closes the pool.
+func checkSchema(ctx context.Context, conn *sqlx.DB) error {
+	latest, err := LatestVersion(conn.DriverName())
+	if err != nil {
+		return err
+	}
+	var version uint
+	var dirty bool
+	err = conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d failed part way, fix it and force the version", version)
+	}
+	if version < latest {
+		return fmt.Errorf("database schema at version %d, this build needs %d, run -migrate-up", version, latest)
+	}
+	return nil
+}
+
+// autoMigrate applies pending migrations before the server takes traffic
+func autoMigrate(conn *sqlx.DB, log *zap.SugaredLogger) error {
+	err := Migrate(conn)
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Infow("database migrated on startup")
+	return nil
+}
+
+// waitForSchema checks the schema every schemaCheckInterval until it is current
+// or ctx is done, logging each time the reason it isn't changes
+func (c *API) waitForSchema(ctx context.Context) {
+	ticker := time.NewTicker(schemaCheckInterval)
+	defer ticker.Stop()
+	last := ""
+	for {
+		err := checkSchema(ctx, c.conn)
+		c.schema.set(err)
+		if err == nil {
+			c.log.Infow("database schema ready")
+			return
+		}
+		if err.Error() != last {
+			c.log.Warnw("waiting for database schema", "err", err)
+			last = err.Error()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// requireSchema answers 503 until the schema is current, so new code never runs
+// against an old schema. Probes, metrics and docs stay up meanwhile.
+func (c *API) requireSchema(exempt ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			err := c.schema.check()
+			if err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			for _, path := range exempt {
+				if strings.TrimSuffix(r.URL.Path, "/") == path {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			c.writeError(w, r, fmt.Errorf("%w: %s", ErrNotReady, err), http.StatusServiceUnavailable)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
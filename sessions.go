@@ -0,0 +1,145 @@
+package doco
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/jmoiron/sqlx"
+)
+
+// sessionUserIDKey holds the logged in user's ID in a session
+const sessionUserIDKey = "user_id"
+
+// sessionCookieName is scoped to doco, other apps on the host may use "session"
+const sessionCookieName = "doco_session"
+
+// newSessionManager keeps sessions in memory ("memory", default) or the sessions table ("db").
+// Cookies are scoped to the API's path and only sent over HTTPS when secure is set.
+func newSessionManager(kind string, conn *sqlx.DB, path string, lifetime time.Duration, secure bool) (*scs.SessionManager, error) {
+	sessions := scs.New()
+	switch kind {
+	case "", "memory":
+	case "db":
+		sessions.Store = &dbSessionStore{conn}
+	default:
+		return nil, fmt.Errorf("unknown session store: %q", kind)
+	}
+	if lifetime > 0 {
+		sessions.Lifetime = lifetime
+	}
+	sessions.Cookie.Name = sessionCookieName
+	sessions.Cookie.Path = path
+	sessions.Cookie.Secure = secure
+	return sessions, nil
+}
+
+// dbSessionStore is an scs.Store on the sessions table, so sessions survive restarts
+type dbSessionStore struct {
+	conn *sqlx.DB
+}
+
+// Find returns the session data, an expired session is the same as a missing one
+func (s *dbSessionStore) Find(token string) ([]byte, bool, error) {
+	b := []byte{}
+	err := s.conn.QueryRow(s.conn.Rebind(`SELECT data FROM sessions WHERE token = ? AND expiry > ?`), token, time.Now().UTC()).Scan(&b)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("session find: %w", err)
+	}
+	return b, true, nil
+}
+
+// Commit inserts or replaces the session
+func (s *dbSessionStore) Commit(token string, b []byte, expiry time.Time) error {
+	_, err := s.conn.Exec(s.conn.Rebind(`INSERT INTO sessions (token, data, expiry) VALUES (?, ?, ?)
+		ON CONFLICT (token) DO UPDATE SET data = excluded.data, expiry = excluded.expiry`), token, b, expiry.UTC())
+	if err != nil {
+		return fmt.Errorf("session commit: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the session, a missing token is not an error
+func (s *dbSessionStore) Delete(token string) error {
+	_, err := s.conn.Exec(s.conn.Rebind(`DELETE FROM sessions WHERE token = ?`), token)
+	if err != nil {
+		return fmt.Errorf("session delete: %w", err)
+	}
+	return nil
+}
+
+// deleteExpired removes expired sessions, a maintenance task
+func (s *dbSessionStore) deleteExpired(ctx context.Context) error {
+	_, err := s.conn.ExecContext(ctx, s.conn.Rebind(`DELETE FROM sessions WHERE expiry <= ?`), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("session purge: %w", err)
+	}
+	return nil
+}
+
+// sessionClaims resolves a session cookie to the claims of its logged in user.
+// Sessions are only read here, so requests outside LoadAndSave aren't buffered.
+func (c *API) sessionClaims(ctx context.Context, token string) (*Claims, error) {
+	ctx, err := c.sessions.Load(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	userID, ok := c.sessions.Get(ctx, sessionUserIDKey).(int64)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+	return userClaims(ctx, userID)
+}
+
+// sessionLoginHandler is loginHandler for browsers, it sets a session cookie instead of returning a token
+func (c *API) sessionLoginHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Request struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		type Response struct {
+			Username string `json:"username"`
+			Admin    bool   `json:"admin"`
+		}
+
+		req := &Request{}
+		err := decodeJSON(r, req)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		user, code, err := authenticate(r.Context(), req.Username, req.Password)
+		if err != nil {
+			return nil, code, err
+		}
+
+		// a fresh token on login stops session fixation
+		err = c.sessions.RenewToken(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		c.sessions.Put(r.Context(), sessionUserIDKey, user.ID.Int64)
+		c.log.Infow("user logged in", "username", user.Username, "session", true)
+		return &Response{Username: user.Username, Admin: user.Admin}, http.StatusOK, nil
+	}
+	return fn
+}
+
+// sessionLogoutHandler destroys the caller's session, logging out twice is fine
+func (c *API) sessionLogoutHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		err := c.sessions.Destroy(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return nil, http.StatusNoContent, nil
+	}
+	return fn
+}
@@ -0,0 +1,184 @@
+package doco
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"doco/db"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrInvalidShareToken is returned for share tokens that are malformed, tampered with or expired
+var ErrInvalidShareToken = errors.New("invalid or expired share token")
+
+const (
+	defaultShareLifetime = 24 * time.Hour
+	maxShareLifetime     = 7 * 24 * time.Hour
+)
+
+// shareKeyLabel derives the share link key from the JWT secret, so a link's MAC
+// can never pass for a token signature or the other way round
+const shareKeyLabel = "doco share links"
+
+// deriveShareKey returns the key share links are signed with
+func deriveShareKey(jwtSecret []byte) []byte {
+	mac := hmac.New(sha256.New, jwtSecret)
+	mac.Write([]byte(shareKeyLabel))
+	return mac.Sum(nil)
+}
+
+// shareMAC signs a share payload
+func (c *API) shareMAC(payload string) []byte {
+	mac := hmac.New(sha256.New, c.shareKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// signShare returns a token granting access to a blob until expiresAt. The blob is
+// named by ID and creation time, SQLite reuses the IDs of deleted blobs so the ID
+// alone could later name someone else's. A link survives a rename or a new version
+// but not a delete.
+func (c *API) signShare(blob *db.Blob, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d.%d", blob.ID.Int64, blob.CreatedAt.UnixNano(), expiresAt.Unix())
+	return payload + "." + hex.EncodeToString(c.shareMAC(payload))
+}
+
+// shareGrant is what a valid share token names
+type shareGrant struct {
+	blobID    int64
+	createdAt int64
+}
+
+// matches reports whether blob is the one the token was signed for
+func (g shareGrant) matches(blob *db.Blob) bool {
+	return blob.ID.Int64 == g.blobID && blob.CreatedAt.UnixNano() == g.createdAt
+}
+
+// parseShareToken checks a token's signature and expiry, returning the blob it grants
+func (c *API) parseShareToken(token string, now time.Time) (shareGrant, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return shareGrant{}, ErrInvalidShareToken
+	}
+	sig, err := hex.DecodeString(parts[3])
+	if err != nil || !hmac.Equal(sig, c.shareMAC(strings.Join(parts[:3], "."))) {
+		return shareGrant{}, ErrInvalidShareToken
+	}
+	blobID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return shareGrant{}, ErrInvalidShareToken
+	}
+	createdAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return shareGrant{}, ErrInvalidShareToken
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return shareGrant{}, ErrInvalidShareToken
+	}
+	return shareGrant{blobID: blobID, createdAt: createdAt}, nil
+}
+
+// shareURL is absolute so it can be sent as is, the load balancer forwards the public host and scheme
+func (c *API) shareURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + c.apiPrefix + "/shared?token=" + url.QueryEscape(token)
+}
+
+// blobShareHandler mints a signed link to a blob for someone without an account.
+// The body is optional, the link lasts a day unless expires_in_seconds says otherwise.
+func (c *API) blobShareHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		type Request struct {
+			ExpiresInSeconds int64 `json:"expires_in_seconds"`
+		}
+		type Response struct {
+			URL       string    `json:"url"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+
+		req := &Request{}
+		if r.ContentLength != 0 {
+			err := decodeJSON(r, req)
+			if err != nil {
+				return nil, http.StatusBadRequest, err
+			}
+		}
+		lifetime := defaultShareLifetime
+		if req.ExpiresInSeconds != 0 {
+			lifetime = time.Duration(req.ExpiresInSeconds) * time.Second
+		}
+		if lifetime <= 0 || lifetime > maxShareLifetime {
+			message := fmt.Sprintf("must be between 1 and %d", int64(maxShareLifetime/time.Second))
+			return nil, http.StatusUnprocessableEntity, ValidationErr(map[string]string{"expires_in_seconds": message})
+		}
+
+		blob, err := db.Blobs(
+			qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName, db.BlobColumns.OwnerID, db.BlobColumns.ExpiresAt, db.BlobColumns.CreatedAt),
+			db.BlobWhere.FileName.EQ(chi.URLParam(r, "blob_id")),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) || err == nil && blobExpired(blob, time.Now()) {
+			return nil, http.StatusNotFound, ErrBlobNotFound
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !canAccessBlob(r, blob) {
+			return nil, http.StatusForbidden, ErrForbidden
+		}
+
+		expiresAt := time.Now().Add(lifetime).Truncate(time.Second)
+		token := c.signShare(blob, expiresAt)
+		c.audit(r, AuditShare, blob.FileName)
+		c.log.Infow("blob shared", "file_name", blob.FileName, "expires_at", expiresAt)
+		return &Response{URL: c.shareURL(r, token), ExpiresAt: expiresAt}, http.StatusCreated, nil
+	}
+	return fn
+}
+
+// sharedBlobHandler serves a blob to anyone holding a valid share token
+func (c *API) sharedBlobHandler() func(w http.ResponseWriter, r *http.Request) {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		grant, err := c.parseShareToken(r.URL.Query().Get("token"), time.Now())
+		if err != nil {
+			c.writeError(w, r, err, http.StatusForbidden)
+			return
+		}
+		err = checkServeParams(r)
+		if err != nil {
+			c.writeError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		blob, err := db.Blobs(
+			qm.Select(blobColumnsWithoutFile...),
+			db.BlobWhere.ID.EQ(null.Int64From(grant.blobID)),
+			notTrashed(),
+		).OneG(r.Context())
+		// a reused ID is a different blob, the one shared is gone
+		if errors.Is(err, sql.ErrNoRows) || err == nil && (!grant.matches(blob) || blobExpired(blob, time.Now())) {
+			c.writeError(w, r, ErrBlobNotFound, http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			c.writeError(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		c.serveBlob(w, r, blob, AuditSharedDownload)
+	}
+	return fn
+}
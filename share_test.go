@@ -0,0 +1,53 @@
+package doco
+
+import (
+	"doco/db"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volatiletech/null"
+)
+
+func TestShareToken(t *testing.T) {
+	now := time.Unix(1580000000, 0)
+	c := &API{shareKey: deriveShareKey([]byte(testJWTSecret))}
+	blob := &db.Blob{ID: null.Int64From(7), CreatedAt: now.Add(-time.Hour)}
+	token := c.signShare(blob, now.Add(time.Hour))
+	parts := strings.Split(token, ".")
+
+	reused := *blob
+	reused.CreatedAt = now
+	other := *blob
+	other.ID = null.Int64From(8)
+
+	tests := []struct {
+		name  string
+		api   *API
+		token string
+		now   time.Time
+		blob  *db.Blob
+		ok    bool
+	}{
+		{"valid", c, token, now, blob, true},
+		{"expired", c, token, now.Add(time.Hour), blob, false},
+		{"other blob", c, token, now, &other, false},
+		{"id reused by a later blob", c, token, now, &reused, false},
+		{"expiry extended", c, strings.Join([]string{parts[0], parts[1], "9999999999", parts[3]}, "."), now, blob, false},
+		{"id changed", c, strings.Join([]string{"8", parts[1], parts[2], parts[3]}, "."), now, &other, false},
+		{"signature cut", c, token[:len(token)-2], now, blob, false},
+		{"other secret", &API{shareKey: deriveShareKey([]byte("other"))}, token, now, blob, false},
+		{"signed with the jwt secret itself", &API{shareKey: []byte(testJWTSecret)}, token, now, blob, false},
+		{"too few parts", c, strings.Join(parts[1:], "."), now, blob, false},
+		{"empty", c, "", now, blob, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant, err := tt.api.parseShareToken(tt.token, tt.now)
+			ok := err == nil && grant.matches(tt.blob)
+			if ok != tt.ok {
+				t.Fatalf("got ok %v (%v), want %v", ok, err, tt.ok)
+			}
+		})
+	}
+}
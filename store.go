@@ -0,0 +1,262 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BlobStore holds the stored (encrypted) bytes of a blob, keyed by blob ID, or
+// by versionKeyPrefix and the version ID for an older version of one.
+// Metadata always lives in the blobs table, only the contents are pluggable.
+type BlobStore interface {
+	Put(ctx context.Context, id string, r io.Reader) error
+	Get(ctx context.Context, id string) (io.ReadCloser, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// keyLister is implemented by stores that can list what they hold, so
+// contents without a blob row can be found
+type keyLister interface {
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// NewBlobStore returns the store named by kind, "db" (default) or "fs"
+func NewBlobStore(kind, path string, conn *sqlx.DB) (BlobStore, error) {
+	switch kind {
+	case "", "db":
+		return &DBBlobStore{conn}, nil
+	case "fs":
+		return NewFSBlobStore(path)
+	}
+	return nil, fmt.Errorf("unknown blob store: %q", kind)
+}
+
+// DBBlobStore keeps blob contents in the file column of the blobs table, and
+// older versions in the file column of blob_versions
+type DBBlobStore struct {
+	conn *sqlx.DB
+}
+
+// row finds the table and row ID holding a key's contents
+func (s *DBBlobStore) row(id string) (string, int64, error) {
+	table := "blobs"
+	if strings.HasPrefix(id, versionKeyPrefix) {
+		table = "blob_versions"
+		id = strings.TrimPrefix(id, versionKeyPrefix)
+	}
+	rowID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return table, rowID, nil
+}
+
+// Put expects the row to exist already and fills in its file column
+func (s *DBBlobStore) Put(ctx context.Context, id string, r io.Reader) error {
+	table, rowID, err := s.row(id)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	_, err = s.conn.ExecContext(ctx, s.conn.Rebind(`UPDATE `+table+` SET file = ? WHERE id = ?`), b, rowID)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	return nil
+}
+
+// PutTx is Put as part of tx, so the contents commit or roll back with the row
+func (s *DBBlobStore) PutTx(ctx context.Context, tx *sql.Tx, id string, r io.Reader) error {
+	table, rowID, err := s.row(id)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, s.conn.Rebind(`UPDATE `+table+` SET file = ? WHERE id = ?`), b, rowID)
+	if err != nil {
+		return fmt.Errorf("db store put: %w", err)
+	}
+	return nil
+}
+
+// txPutter is implemented by stores whose writes can join a database transaction
+type txPutter interface {
+	PutTx(ctx context.Context, tx *sql.Tx, id string, r io.Reader) error
+}
+
+type sectionReadCloser struct {
+	*io.SectionReader
+}
+
+func (sectionReadCloser) Close() error { return nil }
+
+// Get reads the column lazily, the returned reader also implements io.ReaderAt and io.Seeker
+func (s *DBBlobStore) Get(ctx context.Context, id string) (io.ReadCloser, error) {
+	table, rowID, err := s.row(id)
+	if err != nil {
+		return nil, fmt.Errorf("db store get: %w", err)
+	}
+	src, err := newBlobColumnReader(ctx, s.conn, table, rowID)
+	if err != nil {
+		return nil, fmt.Errorf("db store get: %w", err)
+	}
+	return sectionReadCloser{io.NewSectionReader(src, 0, src.size)}, nil
+}
+
+// Delete empties the file column, the row itself is removed by the caller
+func (s *DBBlobStore) Delete(ctx context.Context, id string) error {
+	table, rowID, err := s.row(id)
+	if err != nil {
+		return fmt.Errorf("db store delete: %w", err)
+	}
+	_, err = s.conn.ExecContext(ctx, s.conn.Rebind(`UPDATE `+table+` SET file = ? WHERE id = ?`), []byte{}, rowID)
+	if err != nil {
+		return fmt.Errorf("db store delete: %w", err)
+	}
+	return nil
+}
+
+// FSBlobStore keeps blob contents as files in a directory
+type FSBlobStore struct {
+	dir string
+}
+
+// NewFSBlobStore creates the directory if needed
+func NewFSBlobStore(dir string) (*FSBlobStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("fs store: %w", err)
+	}
+	return &FSBlobStore{dir}, nil
+}
+
+func (s *FSBlobStore) path(id string) string {
+	return filepath.Join(s.dir, filepath.Base(id))
+}
+
+// Put writes to a temp file and renames it into place so readers never see a partial blob
+func (s *FSBlobStore) Put(ctx context.Context, id string, r io.Reader) error {
+	f, err := ioutil.TempFile(s.dir, ".put-")
+	if err != nil {
+		return fmt.Errorf("fs store put: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("fs store put: %w", err)
+	}
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("fs store put: %w", err)
+	}
+	err = os.Rename(f.Name(), s.path(id))
+	if err != nil {
+		return fmt.Errorf("fs store put: %w", err)
+	}
+	return nil
+}
+
+// Get returns the open file, which also implements io.ReaderAt and io.Seeker
+func (s *FSBlobStore) Get(ctx context.Context, id string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(id))
+	if err != nil {
+		return nil, fmt.Errorf("fs store get: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the file, a missing file is not an error
+func (s *FSBlobStore) Delete(ctx context.Context, id string) error {
+	err := os.Remove(s.path(id))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("fs store delete: %w", err)
+	}
+	return nil
+}
+
+// Keys lists the stored keys, skipping in progress temp files
+func (s *FSBlobStore) Keys(ctx context.Context) ([]string, error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("fs store keys: %w", err)
+	}
+	keys := []string{}
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		keys = append(keys, info.Name())
+	}
+	return keys, nil
+}
+
+// blobColumnReader reads one row's file column in slices with substr, so a
+// blob never has to be loaded from SQLite in one piece
+type blobColumnReader struct {
+	ctx   context.Context
+	conn  *sqlx.DB
+	table string
+	id    int64
+	size  int64
+}
+
+func newBlobColumnReader(ctx context.Context, conn *sqlx.DB, table string, id int64) (*blobColumnReader, error) {
+	r := &blobColumnReader{ctx: ctx, conn: conn, table: table, id: id}
+	err := conn.QueryRowContext(ctx, conn.Rebind(`SELECT length(file) FROM `+table+` WHERE id = ?`), id).Scan(&r.size)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *blobColumnReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	var b []byte
+	err := r.conn.QueryRowContext(r.ctx, r.conn.Rebind(`SELECT substr(file, ?, ?) FROM `+r.table+` WHERE id = ?`), off+1, len(p), r.id).Scan(&b)
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, b)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readerAt gives random access to a stored blob, buffering stores that can't seek
+func readerAt(rc io.ReadCloser) (io.ReaderAt, int64, error) {
+	if ras, ok := rc.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok {
+		size, err := ras.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, 0, err
+		}
+		return ras, size, nil
+	}
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bytes.NewReader(b), int64(len(b)), nil
+}
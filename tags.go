@@ -0,0 +1,118 @@
+package doco
+
+import (
+	"database/sql"
+	"doco/db"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// maxTagLength keeps tag names usable as path segments and labels
+const maxTagLength = 64
+
+// tagFilters restricts a blob query to blobs carrying every one of tags
+func tagFilters(tags []string) []qm.QueryMod {
+	mods := []qm.QueryMod{}
+	for i, tag := range tags {
+		mods = append(mods,
+			qm.InnerJoin(fmt.Sprintf(`"blobs_tags" bt%d on bt%d."blob_id" = "blobs"."id"`, i, i)),
+			qm.InnerJoin(fmt.Sprintf(`"tags" t%d on t%d."id" = bt%d."tag_id" and t%d."name" = ?`, i, i, i, i), tag),
+		)
+	}
+	return mods
+}
+
+func validTag(tag string) error {
+	if tag == "" || len(tag) > maxTagLength {
+		return fmt.Errorf("tag must be 1 to %d characters", maxTagLength)
+	}
+	return nil
+}
+
+// taggedBlob loads the blob and tag named in the URL, creating the tag if create is set
+func taggedBlob(r *http.Request, create bool) (*db.Blob, *db.Tag, int, error) {
+	tagName := chi.URLParam(r, "tag")
+	err := validTag(tagName)
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	blob, err := db.Blobs(
+		qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName, db.BlobColumns.OwnerID),
+		db.BlobWhere.FileName.EQ(chi.URLParam(r, "blob_id")),
+		notTrashed(),
+	).OneG(r.Context())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, http.StatusNotFound, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	if !canAccessBlob(r, blob) {
+		return nil, nil, http.StatusForbidden, ErrForbidden
+	}
+
+	tag, err := db.Tags(db.TagWhere.Name.EQ(tagName)).OneG(r.Context())
+	if errors.Is(err, sql.ErrNoRows) && create {
+		tag = &db.Tag{Name: tagName}
+		err = insertG(r.Context(), tag, func() error {
+			inserted, err := db.Tags(qm.Select(db.TagColumns.ID), db.TagWhere.Name.EQ(tagName)).OneG(r.Context())
+			if err != nil {
+				return err
+			}
+			tag.ID = inserted.ID
+			return nil
+		})
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return blob, nil, http.StatusOK, nil
+	}
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	return blob, tag, http.StatusOK, nil
+}
+
+// blobTagAddHandler tags a blob, tagging it twice is not an error
+func (c *API) blobTagAddHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		blob, tag, code, err := taggedBlob(r, true)
+		if err != nil {
+			return nil, code, err
+		}
+		exists, err := blob.Tags(db.TagWhere.ID.EQ(tag.ID)).ExistsG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !exists {
+			err = blob.AddTagsG(r.Context(), false, tag)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+		}
+		return nil, http.StatusNoContent, nil
+	}
+	return fn
+}
+
+// blobTagRemoveHandler untags a blob, removing a tag it doesn't have is not an error
+func (c *API) blobTagRemoveHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		blob, tag, code, err := taggedBlob(r, false)
+		if err != nil {
+			return nil, code, err
+		}
+		if tag == nil {
+			return nil, http.StatusNoContent, nil
+		}
+		err = blob.RemoveTagsG(r.Context(), tag)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		return nil, http.StatusNoContent, nil
+	}
+	return fn
+}
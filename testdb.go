@@ -0,0 +1,44 @@
+package doco
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/volatiletech/sqlboiler/boil"
+)
+
+// testDBs numbers in memory databases so each NewTestDB gets its own
+var testDBs int64
+
+// NewTestDB opens a fresh, fully migrated in memory SQLite database for tests and
+// makes it sqlboiler's global database, which the handlers use. It panics if the
+// database can't be set up. The database lives as long as a connection to it is
+// open, so call the returned teardown when done rather than closing it piecemeal,
+// it also gives sqlboiler back the database it had before.
+func NewTestDB() (*sqlx.DB, func()) {
+	// a named shared cache lets every pooled connection see the same database,
+	// a plain :memory: would give each its own empty one
+	dsn := fmt.Sprintf("file:doco-test-%d?mode=memory&cache=shared&_busy_timeout=5000&_foreign_keys=on", atomic.AddInt64(&testDBs, 1))
+	conn, err := sqlx.Connect(DriverSQLite, dsn)
+	if err != nil {
+		panic(fmt.Sprintf("test db: %s", err))
+	}
+	// one writer as in production, and never let the pool drop to zero
+	// connections, that would discard the database
+	conn.SetMaxOpenConns(1)
+	conn.SetMaxIdleConns(1)
+	conn.SetConnMaxLifetime(0)
+	err = Migrate(conn)
+	if err != nil {
+		conn.Close()
+		panic(fmt.Sprintf("test db: %s", err))
+	}
+	previous := boil.GetDB()
+	boil.SetDB(conn)
+	teardown := func() {
+		boil.SetDB(previous)
+		conn.Close()
+	}
+	return conn, teardown
+}
@@ -0,0 +1,92 @@
+package doco
+
+import (
+	"context"
+	"database/sql"
+	"doco/db"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrBlobNotTrashed is returned when restoring a blob that wasn't deleted
+var ErrBlobNotTrashed = errors.New("blob is not in the trash")
+
+// ErrBlobTrashed is returned when uploading over the name of a blob in the trash
+var ErrBlobTrashed = errors.New("blob is in the trash, restore it or wait for it to be purged")
+
+// notTrashed filters out soft deleted blobs, qualified for queries joining tags
+func notTrashed() qm.QueryMod {
+	return qm.Where(db.TableNames.Blobs + "." + db.BlobColumns.DeletedAt + " IS NULL")
+}
+
+// trashBlob soft deletes blob, it keeps its name and contents until purged
+func trashBlob(ctx context.Context, exec boil.ContextExecutor, blob *db.Blob, now time.Time) error {
+	blob.DeletedAt = null.TimeFrom(now.UTC())
+	_, err := blob.Update(ctx, exec, boil.Whitelist(db.BlobColumns.DeletedAt))
+	return err
+}
+
+// blobRestoreHandler takes a blob back out of the trash
+func (c *API) blobRestoreHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		blob, err := db.Blobs(
+			qm.Select(append([]string{db.BlobColumns.ID, db.BlobColumns.OwnerID, db.BlobColumns.DeletedAt}, blobMetadataColumns...)...),
+			db.BlobWhere.FileName.EQ(chi.URLParam(r, "blob_id")),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) || err == nil && blobExpired(blob, time.Now()) {
+			return nil, http.StatusNotFound, ErrBlobNotFound
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !canAccessBlob(r, blob) {
+			return nil, http.StatusForbidden, ErrForbidden
+		}
+		if blob.DeletedAt.IsNull() {
+			return nil, http.StatusConflict, ErrBlobNotTrashed
+		}
+
+		blob.DeletedAt = null.Time{}
+		_, err = blob.UpdateG(r.Context(), boil.Whitelist(db.BlobColumns.DeletedAt))
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		c.audit(r, AuditRestore, blob.FileName)
+		c.publishBlob(EventBlobRestored, blob, 0)
+		c.log.Infow("blob restored", "file_name", blob.FileName)
+		return newBlobMetadata(blob), http.StatusOK, nil
+	}
+	return fn
+}
+
+// purgeTrashedBlobs removes blobs deleted before cutoff and their stored contents,
+// returning how many went
+func (c *API) purgeTrashedBlobs(ctx context.Context, cutoff time.Time) (int, error) {
+	blobs, err := db.Blobs(
+		qm.Select(db.BlobColumns.ID, db.BlobColumns.FileName),
+		db.BlobWhere.DeletedAt.LTE(null.TimeFrom(cutoff.UTC())),
+	).AllG(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("trashed blobs: %w", err)
+	}
+	for i, blob := range blobs {
+		err = c.deleteBlobContents(ctx, blob)
+		if err != nil {
+			return i, fmt.Errorf("purge %s: %w", blob.FileName, err)
+		}
+		_, err = blob.DeleteG(ctx)
+		if err != nil {
+			return i, fmt.Errorf("purge %s: %w", blob.FileName, err)
+		}
+		c.log.Infow("blob purged", "file_name", blob.FileName)
+	}
+	return len(blobs), nil
+}
@@ -0,0 +1,38 @@
+package doco
+
+import (
+	"net/http"
+	"runtime"
+)
+
+// Build info, set at link time with -ldflags "-X doco.BuildVersion=..." as the Makefile does
+var (
+	BuildVersion = "dev"
+	BuildCommit  = "unknown"
+	BuildDate    = "unknown"
+)
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// GetBuildInfo returns the link time build info
+func GetBuildInfo() *BuildInfo {
+	return &BuildInfo{
+		Version:   BuildVersion,
+		Commit:    BuildCommit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
+
+func (c *API) versionHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		return GetBuildInfo(), http.StatusOK, nil
+	}
+	return fn
+}
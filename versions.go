@@ -0,0 +1,394 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"doco/db"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+)
+
+// ErrBlobVersionNotFound is returned for a ?version= the blob never had
+var ErrBlobVersionNotFound = errors.New("blob version not found")
+
+// ErrInvalidVersion is returned for a ?version= that isn't a positive number
+var ErrInvalidVersion = errors.New("version must be a positive number")
+
+// versionKeyPrefix marks store keys holding an older version of a blob rather than its current contents
+const versionKeyPrefix = "v"
+
+// latestBlobCacheControl makes clients revalidate, with versioning the latest
+// contents under a name can change. Numbered versions stay immutable.
+const latestBlobCacheControl = "private, no-cache"
+
+// blobVersionColumns select everything needed to serve a version except its contents
+var blobVersionColumns = []string{
+	db.BlobVersionColumns.ID,
+	db.BlobVersionColumns.BlobID,
+	db.BlobVersionColumns.Version,
+	db.BlobVersionColumns.MimeType,
+	db.BlobVersionColumns.FileSizeBytes,
+	db.BlobVersionColumns.Extension,
+	db.BlobVersionColumns.Nonce,
+	db.BlobVersionColumns.Compressed,
+	db.BlobVersionColumns.SegmentSize,
+	db.BlobVersionColumns.SealedFinal,
+	db.BlobVersionColumns.Checksum,
+	db.BlobVersionColumns.CreatedAt,
+}
+
+// BlobVersion describes one version of a blob without its contents
+type BlobVersion struct {
+	Version       int64     `json:"version"`
+	MimeType      string    `json:"mime_type"`
+	FileSizeBytes int64     `json:"file_size_bytes"`
+	Checksum      string    `json:"checksum"`
+	CreatedAt     time.Time `json:"created_at"`
+	Latest        bool      `json:"latest"`
+}
+
+// blobVersionKey is the store key for a kept version
+func blobVersionKey(v *db.BlobVersion) string {
+	return versionKeyPrefix + strconv.FormatInt(v.ID.Int64, 10)
+}
+
+// versionParam reads ?version=, zero meaning the latest
+func versionParam(r *http.Request) (int64, error) {
+	v := r.URL.Query().Get("version")
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, ErrInvalidVersion
+	}
+	return n, nil
+}
+
+// latestBlobVersion is the number of a blob's current contents, one past the versions kept
+func (c *API) latestBlobVersion(ctx context.Context, exec boil.ContextExecutor, blobID int64) (int64, error) {
+	var latest int64
+	err := exec.QueryRowContext(ctx, c.conn.Rebind(`SELECT COALESCE(MAX(version), 0) + 1 FROM blob_versions WHERE blob_id = ?`), blobID).Scan(&latest)
+	if err != nil {
+		return 0, fmt.Errorf("blob version: %w", err)
+	}
+	return latest, nil
+}
+
+// blobModTime is when a blob's current contents were stored. created_at never
+// changes, so after versioning replaced them it is content_updated_at.
+func blobModTime(blob *db.Blob) time.Time {
+	if blob.ContentUpdatedAt.Valid {
+		return blob.ContentUpdatedAt.Time
+	}
+	return blob.CreatedAt
+}
+
+// versionView overlays a kept version's contents on its blob, so it is served like the blob itself
+func versionView(blob *db.Blob, v *db.BlobVersion) *db.Blob {
+	view := *blob
+	view.MimeType = v.MimeType
+	view.FileSizeBytes = v.FileSizeBytes
+	view.EXTENSION = v.Extension
+	view.Nonce = v.Nonce
+	view.Compressed = v.Compressed
+	view.SegmentSize = v.SegmentSize
+	view.SealedFinal = v.SealedFinal
+	view.Checksum = v.Checksum
+	view.ContentUpdatedAt = null.TimeFrom(v.CreatedAt)
+	return &view
+}
+
+// blobVersion resolves the request's ?version= to what should be served and the
+// store key holding it. Asking for the latest by number gets the blob itself.
+func (c *API) blobVersion(r *http.Request, blob *db.Blob) (*db.Blob, string, error) {
+	n, err := versionParam(r)
+	if err != nil {
+		return nil, "", err
+	}
+	if n == 0 {
+		return blob, blobKey(blob), nil
+	}
+	v, err := db.BlobVersions(
+		qm.Select(blobVersionColumns...),
+		db.BlobVersionWhere.BlobID.EQ(blob.ID.Int64),
+		db.BlobVersionWhere.Version.EQ(n),
+	).OneG(r.Context())
+	if errors.Is(err, sql.ErrNoRows) {
+		latest, err := c.latestBlobVersion(r.Context(), boil.GetContextDB(), blob.ID.Int64)
+		if err != nil {
+			return nil, "", err
+		}
+		if n != latest {
+			return nil, "", ErrBlobVersionNotFound
+		}
+		return blob, blobKey(blob), nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return versionView(blob, v), blobVersionKey(v), nil
+}
+
+// keepBlobVersion copies a blob's current contents into a new numbered version
+func (c *API) keepBlobVersion(ctx context.Context, blob *db.Blob) (*db.BlobVersion, error) {
+	latest, err := c.latestBlobVersion(ctx, boil.GetContextDB(), blob.ID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	v := &db.BlobVersion{
+		BlobID:        blob.ID.Int64,
+		Version:       latest,
+		MimeType:      blob.MimeType,
+		FileSizeBytes: blob.FileSizeBytes,
+		Extension:     blob.EXTENSION,
+		File:          []byte{},
+		Nonce:         blob.Nonce,
+		Compressed:    blob.Compressed,
+		SegmentSize:   blob.SegmentSize,
+		SealedFinal:   blob.SealedFinal,
+		Checksum:      blob.Checksum,
+		CreatedAt:     blobModTime(blob),
+	}
+	err = insertG(ctx, v, func() error {
+		inserted, err := db.BlobVersions(
+			qm.Select(db.BlobVersionColumns.ID),
+			db.BlobVersionWhere.BlobID.EQ(v.BlobID),
+			db.BlobVersionWhere.Version.EQ(v.Version),
+		).OneG(ctx)
+		if err != nil {
+			return err
+		}
+		v.ID = inserted.ID
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("keep version %d of %s: %w", latest, blob.FileName, err)
+	}
+	err = c.copyStored(ctx, blobKey(blob), blobVersionKey(v))
+	if err != nil {
+		c.dropBlobVersion(ctx, v)
+		return nil, fmt.Errorf("keep version %d of %s: %w", latest, blob.FileName, err)
+	}
+	return v, nil
+}
+
+// copyStored copies stored contents between keys as they are, still encrypted
+func (c *API) copyStored(ctx context.Context, from, to string) error {
+	rc, err := c.store.Get(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return c.store.Put(ctx, to, rc)
+}
+
+// dropBlobVersion forgets a version kept for an update that then failed
+func (c *API) dropBlobVersion(ctx context.Context, v *db.BlobVersion) {
+	err := c.store.Delete(ctx, blobVersionKey(v))
+	if err != nil {
+		c.log.Errorw("blob version cleanup", "blob_id", v.BlobID, "version", v.Version, "err", err)
+	}
+	_, err = v.DeleteG(ctx)
+	if err != nil {
+		c.log.Errorw("blob version cleanup", "blob_id", v.BlobID, "version", v.Version, "err", err)
+	}
+}
+
+// replacedBlobColumns are those a new version of a blob's contents changes
+var replacedBlobColumns = boil.Whitelist(
+	db.BlobColumns.MimeType,
+	db.BlobColumns.FileSizeBytes,
+	db.BlobColumns.EXTENSION,
+	db.BlobColumns.Nonce,
+	db.BlobColumns.Compressed,
+	db.BlobColumns.SegmentSize,
+	db.BlobColumns.SealedFinal,
+	db.BlobColumns.Checksum,
+	db.BlobColumns.ExpiresAt,
+	db.BlobColumns.ContentUpdatedAt,
+	db.BlobColumns.UpdatedAt,
+)
+
+// replaceBlob makes update the latest contents of blob, keeping what was there as
+// a numbered version. The blob keeps its ID, so tags and share links carry over.
+func (c *API) replaceBlob(ctx context.Context, blob, update *db.Blob, ciphertext []byte) (int64, error) {
+	kept, err := c.keepBlobVersion(ctx, blob)
+	if err != nil {
+		return 0, err
+	}
+	// the row and its contents have to change together, or the new metadata
+	// would describe the old ciphertext
+	tx, err := boil.BeginTx(ctx, nil)
+	if err != nil {
+		c.dropBlobVersion(ctx, kept)
+		return 0, err
+	}
+	err = c.updateBlobContents(ctx, tx, blob, update, ciphertext)
+	if err == nil {
+		err = tx.Commit()
+	} else {
+		tx.Rollback()
+	}
+	if err != nil {
+		return 0, c.undoReplace(ctx, blob, kept, err)
+	}
+	return kept.Version + 1, nil
+}
+
+// updateBlobContents gives blob update's contents as part of tx, once its current
+// ones are kept. Stores that can't join tx are written straight away.
+func (c *API) updateBlobContents(ctx context.Context, tx *sql.Tx, blob, update *db.Blob, ciphertext []byte) error {
+	blob.MimeType = update.MimeType
+	blob.FileSizeBytes = update.FileSizeBytes
+	blob.EXTENSION = update.EXTENSION
+	blob.Nonce = update.Nonce
+	blob.Compressed = update.Compressed
+	blob.SegmentSize = update.SegmentSize
+	blob.SealedFinal = update.SealedFinal
+	blob.Checksum = update.Checksum
+	blob.ExpiresAt = update.ExpiresAt
+	blob.ContentUpdatedAt = null.TimeFrom(time.Now().UTC())
+	_, err := blob.Update(ctx, tx, replacedBlobColumns)
+	if err != nil {
+		return err
+	}
+	if p, ok := c.store.(txPutter); ok {
+		return p.PutTx(ctx, tx, blobKey(blob), bytes.NewReader(ciphertext))
+	}
+	return c.store.Put(ctx, blobKey(blob), bytes.NewReader(ciphertext))
+}
+
+// undoReplace forgets the version kept for an update whose transaction failed
+// with err. Contents written outside the transaction are put back first.
+func (c *API) undoReplace(ctx context.Context, blob *db.Blob, kept *db.BlobVersion, err error) error {
+	if _, ok := c.store.(txPutter); !ok {
+		restoreErr := c.copyStored(ctx, blobVersionKey(kept), blobKey(blob))
+		if restoreErr != nil {
+			return fmt.Errorf("%v, restore: %w", err, restoreErr)
+		}
+	}
+	c.dropBlobVersion(ctx, kept)
+	return err
+}
+
+// blobContentKeys are the store keys holding a blob's contents and those of its kept versions
+func blobContentKeys(ctx context.Context, blob *db.Blob) ([]string, error) {
+	versions, err := db.BlobVersions(
+		qm.Select(db.BlobVersionColumns.ID),
+		db.BlobVersionWhere.BlobID.EQ(blob.ID.Int64),
+	).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("blob versions: %w", err)
+	}
+	keys := []string{blobKey(blob)}
+	for _, v := range versions {
+		keys = append(keys, blobVersionKey(v))
+	}
+	return keys, nil
+}
+
+// storedContentKeys is blobContentKeys for every blob at once, as a set
+func storedContentKeys(ctx context.Context) (map[string]bool, error) {
+	blobs, err := db.Blobs(qm.Select(db.BlobColumns.ID)).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("blobs: %w", err)
+	}
+	versions, err := db.BlobVersions(qm.Select(db.BlobVersionColumns.ID)).AllG(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("blob versions: %w", err)
+	}
+	keys := map[string]bool{}
+	for _, blob := range blobs {
+		keys[blobKey(blob)] = true
+	}
+	for _, v := range versions {
+		keys[blobVersionKey(v)] = true
+	}
+	return keys, nil
+}
+
+// isContentKey reports whether a store key is shaped like blobKey or blobVersionKey
+func isContentKey(key string) bool {
+	_, err := strconv.ParseInt(strings.TrimPrefix(key, versionKeyPrefix), 10, 64)
+	return err == nil
+}
+
+// deleteBlobContents removes a blob's stored contents and those of its kept
+// versions, their rows go with the blob's
+func (c *API) deleteBlobContents(ctx context.Context, blob *db.Blob) error {
+	keys, err := blobContentKeys(ctx, blob)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		err = c.store.Delete(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobVersionsHandler lists a blob's versions, newest first
+func (c *API) blobVersionsHandler() func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+	fn := func(w http.ResponseWriter, r *http.Request) (interface{}, int, error) {
+		blob, err := db.Blobs(
+			qm.Select(db.BlobColumns.ID, db.BlobColumns.OwnerID, db.BlobColumns.ExpiresAt, db.BlobColumns.MimeType,
+				db.BlobColumns.FileSizeBytes, db.BlobColumns.Checksum, db.BlobColumns.CreatedAt, db.BlobColumns.ContentUpdatedAt),
+			db.BlobWhere.FileName.EQ(chi.URLParam(r, "blob_id")),
+			notTrashed(),
+		).OneG(r.Context())
+		if errors.Is(err, sql.ErrNoRows) || err == nil && blobExpired(blob, time.Now()) {
+			return nil, http.StatusNotFound, ErrBlobNotFound
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		if !canAccessBlob(r, blob) {
+			return nil, http.StatusForbidden, ErrForbidden
+		}
+		kept, err := db.BlobVersions(
+			qm.Select(blobVersionColumns...),
+			db.BlobVersionWhere.BlobID.EQ(blob.ID.Int64),
+			qm.OrderBy(db.BlobVersionColumns.Version+" DESC"),
+		).AllG(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		latest := int64(1)
+		if len(kept) > 0 {
+			latest = kept[0].Version + 1
+		}
+		versions := []*BlobVersion{{
+			Version:       latest,
+			MimeType:      blob.MimeType,
+			FileSizeBytes: blob.FileSizeBytes,
+			Checksum:      blob.Checksum,
+			CreatedAt:     blobModTime(blob),
+			Latest:        true,
+		}}
+		for _, v := range kept {
+			versions = append(versions, &BlobVersion{
+				Version:       v.Version,
+				MimeType:      v.MimeType,
+				FileSizeBytes: v.FileSizeBytes,
+				Checksum:      v.Checksum,
+				CreatedAt:     v.CreatedAt,
+			})
+		}
+		return versions, http.StatusOK, nil
+	}
+	return fn
+}
@@ -0,0 +1,48 @@
+package doco
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestBlobVersionReupload(t *testing.T) {
+	srv, token, stop := newTestServer(t, ServerConfig{BlobVersioning: true})
+	defer stop()
+	blobURL := srv.URL + DefaultAPIPrefix + "/blobs/notes.txt"
+	first := []byte("first draft")
+	second := []byte("second draft")
+
+	resp, b := uploadBlob(t, srv, token, "notes.txt", first)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("upload: got %d %s", resp.StatusCode, b)
+	}
+	resp, b = uploadBlob(t, srv, token, "notes.txt", second)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("re-upload: got %d %s", resp.StatusCode, b)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []byte
+		code  int
+	}{
+		{"latest", "", second, http.StatusOK},
+		{"first version", "?version=1", first, http.StatusOK},
+		{"latest by number", "?version=2", second, http.StatusOK},
+		{"never uploaded", "?version=3", nil, http.StatusNotFound},
+		{"invalid", "?version=0", nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, b := doRequest(t, token, http.MethodGet, blobURL+tt.query, nil, "")
+			if resp.StatusCode != tt.code {
+				t.Fatalf("got %d %s, want %d", resp.StatusCode, b, tt.code)
+			}
+			if tt.want != nil && !bytes.Equal(b, tt.want) {
+				t.Fatalf("got %q, want %q", b, tt.want)
+			}
+		})
+	}
+}
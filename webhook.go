@@ -0,0 +1,136 @@
+package doco
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// webhookSignatureHeader carries the hex HMAC-SHA256 of the request body, keyed
+// with the webhook secret and prefixed "sha256="
+const webhookSignatureHeader = "X-Doco-Signature"
+
+// webhookEventHeader names the event type, so receivers can route before parsing
+const webhookEventHeader = "X-Doco-Event"
+
+const (
+	// webhookBuffer is how many events may wait for delivery before new ones are dropped
+	webhookBuffer = 256
+	// webhookAttempts is how many times a delivery is tried before it is given up
+	webhookAttempts = 5
+	// webhookBackoff is the wait before the first retry, doubling after each
+	webhookBackoff = time.Second
+	// webhookTimeout bounds each delivery attempt
+	webhookTimeout = 10 * time.Second
+)
+
+// webhookSender posts blob events to a configured URL in the background, one at
+// a time so the receiver sees them in order
+type webhookSender struct {
+	url    string
+	secret []byte
+	client *http.Client
+	queue  chan *Event
+	log    *zap.SugaredLogger
+}
+
+func newWebhookSender(url, secret string, log *zap.SugaredLogger) *webhookSender {
+	return &webhookSender{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan *Event, webhookBuffer),
+		log:    log,
+	}
+}
+
+// webhookSignature signs body with secret, receivers recompute it to check a
+// delivery came from this server
+func webhookSignature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send queues e for delivery without blocking the handler publishing it. An event
+// that doesn't fit is dropped and logged rather than holding up requests.
+func (s *webhookSender) send(e *Event) {
+	select {
+	case s.queue <- e:
+	default:
+		s.log.Warnw("webhook queue full, event dropped", "type", e.Type, "file_name", e.FileName)
+	}
+}
+
+// run delivers queued events until ctx is done
+func (s *webhookSender) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-s.queue:
+			err := s.deliver(ctx, e)
+			if err != nil {
+				s.log.Errorw("webhook delivery failed", "type", e.Type, "file_name", e.FileName, "err", err)
+			}
+		}
+	}
+}
+
+// deliver posts e, retrying with backoff on network errors, 5xx and 429
+func (s *webhookSender) deliver(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	backoff := webhookBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := s.post(ctx, e.Type, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == webhookAttempts {
+			return fmt.Errorf("attempt %d: %w", attempt, err)
+		}
+		s.log.Warnw("webhook delivery failed, retrying", "type", e.Type, "file_name", e.FileName, "attempt", attempt, "backoff", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth retrying
+func (s *webhookSender) post(ctx context.Context, eventType string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, eventType)
+	req.Header.Set(webhookSignatureHeader, webhookSignature(s.secret, body))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	// drain so the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook answered %s", resp.Status)
+}
@@ -0,0 +1,113 @@
+package doco
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsWriteWait bounds each write, a client that stops reading is dropped
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is how long a client may go without answering a ping
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod leaves a ping time to be answered before wsPongWait runs out
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxMessage bounds what clients may send, the channel only pushes
+	wsMaxMessage = 512
+)
+
+// checkOrigin stops other sites' pages from opening a socket with the user's
+// cookie. CORS doesn't cover WebSockets, so the allowed origins are checked here.
+// Clients that aren't browsers send no Origin and are let through.
+func (c *API) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range c.allowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// websocketHandler pushes the same blob events as eventsHandler as JSON text
+// messages. Anything the client sends is ignored. The socket is closed cleanly
+// when the request times out or the server stops, clients should reconnect.
+func (c *API) websocketHandler(ctx context.Context) func(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: c.checkOrigin}
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok {
+			c.writeError(w, r, ErrUnauthorized, http.StatusUnauthorized)
+			return
+		}
+		// a failed upgrade has already been answered
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		events, unsubscribe := c.events.subscribe()
+		defer unsubscribe()
+
+		// the reader answers pings and notices the client going away
+		conn.SetReadLimit(wsMaxMessage)
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		})
+		gone := make(chan struct{})
+		go func() {
+			defer close(gone)
+			for {
+				_, _, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		closeWith := func(code int, reason string) {
+			msg := websocket.FormatCloseMessage(code, reason)
+			conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteWait))
+		}
+		ping := time.NewTicker(wsPingPeriod)
+		defer ping.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				closeWith(websocket.CloseGoingAway, "server stopping")
+				return
+			case <-r.Context().Done():
+				closeWith(websocket.CloseNormalClosure, "")
+				return
+			case <-gone:
+				return
+			case e, ok := <-events:
+				if !ok {
+					closeWith(websocket.CloseTryAgainLater, "fell behind")
+					return
+				}
+				if !e.visibleTo(claims) {
+					continue
+				}
+				conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+				err = conn.WriteJSON(e)
+				if err != nil {
+					return
+				}
+			case <-ping.C:
+				err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait))
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return fn
+}